@@ -19,6 +19,7 @@ const (
 	ClientServerPolicyDefault      string = string(types.CSNegotiationPolicyRequestTCP)
 	PortDefault                    int    = 1247
 	HashSchemeDefault              string = types.HashSchemeDefault
+	MatchHashPolicyDefault         string = string(types.MatchHashPolicyCompatible)
 	EncryptionAlgorithmDefault     string = "AES-256-CBC"
 	EncryptionKeySizeDefault       int    = 32
 	EncryptionSaltSizeDefault      int    = 8
@@ -55,17 +56,27 @@ type Config struct {
 	SSLCertificateChainFile string `json:"irods_ssl_certificate_chain_file,omitempty" yaml:"irods_ssl_certificate_chain_file,omitempty" envconfig:"IRODS_SSL_CERTIFICATE_CHAIN_FILE"`
 	SSLCertificateKeyFile   string `json:"irods_ssl_certificate_key_file,omitempty" yaml:"irods_ssl_certificate_key_file,omitempty" envconfig:"IRODS_SSL_CERTIFICATE_KEY_FILE"`
 	SSLDHParamsFile         string `json:"irods_ssl_dh_params_file,omitempty" yaml:"irods_ssl_dh_params_file,omitempty" envconfig:"IRODS_SSL_DH_PARAMS_FILE"`
+	GSIServerDN             string `json:"irods_gsi_server_dn,omitempty" yaml:"irods_gsi_server_dn,omitempty" envconfig:"IRODS_GSI_SERVER_DN"`
 
 	// go-irodsclient only
 	Password      string `json:"irods_user_password,omitempty" yaml:"irods_user_password,omitempty" envconfig:"IRODS_USER_PASSWORD"`
 	Ticket        string `json:"irods_ticket,omitempty" yaml:"irods_ticket,omitempty" envconfig:"IRODS_TICKET"`
 	PAMToken      string `json:"irods_pam_token,omitempty" yaml:"irods_pam_token,omitempty" envconfig:"IRODS_PAM_TOKEN"`
 	PAMTTL        int    `json:"irods_pam_ttl,omitempty" yaml:"irods_pam_ttl,omitempty" envconfig:"IRODS_PAM_TTL"`
+	AccessToken   string `json:"irods_access_token,omitempty" yaml:"irods_access_token,omitempty" envconfig:"IRODS_ACCESS_TOKEN"`
 	SSLServerName string `json:"irods_ssl_server_name,omitempty" yaml:"irods_ssl_server_name,omitempty" envconfig:"IRODS_SSL_SERVER_NAME"`
 	WebDAVBaseURL string `json:"irods_webdav_base_url,omitempty" yaml:"irods_webdav_base_url,omitempty" envconfig:"IRODS_WEBDAV_BASE_URL"`
 
-	// not used
-	GSIServerDN string `json:"irods_gsi_server_dn,omitempty" yaml:"irods_gsi_server_dn,omitempty" envconfig:"IRODS_GSI_SERVER_DN"`
+	SSLCertificateKeyPassword string `json:"irods_ssl_certificate_key_password,omitempty" yaml:"irods_ssl_certificate_key_password,omitempty" envconfig:"IRODS_SSL_CERTIFICATE_KEY_PASSWORD"`
+
+	KerberosServicePrincipal    string `json:"irods_kerberos_service_principal,omitempty" yaml:"irods_kerberos_service_principal,omitempty" envconfig:"IRODS_KERBEROS_SERVICE_PRINCIPAL"`
+	KerberosPrincipal           string `json:"irods_kerberos_principal,omitempty" yaml:"irods_kerberos_principal,omitempty" envconfig:"IRODS_KERBEROS_PRINCIPAL"`
+	KerberosKeytabFile          string `json:"irods_kerberos_keytab_file,omitempty" yaml:"irods_kerberos_keytab_file,omitempty" envconfig:"IRODS_KERBEROS_KEYTAB_FILE"`
+	KerberosCredentialCacheFile string `json:"irods_kerberos_credential_cache_file,omitempty" yaml:"irods_kerberos_credential_cache_file,omitempty" envconfig:"IRODS_KERBEROS_CREDENTIAL_CACHE_FILE"`
+	KerberosConfigFile          string `json:"irods_kerberos_config_file,omitempty" yaml:"irods_kerberos_config_file,omitempty" envconfig:"IRODS_KERBEROS_CONFIG_FILE"`
+
+	GSIProxyFile         string `json:"irods_gsi_proxy_file,omitempty" yaml:"irods_gsi_proxy_file,omitempty" envconfig:"IRODS_GSI_PROXY_FILE"`
+	GSICACertificatePath string `json:"irods_gsi_ca_certificate_path,omitempty" yaml:"irods_gsi_ca_certificate_path,omitempty" envconfig:"IRODS_GSI_CA_CERTIFICATE_PATH"`
 }
 
 // GetDefaultConfig returns default config
@@ -76,6 +87,7 @@ func GetDefaultConfig() *Config {
 		ClientServerPolicy:      ClientServerPolicyDefault,
 		Port:                    PortDefault,
 		DefaultHashScheme:       HashSchemeDefault,
+		MatchHashPolicy:         MatchHashPolicyDefault,
 		Debug:                   false,
 		LogLevel:                0,
 		EncryptionAlgorithm:     EncryptionAlgorithmDefault,
@@ -215,7 +227,7 @@ func (cfg *Config) FixAuthConfiguration() {
 
 	authScheme := types.GetAuthScheme(cfg.AuthenticationScheme)
 
-	if authScheme != types.AuthSchemeNative {
+	if authScheme.IsSSLRequired() {
 		cfg.ClientServerPolicy = string(types.CSNegotiationPolicyRequestSSL)
 	}
 
@@ -264,9 +276,11 @@ func (cfg *Config) ToIRODSAccount() *types.IRODSAccount {
 		Password:                cfg.Password,
 		DefaultResource:         cfg.DefaultResource,
 		DefaultHashScheme:       cfg.DefaultHashScheme,
+		MatchHashPolicy:         types.GetMatchHashPolicy(cfg.MatchHashPolicy),
 		Ticket:                  cfg.Ticket,
 		PamTTL:                  cfg.PAMTTL,
 		PAMToken:                cfg.PAMToken,
+		AccessToken:             cfg.AccessToken,
 		SSLConfiguration: &types.IRODSSSLConfig{
 			CACertificateFile:       cfg.SSLCACertificateFile,
 			CACertificatePath:       cfg.SSLCACertificatePath,
@@ -277,6 +291,21 @@ func (cfg *Config) ToIRODSAccount() *types.IRODSAccount {
 			VerifyServer:            verifyServer,
 			DHParamsFile:            cfg.SSLDHParamsFile,
 			ServerName:              cfg.SSLServerName,
+			CertificateChainFile:    cfg.SSLCertificateChainFile,
+			CertificateKeyFile:      cfg.SSLCertificateKeyFile,
+			CertificateKeyPassword:  cfg.SSLCertificateKeyPassword,
+		},
+		KerberosConfiguration: &types.IRODSKerberosConfig{
+			ServicePrincipal:    cfg.KerberosServicePrincipal,
+			Principal:           cfg.KerberosPrincipal,
+			KeytabFile:          cfg.KerberosKeytabFile,
+			CredentialCacheFile: cfg.KerberosCredentialCacheFile,
+			ConfigFile:          cfg.KerberosConfigFile,
+		},
+		GSIConfiguration: &types.IRODSGSIConfig{
+			ServerDN:          cfg.GSIServerDN,
+			ProxyFile:         cfg.GSIProxyFile,
+			CACertificatePath: cfg.GSICACertificatePath,
 		},
 	}
 
@@ -292,8 +321,10 @@ func (cfg *Config) ClearICommandsIncompatibleFields() *Config {
 	cfg2.Password = ""
 	cfg2.Ticket = ""
 	cfg2.PAMToken = ""
+	cfg2.AccessToken = ""
 	cfg2.PAMTTL = 0
 	cfg2.SSLServerName = ""
+	cfg2.SSLCertificateKeyPassword = ""
 
 	return &cfg2
 }
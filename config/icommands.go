@@ -120,11 +120,13 @@ func (manager *ICommandsEnvironmentManager) FromIRODSAccount(account *types.IROD
 
 	manager.Environment.Password = account.Password
 	manager.Environment.Ticket = account.Ticket
-	manager.Environment.PAMToken = account.PAMToken
+	manager.Environment.PAMToken = account.GetPAMToken()
+	manager.Environment.AccessToken = account.AccessToken
 	manager.Environment.PAMTTL = account.PamTTL
 
 	manager.Environment.DefaultResource = account.DefaultResource
 	manager.Environment.DefaultHashScheme = account.DefaultHashScheme
+	manager.Environment.MatchHashPolicy = string(account.MatchHashPolicy)
 
 	if account.SSLConfiguration != nil {
 		manager.Environment.SSLCACertificateFile = account.SSLConfiguration.CACertificateFile
@@ -136,6 +138,23 @@ func (manager *ICommandsEnvironmentManager) FromIRODSAccount(account *types.IROD
 		manager.Environment.SSLVerifyServer = string(account.SSLConfiguration.VerifyServer)
 		manager.Environment.SSLDHParamsFile = account.SSLConfiguration.DHParamsFile
 		manager.Environment.SSLServerName = account.SSLConfiguration.ServerName
+		manager.Environment.SSLCertificateChainFile = account.SSLConfiguration.CertificateChainFile
+		manager.Environment.SSLCertificateKeyFile = account.SSLConfiguration.CertificateKeyFile
+		manager.Environment.SSLCertificateKeyPassword = account.SSLConfiguration.CertificateKeyPassword
+	}
+
+	if account.KerberosConfiguration != nil {
+		manager.Environment.KerberosServicePrincipal = account.KerberosConfiguration.ServicePrincipal
+		manager.Environment.KerberosPrincipal = account.KerberosConfiguration.Principal
+		manager.Environment.KerberosKeytabFile = account.KerberosConfiguration.KeytabFile
+		manager.Environment.KerberosCredentialCacheFile = account.KerberosConfiguration.CredentialCacheFile
+		manager.Environment.KerberosConfigFile = account.KerberosConfiguration.ConfigFile
+	}
+
+	if account.GSIConfiguration != nil {
+		manager.Environment.GSIServerDN = account.GSIConfiguration.ServerDN
+		manager.Environment.GSIProxyFile = account.GSIConfiguration.ProxyFile
+		manager.Environment.GSICACertificatePath = account.GSIConfiguration.CACertificatePath
 	}
 
 	manager.FixAuthConfiguration()
@@ -208,8 +227,8 @@ func (manager *ICommandsEnvironmentManager) Load() error {
 		}
 	}
 
-	// read password (.irodsA)
-	if len(manager.PasswordFilePath) > 0 {
+	// read password (.irodsA), krb and gsi do not use it
+	if len(manager.PasswordFilePath) > 0 && types.GetAuthScheme(manager.Environment.AuthenticationScheme).UsePassword() {
 		if util.ExistFile(manager.PasswordFilePath) {
 			logger.Debugf("reading icommands password file %q", manager.PasswordFilePath)
 
@@ -315,26 +334,39 @@ func (manager *ICommandsEnvironmentManager) SaveEnvironment() error {
 		}
 	}
 
-	if len(manager.PasswordFilePath) > 0 {
-		authScheme := types.GetAuthScheme(manager.Environment.AuthenticationScheme)
-
+	authScheme := types.GetAuthScheme(manager.Environment.AuthenticationScheme)
+	if authScheme.UsePassword() {
 		password := manager.Environment.Password
 		if authScheme.IsPAM() {
 			password = manager.Environment.PAMToken
 		}
 
-		obfuscator := NewPasswordObfuscator()
-		obfuscator.SetUID(manager.UID)
-
-		err := obfuscator.EncodeToFile(manager.PasswordFilePath, []byte(password))
+		err := manager.savePasswordFile(password)
 		if err != nil {
-			return errors.Wrapf(err, "failed to encode password to file %q", manager.PasswordFilePath)
+			return err
 		}
 	}
 
 	return nil
 }
 
+// savePasswordFile saves password or pam token to password file (.irodsA)
+func (manager *ICommandsEnvironmentManager) savePasswordFile(password string) error {
+	if len(manager.PasswordFilePath) == 0 {
+		return nil
+	}
+
+	obfuscator := NewPasswordObfuscator()
+	obfuscator.SetUID(manager.UID)
+
+	err := obfuscator.EncodeToFile(manager.PasswordFilePath, []byte(password))
+	if err != nil {
+		return errors.Wrapf(err, "failed to encode password to file %q", manager.PasswordFilePath)
+	}
+
+	return nil
+}
+
 // SaveSession saves session to a dir
 func (manager *ICommandsEnvironmentManager) SaveSession() error {
 	if manager.Session == nil {
@@ -358,5 +390,13 @@ func (manager *ICommandsEnvironmentManager) SaveSession() error {
 		}
 	}
 
+	// persist pam token issued (e.g., by pam_interactive auth) to password file, so following logins reuse it
+	if manager.Environment != nil && types.GetAuthScheme(manager.Environment.AuthenticationScheme).IsPAM() && len(manager.Environment.PAMToken) > 0 {
+		err := manager.savePasswordFile(manager.Environment.PAMToken)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -102,6 +102,8 @@ type FileSystemConfig struct {
 	Cache CacheConfig `yaml:"cache,omitempty" json:"cache,omitempty"`
 
 	AddressResolver session.AddressResolver
+
+	PAMInteractiveHandler *types.PAMInteractiveHandler `yaml:"-" json:"-"` // prompt handler for pam_interactive auth scheme, stdin is used if nil
 }
 
 // NewFileSystemConfig create a FileSystemConfig with a default settings
@@ -113,7 +115,8 @@ func NewFileSystemConfig(applicationName string) *FileSystemConfig {
 		IOConnection:       NewDefaultIOConnectionConfig(),
 		Cache:              NewDefaultCacheConfig(),
 
-		AddressResolver: nil,
+		AddressResolver:       nil,
+		PAMInteractiveHandler: nil,
 	}
 }
 
@@ -21,6 +21,8 @@ type FileHandle struct {
 	entry               *Entry
 	offset              int64
 	openMode            types.FileOpenMode
+	buffer              *fileHandleBuffer      // nil if not buffered
+	readers             chan *fileHandleReader // nil if not opened for concurrent ReadAt
 	mutex               sync.Mutex
 }
 
@@ -87,6 +89,16 @@ func (handle *FileHandle) Close() error {
 	handle.mutex.Lock()
 	defer handle.mutex.Unlock()
 
+	handle.releaseReaders()
+
+	var flushErr error
+	if handle.buffer != nil {
+		handle.releasePrefetch()
+
+		// close the file even if flush fails
+		flushErr = handle.flushWriteBuffer()
+	}
+
 	if handle.irodsFileLockHandle != nil {
 		// unlock if locked
 		err := irods_fs.UnlockDataObject(handle.connection, handle.irodsFileLockHandle)
@@ -107,6 +119,10 @@ func (handle *FileHandle) Close() error {
 		handle.filesystem.cachePropagation.PropagateFileUpdate(handle.entry.Path)
 	}
 
+	if flushErr != nil {
+		return flushErr
+	}
+
 	return err
 }
 
@@ -115,12 +131,28 @@ func (handle *FileHandle) Seek(offset int64, whence int) (int64, error) {
 	handle.mutex.Lock()
 	defer handle.mutex.Unlock()
 
+	if handle.buffer != nil {
+		err := handle.flushWriteBuffer()
+		if err != nil {
+			return handle.offset, err
+		}
+
+		if types.Whence(whence) == types.SeekCur {
+			// the file pointer at the server may differ from the handle's offset
+			offset += handle.offset
+			whence = int(types.SeekSet)
+		}
+	}
+
 	newOffset, err := irods_fs.SeekDataObject(handle.connection, handle.irodsFileHandle, offset, types.Whence(whence))
 	if err != nil {
 		return newOffset, err
 	}
 
 	handle.offset = newOffset
+	if handle.buffer != nil {
+		handle.buffer.connectionOffset = newOffset
+	}
 	return newOffset, nil
 }
 
@@ -129,6 +161,15 @@ func (handle *FileHandle) Truncate(size int64) error {
 	handle.mutex.Lock()
 	defer handle.mutex.Unlock()
 
+	if handle.buffer != nil {
+		err := handle.flushWriteBuffer()
+		if err != nil {
+			return err
+		}
+
+		handle.invalidateReadBuffer()
+	}
+
 	err := irods_fs.TruncateDataObjectHandle(handle.connection, handle.irodsFileHandle, size)
 	if err != nil {
 		return err
@@ -146,6 +187,12 @@ func (handle *FileHandle) Read(buffer []byte) (int, error) {
 		return 0, errors.Errorf("file is opened with %q mode", handle.openMode)
 	}
 
+	if handle.buffer != nil {
+		readLen, err := handle.readBuffered(buffer, handle.offset)
+		handle.offset += int64(readLen)
+		return readLen, err
+	}
+
 	readLen, err := irods_fs.ReadDataObject(handle.connection, handle.irodsFileHandle, buffer)
 	if readLen > 0 {
 		handle.offset += int64(readLen)
@@ -157,6 +204,14 @@ func (handle *FileHandle) Read(buffer []byte) (int, error) {
 
 // ReadAt reads data from given offset
 func (handle *FileHandle) ReadAt(buffer []byte, offset int64) (int, error) {
+	if handle.readers != nil {
+		// does not change the offset of the handle
+		readLen, ok, err := handle.readAtConcurrent(buffer, offset)
+		if ok {
+			return readLen, err
+		}
+	}
+
 	handle.mutex.Lock()
 	defer handle.mutex.Unlock()
 
@@ -164,6 +219,12 @@ func (handle *FileHandle) ReadAt(buffer []byte, offset int64) (int, error) {
 		return 0, errors.Errorf("file is opened with %q mode", handle.openMode)
 	}
 
+	if handle.buffer != nil {
+		readLen, err := handle.readBuffered(buffer, offset)
+		handle.offset = offset + int64(readLen)
+		return readLen, err
+	}
+
 	if handle.offset != offset {
 		newOffset, err := irods_fs.SeekDataObject(handle.connection, handle.irodsFileHandle, offset, types.SeekSet)
 		if err != nil {
@@ -195,6 +256,12 @@ func (handle *FileHandle) Write(data []byte) (int, error) {
 		return 0, errors.Errorf("file is opened with %q mode", handle.openMode)
 	}
 
+	if handle.buffer != nil {
+		writeLen, err := handle.writeBuffered(data, handle.offset)
+		handle.offset += int64(writeLen)
+		return writeLen, err
+	}
+
 	err := irods_fs.WriteDataObject(handle.connection, handle.irodsFileHandle, data)
 	if err != nil {
 		return 0, err
@@ -219,6 +286,12 @@ func (handle *FileHandle) WriteAt(data []byte, offset int64) (int, error) {
 		return 0, errors.Errorf("file is opened with %q mode", handle.openMode)
 	}
 
+	if handle.buffer != nil {
+		writeLen, err := handle.writeBuffered(data, offset)
+		handle.offset = offset + int64(writeLen)
+		return writeLen, err
+	}
+
 	if handle.offset != offset {
 		newOffset, err := irods_fs.SeekDataObject(handle.connection, handle.irodsFileHandle, offset, types.SeekSet)
 		if err != nil {
@@ -308,6 +381,18 @@ func (handle *FileHandle) UnlockDataObject() error {
 
 // preprocessRename should be called before the file is renamed
 func (handle *FileHandle) preprocessRename() error {
+	// readers are not reopened after rename
+	handle.releaseReaders()
+
+	if handle.buffer != nil {
+		handle.releasePrefetch()
+
+		err := handle.flushWriteBuffer()
+		if err != nil {
+			return err
+		}
+	}
+
 	// first, we need to close the file
 	err := irods_fs.CloseDataObject(handle.connection, handle.irodsFileHandle)
 
@@ -360,6 +445,10 @@ func (handle *FileHandle) postprocessRename(newPath string, newEntry *Entry) err
 	handle.irodsFileHandle = newHandle
 	handle.entry = newEntry
 	handle.openMode = newOpenMode
+
+	if handle.buffer != nil {
+		handle.buffer.connectionOffset = handle.offset
+	}
 	return nil
 }
 
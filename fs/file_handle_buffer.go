@@ -0,0 +1,421 @@
+package fs
+
+import (
+	"io"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// FileHandleReadAheadSizeDefault is a default size of read-ahead window
+	FileHandleReadAheadSizeDefault int = common.ReadWriteBufferSize
+	// FileHandleWriteBehindSizeDefault is a default max size of write data to coalesce
+	FileHandleWriteBehindSizeDefault int = common.ReadWriteBufferSize
+)
+
+// FileHandleBufferConfig is a configuration for buffered file handle
+type FileHandleBufferConfig struct {
+	ReadAheadSize   int  // size of read-ahead window for sequential reads, 0 disables read-ahead
+	WriteBehindSize int  // max size of contiguous writes to coalesce, 0 disables write-behind
+	AsyncPrefetch   bool // prefetch the next window in background using another io connection, for read-only mode
+}
+
+// NewDefaultFileHandleBufferConfig creates a default FileHandleBufferConfig
+func NewDefaultFileHandleBufferConfig() *FileHandleBufferConfig {
+	return &FileHandleBufferConfig{
+		ReadAheadSize:   FileHandleReadAheadSizeDefault,
+		WriteBehindSize: FileHandleWriteBehindSizeDefault,
+		AsyncPrefetch:   true,
+	}
+}
+
+// fileHandlePrefetch is a read-ahead request running in background
+type fileHandlePrefetch struct {
+	offset int64
+	data   []byte
+	eof    bool
+	err    error
+	done   chan struct{}
+}
+
+// wait waits for the prefetch to complete
+func (prefetch *fileHandlePrefetch) wait() {
+	<-prefetch.done
+}
+
+// fileHandleBuffer holds read-ahead and write-behind state of a file handle
+// all fields are protected by the mutex of the file handle
+type fileHandleBuffer struct {
+	config *FileHandleBufferConfig
+
+	// offset of the file pointer of the handle's connection at the server
+	connectionOffset int64
+
+	// read-ahead
+	readOffset  int64
+	readData    []byte
+	readEOF     bool
+	lastReadEnd int64
+
+	// async prefetch, uses its own connection and file handle
+	prefetch                   *fileHandlePrefetch
+	prefetchConnection         *connection.IRODSConnection
+	prefetchIRODSFileHandle    *types.IRODSFileHandle
+	prefetchConnectionOffset   int64
+	prefetchConnectionDisabled bool
+
+	// write-behind
+	writeOffset int64
+	writeData   []byte
+}
+
+// OpenFileBuffered opens a file with read-ahead and write-behind buffering, returns a handle
+// buffering reduces round trips for small reads and writes, e.g., FUSE-like or archive reader workloads
+func (fs *FileSystem) OpenFileBuffered(irodsPath string, resource string, mode string, bufferConfig *FileHandleBufferConfig) (*FileHandle, error) {
+	handle, err := fs.OpenFile(irodsPath, resource, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	if bufferConfig == nil {
+		bufferConfig = NewDefaultFileHandleBufferConfig()
+	}
+
+	handle.buffer = &fileHandleBuffer{
+		config:           bufferConfig,
+		connectionOffset: handle.offset,
+		readOffset:       0,
+		readData:         nil,
+		readEOF:          false,
+		lastReadEnd:      handle.offset,
+		writeOffset:      0,
+		writeData:        nil,
+	}
+
+	return handle, nil
+}
+
+// IsBuffered returns true if the file handle is opened with buffering
+func (handle *FileHandle) IsBuffered() bool {
+	return handle.buffer != nil
+}
+
+// Flush writes buffered data to the server
+func (handle *FileHandle) Flush() error {
+	handle.mutex.Lock()
+	defer handle.mutex.Unlock()
+
+	return handle.flushWriteBuffer()
+}
+
+// flushWriteBuffer writes pending write-behind data, the caller must hold the mutex
+func (handle *FileHandle) flushWriteBuffer() error {
+	if handle.buffer == nil || len(handle.buffer.writeData) == 0 {
+		return nil
+	}
+
+	err := handle.seekConnection(handle.buffer.writeOffset)
+	if err != nil {
+		return err
+	}
+
+	err = irods_fs.WriteDataObject(handle.connection, handle.irodsFileHandle, handle.buffer.writeData)
+	if err != nil {
+		return err
+	}
+
+	handle.buffer.connectionOffset += int64(len(handle.buffer.writeData))
+	handle.buffer.writeData = nil
+	return nil
+}
+
+// seekConnection moves the file pointer at the server if required, the caller must hold the mutex
+func (handle *FileHandle) seekConnection(offset int64) error {
+	if handle.buffer.connectionOffset == offset {
+		return nil
+	}
+
+	newOffset, err := irods_fs.SeekDataObject(handle.connection, handle.irodsFileHandle, offset, types.SeekSet)
+	if err != nil {
+		return err
+	}
+
+	handle.buffer.connectionOffset = newOffset
+
+	if newOffset != offset {
+		return errors.Errorf("failed to seek to %d", offset)
+	}
+
+	return nil
+}
+
+// readConnection reads data at the offset from the server, the caller must hold the mutex
+func (handle *FileHandle) readConnection(buffer []byte, offset int64) (int, error) {
+	err := handle.seekConnection(offset)
+	if err != nil {
+		return 0, err
+	}
+
+	readLen, err := irods_fs.ReadDataObject(handle.connection, handle.irodsFileHandle, buffer)
+	if readLen > 0 {
+		handle.buffer.connectionOffset += int64(readLen)
+	}
+
+	return readLen, err
+}
+
+// invalidateReadBuffer drops read-ahead data, the caller must hold the mutex
+func (handle *FileHandle) invalidateReadBuffer() {
+	handle.buffer.readData = nil
+	handle.buffer.readEOF = false
+
+	if handle.buffer.prefetch != nil {
+		handle.buffer.prefetch.wait()
+		handle.buffer.prefetch = nil
+	}
+}
+
+// readBuffered reads data at the offset using read-ahead buffer, the caller must hold the mutex
+func (handle *FileHandle) readBuffered(buffer []byte, offset int64) (int, error) {
+	err := handle.flushWriteBuffer()
+	if err != nil {
+		return 0, err
+	}
+
+	buf := handle.buffer
+	sequential := offset == buf.lastReadEnd
+	readAhead := sequential && buf.config.ReadAheadSize > 0
+
+	totalReadLen := 0
+	for totalReadLen < len(buffer) {
+		curOffset := offset + int64(totalReadLen)
+		readBufferEnd := buf.readOffset + int64(len(buf.readData))
+
+		// from read-ahead buffer
+		if curOffset >= buf.readOffset && curOffset < readBufferEnd {
+			copyLen := copy(buffer[totalReadLen:], buf.readData[curOffset-buf.readOffset:])
+			totalReadLen += copyLen
+			continue
+		}
+
+		if buf.readEOF && curOffset == readBufferEnd {
+			buf.lastReadEnd = curOffset
+			return totalReadLen, io.EOF
+		}
+
+		// from prefetched data
+		if buf.prefetch != nil && buf.prefetch.offset == curOffset {
+			prefetch := buf.prefetch
+			prefetch.wait()
+			buf.prefetch = nil
+
+			if prefetch.err == nil {
+				buf.readOffset = prefetch.offset
+				buf.readData = prefetch.data
+				buf.readEOF = prefetch.eof
+				continue
+			}
+
+			// fall back to read with the handle's connection
+			log.Debugf("failed to prefetch data of %q at %d, %v", handle.entry.Path, prefetch.offset, prefetch.err)
+		}
+
+		if !readAhead {
+			// random access, read only requested
+			readLen, err := handle.readConnection(buffer[totalReadLen:], curOffset)
+			totalReadLen += readLen
+			buf.lastReadEnd = offset + int64(totalReadLen)
+			return totalReadLen, err
+		}
+
+		readSize := len(buffer) - totalReadLen
+		if readSize < buf.config.ReadAheadSize {
+			readSize = buf.config.ReadAheadSize
+		}
+
+		data := make([]byte, readSize)
+		readLen, err := handle.readConnection(data, curOffset)
+		if err != nil && err != io.EOF {
+			buf.readData = nil
+			buf.lastReadEnd = curOffset
+			return totalReadLen, err
+		}
+
+		buf.readOffset = curOffset
+		buf.readData = data[:readLen]
+		buf.readEOF = err == io.EOF
+
+		if readLen == 0 {
+			buf.lastReadEnd = curOffset
+			return totalReadLen, io.EOF
+		}
+	}
+
+	buf.lastReadEnd = offset + int64(totalReadLen)
+
+	if readAhead && buf.config.AsyncPrefetch && !buf.readEOF && handle.IsReadOnlyMode() {
+		handle.startPrefetch(buf.readOffset + int64(len(buf.readData)))
+	}
+
+	return totalReadLen, nil
+}
+
+// startPrefetch starts reading the next window in background, the caller must hold the mutex
+func (handle *FileHandle) startPrefetch(offset int64) {
+	buf := handle.buffer
+
+	if buf.prefetch != nil {
+		if buf.prefetch.offset == offset {
+			// already requested
+			return
+		}
+
+		buf.prefetch.wait()
+		buf.prefetch = nil
+	}
+
+	if buf.prefetchConnectionDisabled {
+		return
+	}
+
+	if buf.prefetchConnection == nil {
+		conn, err := handle.filesystem.ioSession.AcquireConnection(true)
+		if err != nil {
+			log.Debugf("failed to acquire a connection for prefetch, disabling prefetch, %v", err)
+			buf.prefetchConnectionDisabled = true
+			return
+		}
+
+		keywords := map[common.KeyWord]string{}
+		prefetchHandle, prefetchOffset, err := irods_fs.OpenDataObject(conn, handle.irodsFileHandle.Path, handle.irodsFileHandle.Resource, string(types.FileOpenModeReadOnly), keywords)
+		if err != nil {
+			log.Debugf("failed to open data object %q for prefetch, disabling prefetch, %v", handle.irodsFileHandle.Path, err)
+			handle.filesystem.ioSession.ReturnConnection(conn) //nolint
+			buf.prefetchConnectionDisabled = true
+			return
+		}
+
+		buf.prefetchConnection = conn
+		buf.prefetchIRODSFileHandle = prefetchHandle
+		buf.prefetchConnectionOffset = prefetchOffset
+	}
+
+	prefetch := &fileHandlePrefetch{
+		offset: offset,
+		data:   nil,
+		eof:    false,
+		err:    nil,
+		done:   make(chan struct{}),
+	}
+
+	buf.prefetch = prefetch
+
+	conn := buf.prefetchConnection
+	irodsFileHandle := buf.prefetchIRODSFileHandle
+	readSize := buf.config.ReadAheadSize
+
+	// only one prefetch runs at a time, so the prefetch connection offset is safe to update here
+	go func() {
+		defer close(prefetch.done)
+
+		if buf.prefetchConnectionOffset != offset {
+			newOffset, err := irods_fs.SeekDataObject(conn, irodsFileHandle, offset, types.SeekSet)
+			if err != nil {
+				prefetch.err = err
+				return
+			}
+
+			buf.prefetchConnectionOffset = newOffset
+			if newOffset != offset {
+				prefetch.err = errors.Errorf("failed to seek to %d", offset)
+				return
+			}
+		}
+
+		data := make([]byte, readSize)
+		readLen, err := irods_fs.ReadDataObject(conn, irodsFileHandle, data)
+		if readLen > 0 {
+			buf.prefetchConnectionOffset += int64(readLen)
+		}
+
+		if err != nil && err != io.EOF {
+			prefetch.err = err
+			return
+		}
+
+		prefetch.data = data[:readLen]
+		prefetch.eof = err == io.EOF
+	}()
+}
+
+// releasePrefetch waits for prefetch and closes the prefetch connection, the caller must hold the mutex
+func (handle *FileHandle) releasePrefetch() {
+	buf := handle.buffer
+
+	if buf.prefetch != nil {
+		buf.prefetch.wait()
+		buf.prefetch = nil
+	}
+
+	if buf.prefetchConnection != nil {
+		err := irods_fs.CloseDataObject(buf.prefetchConnection, buf.prefetchIRODSFileHandle)
+		if err != nil {
+			log.Debugf("failed to close data object %q opened for prefetch, %v", buf.prefetchIRODSFileHandle.Path, err)
+		}
+
+		handle.filesystem.ioSession.ReturnConnection(buf.prefetchConnection) //nolint
+		buf.prefetchConnection = nil
+		buf.prefetchIRODSFileHandle = nil
+	}
+}
+
+// writeBuffered writes data at the offset using write-behind buffer, the caller must hold the mutex
+func (handle *FileHandle) writeBuffered(data []byte, offset int64) (int, error) {
+	buf := handle.buffer
+
+	// written data makes read-ahead data stale
+	handle.invalidateReadBuffer()
+
+	pendingEnd := buf.writeOffset + int64(len(buf.writeData))
+	contiguous := len(buf.writeData) > 0 && offset == pendingEnd
+
+	if !contiguous || len(buf.writeData)+len(data) > buf.config.WriteBehindSize {
+		err := handle.flushWriteBuffer()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if len(data) >= buf.config.WriteBehindSize {
+		// too large to buffer, write directly
+		err := handle.seekConnection(offset)
+		if err != nil {
+			return 0, err
+		}
+
+		err = irods_fs.WriteDataObject(handle.connection, handle.irodsFileHandle, data)
+		if err != nil {
+			return 0, err
+		}
+
+		buf.connectionOffset += int64(len(data))
+	} else {
+		if len(buf.writeData) == 0 {
+			buf.writeOffset = offset
+			buf.writeData = make([]byte, 0, buf.config.WriteBehindSize)
+		}
+
+		buf.writeData = append(buf.writeData, data...)
+	}
+
+	if handle.entry.Size < offset+int64(len(data)) {
+		handle.entry.Size = offset + int64(len(data))
+	}
+
+	return len(data), nil
+}
@@ -0,0 +1,192 @@
+package fs
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// FileHandleConcurrentReadersDefault is a default number of connections for concurrent ReadAt
+	FileHandleConcurrentReadersDefault int = 4
+)
+
+// fileHandleReader is a reader with its own connection and file handle, used for concurrent ReadAt
+type fileHandleReader struct {
+	connection      *connection.IRODSConnection
+	irodsFileHandle *types.IRODSFileHandle
+	offset          int64
+	replicaToken    bool
+}
+
+// OpenFileConcurrentReader opens a file with read-only mode and extra io connections, returns a handle
+// ReadAt calls on the handle run concurrently, each on one of the connections opening the same replica
+// Read and Seek use the handle's own connection as usual
+func (fs *FileSystem) OpenFileConcurrentReader(irodsPath string, resource string, readers int) (*FileHandle, error) {
+	handle, err := fs.OpenFile(irodsPath, resource, string(types.FileOpenModeReadOnly))
+	if err != nil {
+		return nil, err
+	}
+
+	if readers <= 0 {
+		readers = FileHandleConcurrentReadersDefault
+	}
+
+	// do not wait for connections used by others
+	availableConnections := fs.ioSession.GetAvailableConnections()
+	if readers > availableConnections {
+		readers = availableConnections
+	}
+
+	logger := log.WithFields(log.Fields{
+		"path":    handle.irodsFileHandle.Path,
+		"readers": readers,
+	})
+
+	// replica token makes all readers access the same replica
+	replicaToken := ""
+	resourceHierarchy := ""
+	if handle.connection.SupportParallelUpload() {
+		replicaToken, resourceHierarchy, err = irods_fs.GetReplicaAccessInfo(handle.connection, handle.irodsFileHandle)
+		if err != nil {
+			handle.Close() //nolint
+			return nil, err
+		}
+	}
+
+	readerList := []*fileHandleReader{}
+	for i := 0; i < readers; i++ {
+		conn, err := fs.GetIOConnection(false)
+		if err != nil {
+			// use readers we have
+			logger.Debugf("failed to get a connection for reader %d, %v", i, err)
+			break
+		}
+
+		keywords := map[common.KeyWord]string{}
+
+		var readerHandle *types.IRODSFileHandle
+		var readerOffset int64
+		if len(replicaToken) > 0 {
+			readerHandle, readerOffset, err = irods_fs.OpenDataObjectWithReplicaToken(conn, handle.irodsFileHandle.Path, handle.irodsFileHandle.Resource, string(types.FileOpenModeReadOnly), replicaToken, resourceHierarchy, readers, handle.entry.Size, keywords)
+		} else {
+			readerHandle, readerOffset, err = irods_fs.OpenDataObject(conn, handle.irodsFileHandle.Path, handle.irodsFileHandle.Resource, string(types.FileOpenModeReadOnly), keywords)
+		}
+
+		if err != nil {
+			fs.ReturnIOConnection(conn) //nolint
+
+			for _, reader := range readerList {
+				reader.close(fs)
+			}
+
+			handle.Close() //nolint
+			return nil, err
+		}
+
+		readerList = append(readerList, &fileHandleReader{
+			connection:      conn,
+			irodsFileHandle: readerHandle,
+			offset:          readerOffset,
+			replicaToken:    len(replicaToken) > 0,
+		})
+	}
+
+	if len(readerList) == 0 {
+		// ReadAt falls back to the handle's connection
+		logger.Debug("no connections available for readers, ReadAt will not run concurrently")
+		return handle, nil
+	}
+
+	handle.readers = make(chan *fileHandleReader, len(readerList))
+	for _, reader := range readerList {
+		handle.readers <- reader
+	}
+
+	return handle, nil
+}
+
+// IsConcurrentReader returns true if the file handle is opened for concurrent ReadAt
+func (handle *FileHandle) IsConcurrentReader() bool {
+	return handle.readers != nil
+}
+
+// readAtConcurrent reads data at the offset using one of readers
+// returns false if readers are released
+func (handle *FileHandle) readAtConcurrent(buffer []byte, offset int64) (int, bool, error) {
+	reader, ok := <-handle.readers
+	if !ok {
+		// released
+		return 0, false, nil
+	}
+
+	defer func() {
+		handle.readers <- reader
+	}()
+
+	readLen, err := reader.readAt(buffer, offset)
+	return readLen, true, err
+}
+
+// releaseReaders waits for ongoing ReadAt calls and closes readers, the caller must hold the mutex
+func (handle *FileHandle) releaseReaders() {
+	if handle.readers == nil {
+		return
+	}
+
+	readers := cap(handle.readers)
+	for i := 0; i < readers; i++ {
+		reader, ok := <-handle.readers
+		if !ok {
+			// already released
+			return
+		}
+
+		reader.close(handle.filesystem)
+	}
+
+	close(handle.readers)
+}
+
+// readAt reads data at the offset
+func (reader *fileHandleReader) readAt(buffer []byte, offset int64) (int, error) {
+	if reader.offset != offset {
+		newOffset, err := irods_fs.SeekDataObject(reader.connection, reader.irodsFileHandle, offset, types.SeekSet)
+		if err != nil {
+			return 0, err
+		}
+
+		reader.offset = newOffset
+
+		if newOffset != offset {
+			return 0, errors.Errorf("failed to seek to %d", offset)
+		}
+	}
+
+	readLen, err := irods_fs.ReadDataObject(reader.connection, reader.irodsFileHandle, buffer)
+	if readLen > 0 {
+		reader.offset += int64(readLen)
+	}
+
+	// it is possible to return readLen + EOF
+	return readLen, err
+}
+
+// close closes the file handle of the reader and returns the connection
+func (reader *fileHandleReader) close(fs *FileSystem) {
+	var err error
+	if reader.replicaToken {
+		err = irods_fs.CloseDataObjectReplica(reader.connection, reader.irodsFileHandle)
+	} else {
+		err = irods_fs.CloseDataObject(reader.connection, reader.irodsFileHandle)
+	}
+
+	if err != nil {
+		log.Debugf("failed to close data object %q opened for reader, %v", reader.irodsFileHandle.Path, err)
+	}
+
+	fs.ReturnIOConnection(reader.connection) //nolint
+}
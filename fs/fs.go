@@ -35,6 +35,14 @@ func NewFileSystem(account *types.IRODSAccount, config *FileSystemConfig) (*File
 	var ioSessionConfig *session.IRODSSessionConfig
 	if config != nil {
 		ioSessionConfig = config.ToIOSessionConfig()
+
+		// share the handler between sessions so users are prompted only once
+		// the handler is set on a copy not to modify the account given
+		if account != nil && config.PAMInteractiveHandler != nil {
+			accountCopy := *account
+			accountCopy.SetPAMInteractiveHandler(config.PAMInteractiveHandler)
+			account = &accountCopy
+		}
 	}
 
 	ioSession, err := session.NewIRODSSession(account, ioSessionConfig)
@@ -203,6 +211,45 @@ func (fs *FileSystem) GetMetrics() *metrics.IRODSMetrics {
 func (fs *FileSystem) Stat(irodsPath string) (*Entry, error) {
 	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
 
+	cachedEntry, err := fs.statFromCache(irodsCorrectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if cachedEntry != nil {
+		return cachedEntry, nil
+	}
+
+	// if cache does not exist,
+	// check dir first
+	dirStat, err := fs.getCollectionNoCache(irodsCorrectPath)
+	if err != nil {
+		if !types.IsFileNotFoundError(err) {
+			return nil, err
+		}
+	} else {
+		return dirStat, nil
+	}
+
+	// if it's not dir, check file
+	fileStat, err := fs.getDataObjectNoCache(irodsCorrectPath)
+	if err != nil {
+		if !types.IsFileNotFoundError(err) {
+			return nil, err
+		}
+	} else {
+		return fileStat, nil
+	}
+
+	// not a collection, not a data object
+	fs.cache.AddNegativeEntryCache(irodsCorrectPath)
+	newErr := types.NewFileNotFoundError(irodsCorrectPath)
+	return nil, errors.Wrapf(newErr, "failed to find the data object or the collection for path %q", irodsCorrectPath)
+}
+
+// statFromCache returns file status from cache
+// returns nil entry without error if cache does not have the entry
+func (fs *FileSystem) statFromCache(irodsCorrectPath string) (*Entry, error) {
 	// check if a negative cache for the given path exists
 	if fs.cache.HasNegativeEntryCache(irodsCorrectPath) {
 		// has a negative cache - fail fast
@@ -236,31 +283,7 @@ func (fs *FileSystem) Stat(irodsPath string) (*Entry, error) {
 		}
 	}
 
-	// if cache does not exist,
-	// check dir first
-	dirStat, err := fs.getCollectionNoCache(irodsCorrectPath)
-	if err != nil {
-		if !types.IsFileNotFoundError(err) {
-			return nil, err
-		}
-	} else {
-		return dirStat, nil
-	}
-
-	// if it's not dir, check file
-	fileStat, err := fs.getDataObjectNoCache(irodsCorrectPath)
-	if err != nil {
-		if !types.IsFileNotFoundError(err) {
-			return nil, err
-		}
-	} else {
-		return fileStat, nil
-	}
-
-	// not a collection, not a data object
-	fs.cache.AddNegativeEntryCache(irodsCorrectPath)
-	newErr := types.NewFileNotFoundError(irodsCorrectPath)
-	return nil, errors.Wrapf(newErr, "failed to find the data object or the collection for path %q", irodsCorrectPath)
+	return nil, nil
 }
 
 // StatDir returns status of a directory
@@ -789,6 +812,82 @@ func (fs *FileSystem) ReplicateFile(irodsPath string, resource string, update bo
 	return nil
 }
 
+// PhysicalMoveFile moves a replica of a file from srcResource to destResource, like iphymv
+// adminFlag allows admins to move replicas of other users, like iphymv -M
+func (fs *FileSystem) PhysicalMoveFile(irodsPath string, srcResource string, destResource string, adminFlag bool) error {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	// we use ioSession to acquire connection as it make take a long time
+	conn, err := fs.ioSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.ioSession.ReturnConnection(conn) //nolint
+
+	err = irods_fs.PhysicalMoveDataObject(conn, irodsCorrectPath, srcResource, destResource, adminFlag)
+	if err != nil {
+		return err
+	}
+
+	// replica info is changed
+	fs.InvalidateCacheForFileUpdate(irodsCorrectPath)
+	fs.cachePropagation.PropagateFileUpdate(irodsCorrectPath)
+	return nil
+}
+
+// PhysicalMoveDir moves replicas of all files under the dir from srcResource to destResource recursively, like iphymv -r
+// if srcResource is given, files not having a replica on srcResource are skipped
+// adminFlag allows admins to move replicas of other users, like iphymv -M
+func (fs *FileSystem) PhysicalMoveDir(irodsPath string, srcResource string, destResource string, adminFlag bool) error {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	entry, err := fs.StatDir(irodsCorrectPath)
+	if err != nil {
+		return err
+	}
+
+	// we use ioSession to acquire connection as it make take a long time
+	conn, err := fs.ioSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.ioSession.ReturnConnection(conn) //nolint
+
+	return fs.physicalMoveDirInternal(conn, entry.Path, srcResource, destResource, adminFlag)
+}
+
+func (fs *FileSystem) physicalMoveDirInternal(conn *connection.IRODSConnection, irodsPath string, srcResource string, destResource string, adminFlag bool) error {
+	entries, err := fs.List(irodsPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list dir %q", irodsPath)
+	}
+
+	for _, entry := range entries {
+		if entry.Type == DirectoryEntry {
+			err = fs.physicalMoveDirInternal(conn, entry.Path, srcResource, destResource, adminFlag)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if len(srcResource) > 0 && !entry.HasReplicaOnResource(srcResource) {
+			continue
+		}
+
+		err = irods_fs.PhysicalMoveDataObject(conn, entry.Path, srcResource, destResource, adminFlag)
+		if err != nil {
+			return err
+		}
+
+		// replica info is changed
+		fs.InvalidateCacheForFileUpdate(entry.Path)
+		fs.cachePropagation.PropagateFileUpdate(entry.Path)
+	}
+
+	return nil
+}
+
 // OpenFile opens an existing file for read/write
 func (fs *FileSystem) OpenFile(irodsPath string, resource string, mode string) (*FileHandle, error) {
 	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
@@ -911,6 +1010,11 @@ func (fs *FileSystem) getCollectionNoCache(irodsPath string) (*Entry, error) {
 	}
 	defer fs.metadataSession.ReturnConnection(conn) //nolint
 
+	return fs.getCollectionWithConnectionNoCache(conn, irodsPath)
+}
+
+// getCollectionWithConnectionNoCache returns collection entry
+func (fs *FileSystem) getCollectionWithConnectionNoCache(conn *connection.IRODSConnection, irodsPath string) (*Entry, error) {
 	collection, err := irods_fs.GetCollection(conn, irodsPath)
 	if err != nil {
 		return nil, err
@@ -949,6 +1053,24 @@ func (fs *FileSystem) getCollection(irodsPath string) (*Entry, error) {
 // listEntries lists entries in a collection
 func (fs *FileSystem) listEntries(collPath string) ([]*Entry, error) {
 	// check cache first
+	cachedEntries, ok := fs.listEntriesFromCache(collPath)
+	if ok {
+		return cachedEntries, nil
+	}
+
+	// otherwise, retrieve it and add it to cache
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return nil, err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	return fs.listEntriesWithConnection(conn, collPath)
+}
+
+// listEntriesFromCache lists entries in a collection from cache
+// returns false if cache does not have all entries
+func (fs *FileSystem) listEntriesFromCache(collPath string) ([]*Entry, bool) {
 	cachedEntries := []*Entry{}
 	useCached := false
 
@@ -971,16 +1093,14 @@ func (fs *FileSystem) listEntries(collPath string) ([]*Entry, error) {
 		for _, cachedEntry := range cachedEntries {
 			fs.cache.RemoveNegativeEntryCache(cachedEntry.Path)
 		}
-		return cachedEntries, nil
+		return cachedEntries, true
 	}
 
-	// otherwise, retrieve it and add it to cache
-	conn, err := fs.metadataSession.AcquireConnection(true)
-	if err != nil {
-		return nil, err
-	}
-	defer fs.metadataSession.ReturnConnection(conn) //nolint
+	return nil, false
+}
 
+// listEntriesWithConnection lists entries in a collection and add them to cache
+func (fs *FileSystem) listEntriesWithConnection(conn *connection.IRODSConnection, collPath string) ([]*Entry, error) {
 	collections, err := irods_fs.ListSubCollections(conn, collPath)
 	if err != nil {
 		return nil, err
@@ -2,7 +2,6 @@ package fs
 
 import (
 	"bytes"
-	"encoding/hex"
 	"os"
 	"path/filepath"
 	"time"
@@ -80,6 +79,11 @@ func (fs *FileSystem) DownloadFile(irodsPath string, resource string, localPath
 		if len(entry.CheckSum) == 0 {
 			return fileTransferResult, errors.Errorf("failed to get checksum of the source data object for path %q", irodsSrcPath)
 		}
+
+		_, err = fs.getChecksumAlgorithmForVerification(irodsSrcPath, entry.CheckSumAlgorithm)
+		if err != nil {
+			return fileTransferResult, err
+		}
 	}
 
 	keywords := map[common.KeyWord]string{}
@@ -101,16 +105,12 @@ func (fs *FileSystem) DownloadFile(irodsPath string, resource string, localPath
 
 	if verifyChecksum {
 		// verify checksum
-		_, hash, err := fs.calculateLocalFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
-		if err != nil {
-			return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localFilePath)
-		}
-
-		fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
+		checksumAlgorithm, hash, err := fs.verifyLocalFileChecksum(irodsSrcPath, localFilePath, entry.CheckSumAlgorithm, entry.CheckSum, transferCallback)
+		fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
 		fileTransferResult.LocalCheckSum = hash
 
-		if !bytes.Equal(entry.CheckSum, hash) {
-			return fileTransferResult, errors.Errorf("checksum verification failed, download failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+		if err != nil {
+			return fileTransferResult, errors.Wrapf(err, "checksum verification failed, download failed")
 		}
 	}
 
@@ -166,6 +166,11 @@ func (fs *FileSystem) DownloadFileWithConnection(conn *connection.IRODSConnectio
 		if len(entry.CheckSum) == 0 {
 			return fileTransferResult, errors.Errorf("failed to get checksum of the source data object for path %q", irodsSrcPath)
 		}
+
+		_, err = fs.getChecksumAlgorithmForVerification(irodsSrcPath, entry.CheckSumAlgorithm)
+		if err != nil {
+			return fileTransferResult, err
+		}
 	}
 
 	keywords := map[common.KeyWord]string{}
@@ -187,16 +192,12 @@ func (fs *FileSystem) DownloadFileWithConnection(conn *connection.IRODSConnectio
 
 	if verifyChecksum {
 		// verify checksum
-		_, hash, err := fs.calculateLocalFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
-		if err != nil {
-			return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localFilePath)
-		}
-
-		fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
+		checksumAlgorithm, hash, err := fs.verifyLocalFileChecksum(irodsSrcPath, localFilePath, entry.CheckSumAlgorithm, entry.CheckSum, transferCallback)
+		fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
 		fileTransferResult.LocalCheckSum = hash
 
-		if !bytes.Equal(entry.CheckSum, hash) {
-			return fileTransferResult, errors.Errorf("checksum verification failed, download failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+		if err != nil {
+			return fileTransferResult, errors.Wrapf(err, "checksum verification failed, download failed")
 		}
 	}
 
@@ -252,6 +253,11 @@ func (fs *FileSystem) DownloadFileResumable(irodsPath string, resource string, l
 		if len(entry.CheckSum) == 0 {
 			return fileTransferResult, errors.Errorf("failed to get checksum of the source data object for path %q", irodsSrcPath)
 		}
+
+		_, err = fs.getChecksumAlgorithmForVerification(irodsSrcPath, entry.CheckSumAlgorithm)
+		if err != nil {
+			return fileTransferResult, err
+		}
 	}
 
 	keywords := map[common.KeyWord]string{}
@@ -273,16 +279,12 @@ func (fs *FileSystem) DownloadFileResumable(irodsPath string, resource string, l
 
 	if verifyChecksum {
 		// verify checksum
-		_, hash, err := fs.calculateLocalFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
-		if err != nil {
-			return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localFilePath)
-		}
-
-		fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
+		checksumAlgorithm, hash, err := fs.verifyLocalFileChecksum(irodsSrcPath, localFilePath, entry.CheckSumAlgorithm, entry.CheckSum, transferCallback)
+		fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
 		fileTransferResult.LocalCheckSum = hash
 
-		if !bytes.Equal(entry.CheckSum, hash) {
-			return fileTransferResult, errors.Errorf("checksum verification failed, download failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+		if err != nil {
+			return fileTransferResult, errors.Wrapf(err, "checksum verification failed, download failed")
 		}
 	}
 
@@ -338,6 +340,11 @@ func (fs *FileSystem) DownloadFileResumableWithConnection(conn *connection.IRODS
 		if len(entry.CheckSum) == 0 {
 			return fileTransferResult, errors.Errorf("failed to get checksum of the source data object for path %q", irodsSrcPath)
 		}
+
+		_, err = fs.getChecksumAlgorithmForVerification(irodsSrcPath, entry.CheckSumAlgorithm)
+		if err != nil {
+			return fileTransferResult, err
+		}
 	}
 
 	keywords := map[common.KeyWord]string{}
@@ -359,16 +366,12 @@ func (fs *FileSystem) DownloadFileResumableWithConnection(conn *connection.IRODS
 
 	if verifyChecksum {
 		// verify checksum
-		_, hash, err := fs.calculateLocalFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
-		if err != nil {
-			return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localFilePath)
-		}
-
-		fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
+		checksumAlgorithm, hash, err := fs.verifyLocalFileChecksum(irodsSrcPath, localFilePath, entry.CheckSumAlgorithm, entry.CheckSum, transferCallback)
+		fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
 		fileTransferResult.LocalCheckSum = hash
 
-		if !bytes.Equal(entry.CheckSum, hash) {
-			return fileTransferResult, errors.Errorf("checksum verification failed, download failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+		if err != nil {
+			return fileTransferResult, errors.Wrapf(err, "checksum verification failed, download failed")
 		}
 	}
 
@@ -403,6 +406,11 @@ func (fs *FileSystem) DownloadFileToBuffer(irodsPath string, resource string, bu
 		if len(entry.CheckSum) == 0 {
 			return fileTransferResult, errors.Errorf("failed to get checksum of the source data object for path %q", irodsSrcPath)
 		}
+
+		_, err = fs.getChecksumAlgorithmForVerification(irodsSrcPath, entry.CheckSumAlgorithm)
+		if err != nil {
+			return fileTransferResult, err
+		}
 	}
 
 	keywords := map[common.KeyWord]string{}
@@ -419,16 +427,12 @@ func (fs *FileSystem) DownloadFileToBuffer(irodsPath string, resource string, bu
 
 	if verifyChecksum {
 		// verify checksum
-		_, hash, err := fs.calculateBufferHash(buffer, entry.CheckSumAlgorithm, transferCallback)
-		if err != nil {
-			return fileTransferResult, errors.Wrapf(err, "failed to get hash of buffer data")
-		}
-
-		fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
+		checksumAlgorithm, hash, err := fs.verifyBufferChecksum(irodsSrcPath, buffer, entry.CheckSumAlgorithm, entry.CheckSum, transferCallback)
+		fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
 		fileTransferResult.LocalCheckSum = hash
 
-		if !bytes.Equal(entry.CheckSum, hash) {
-			return fileTransferResult, errors.Errorf("checksum verification failed, download failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+		if err != nil {
+			return fileTransferResult, errors.Wrapf(err, "checksum verification failed, download failed")
 		}
 	}
 
@@ -463,6 +467,11 @@ func (fs *FileSystem) DownloadFileToBufferWithConnection(conn *connection.IRODSC
 		if len(entry.CheckSum) == 0 {
 			return fileTransferResult, errors.Errorf("failed to get checksum of the source data object for path %q", irodsSrcPath)
 		}
+
+		_, err = fs.getChecksumAlgorithmForVerification(irodsSrcPath, entry.CheckSumAlgorithm)
+		if err != nil {
+			return fileTransferResult, err
+		}
 	}
 
 	keywords := map[common.KeyWord]string{}
@@ -479,16 +488,12 @@ func (fs *FileSystem) DownloadFileToBufferWithConnection(conn *connection.IRODSC
 
 	if verifyChecksum {
 		// verify checksum
-		_, hash, err := fs.calculateBufferHash(buffer, entry.CheckSumAlgorithm, transferCallback)
-		if err != nil {
-			return fileTransferResult, errors.Wrapf(err, "failed to get hash of buffer data")
-		}
-
-		fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
+		checksumAlgorithm, hash, err := fs.verifyBufferChecksum(irodsSrcPath, buffer, entry.CheckSumAlgorithm, entry.CheckSum, transferCallback)
+		fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
 		fileTransferResult.LocalCheckSum = hash
 
-		if !bytes.Equal(entry.CheckSum, hash) {
-			return fileTransferResult, errors.Errorf("checksum verification failed, download failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+		if err != nil {
+			return fileTransferResult, errors.Wrapf(err, "checksum verification failed, download failed")
 		}
 	}
 
@@ -544,6 +549,11 @@ func (fs *FileSystem) DownloadFileParallel(irodsPath string, resource string, lo
 		if len(entry.CheckSum) == 0 {
 			return fileTransferResult, errors.Errorf("failed to get checksum of the source data object for path %q", irodsSrcPath)
 		}
+
+		_, err = fs.getChecksumAlgorithmForVerification(irodsSrcPath, entry.CheckSumAlgorithm)
+		if err != nil {
+			return fileTransferResult, err
+		}
 	}
 
 	keywords := map[common.KeyWord]string{}
@@ -565,16 +575,12 @@ func (fs *FileSystem) DownloadFileParallel(irodsPath string, resource string, lo
 
 	if verifyChecksum {
 		// verify checksum
-		_, hash, err := fs.calculateLocalFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
-		if err != nil {
-			return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localFilePath)
-		}
-
-		fileTransferResult.IRODSCheckSumAlgorithm = entry.CheckSumAlgorithm
+		checksumAlgorithm, hash, err := fs.verifyLocalFileChecksum(irodsSrcPath, localFilePath, entry.CheckSumAlgorithm, entry.CheckSum, transferCallback)
+		fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
 		fileTransferResult.LocalCheckSum = hash
 
-		if !bytes.Equal(entry.CheckSum, hash) {
-			return fileTransferResult, errors.Errorf("checksum verification failed, download failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+		if err != nil {
+			return fileTransferResult, errors.Wrapf(err, "checksum verification failed, download failed")
 		}
 	}
 
@@ -630,6 +636,11 @@ func (fs *FileSystem) DownloadFileParallelWithConnections(conns []*connection.IR
 		if len(entry.CheckSum) == 0 {
 			return fileTransferResult, errors.Errorf("failed to get checksum of the source data object for path %q", irodsSrcPath)
 		}
+
+		_, err = fs.getChecksumAlgorithmForVerification(irodsSrcPath, entry.CheckSumAlgorithm)
+		if err != nil {
+			return fileTransferResult, err
+		}
 	}
 
 	keywords := map[common.KeyWord]string{}
@@ -651,16 +662,12 @@ func (fs *FileSystem) DownloadFileParallelWithConnections(conns []*connection.IR
 
 	if verifyChecksum {
 		// verify checksum
-		_, hash, err := fs.calculateLocalFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
-		if err != nil {
-			return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localFilePath)
-		}
-
-		fileTransferResult.IRODSCheckSumAlgorithm = entry.CheckSumAlgorithm
+		checksumAlgorithm, hash, err := fs.verifyLocalFileChecksum(irodsSrcPath, localFilePath, entry.CheckSumAlgorithm, entry.CheckSum, transferCallback)
+		fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
 		fileTransferResult.LocalCheckSum = hash
 
-		if !bytes.Equal(entry.CheckSum, hash) {
-			return fileTransferResult, errors.Errorf("checksum verification failed, download failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+		if err != nil {
+			return fileTransferResult, errors.Wrapf(err, "checksum verification failed, download failed")
 		}
 	}
 
@@ -716,6 +723,11 @@ func (fs *FileSystem) DownloadFileParallelResumable(irodsPath string, resource s
 		if len(entry.CheckSum) == 0 {
 			return fileTransferResult, errors.Errorf("failed to get checksum of the source data object for path %q", irodsSrcPath)
 		}
+
+		_, err = fs.getChecksumAlgorithmForVerification(irodsSrcPath, entry.CheckSumAlgorithm)
+		if err != nil {
+			return fileTransferResult, err
+		}
 	}
 
 	keywords := map[common.KeyWord]string{}
@@ -737,16 +749,12 @@ func (fs *FileSystem) DownloadFileParallelResumable(irodsPath string, resource s
 
 	if verifyChecksum {
 		// verify checksum
-		_, hash, err := fs.calculateLocalFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
-		if err != nil {
-			return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localFilePath)
-		}
-
-		fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
+		checksumAlgorithm, hash, err := fs.verifyLocalFileChecksum(irodsSrcPath, localFilePath, entry.CheckSumAlgorithm, entry.CheckSum, transferCallback)
+		fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
 		fileTransferResult.LocalCheckSum = hash
 
-		if !bytes.Equal(entry.CheckSum, hash) {
-			return fileTransferResult, errors.Errorf("checksum verification failed, download failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+		if err != nil {
+			return fileTransferResult, errors.Wrapf(err, "checksum verification failed, download failed")
 		}
 	}
 
@@ -802,6 +810,11 @@ func (fs *FileSystem) DownloadFileParallelResumableWithConnections(conns []*conn
 		if len(entry.CheckSum) == 0 {
 			return fileTransferResult, errors.Errorf("failed to get checksum of the source data object for path %q", irodsSrcPath)
 		}
+
+		_, err = fs.getChecksumAlgorithmForVerification(irodsSrcPath, entry.CheckSumAlgorithm)
+		if err != nil {
+			return fileTransferResult, err
+		}
 	}
 
 	keywords := map[common.KeyWord]string{}
@@ -823,16 +836,12 @@ func (fs *FileSystem) DownloadFileParallelResumableWithConnections(conns []*conn
 
 	if verifyChecksum {
 		// verify checksum
-		_, hash, err := fs.calculateLocalFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
-		if err != nil {
-			return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localFilePath)
-		}
-
-		fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
+		checksumAlgorithm, hash, err := fs.verifyLocalFileChecksum(irodsSrcPath, localFilePath, entry.CheckSumAlgorithm, entry.CheckSum, transferCallback)
+		fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
 		fileTransferResult.LocalCheckSum = hash
 
-		if !bytes.Equal(entry.CheckSum, hash) {
-			return fileTransferResult, errors.Errorf("checksum verification failed, download failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+		if err != nil {
+			return fileTransferResult, errors.Wrapf(err, "checksum verification failed, download failed")
 		}
 	}
 
@@ -902,16 +911,12 @@ func (fs *FileSystem) DownloadFileRedirectToResource(irodsPath string, resource
 
 	if verifyChecksum {
 		// verify checksum
-		_, hash, err := fs.calculateLocalFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
-		if err != nil {
-			return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localFilePath)
-		}
-
-		fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
+		checksumAlgorithm, hash, err := fs.verifyLocalFileChecksum(irodsSrcPath, localFilePath, entry.CheckSumAlgorithm, entry.CheckSum, transferCallback)
+		fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
 		fileTransferResult.LocalCheckSum = hash
 
-		if !bytes.Equal(entry.CheckSum, hash) {
-			return fileTransferResult, errors.Errorf("checksum verification failed, download failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+		if err != nil {
+			return fileTransferResult, errors.Wrapf(err, "checksum verification failed, download failed")
 		}
 	}
 
@@ -981,16 +986,12 @@ func (fs *FileSystem) DownloadFileRedirectToResourceWithConnection(controlConn *
 
 	if verifyChecksum {
 		// verify checksum
-		_, hash, err := fs.calculateLocalFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
-		if err != nil {
-			return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localFilePath)
-		}
-
-		fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
+		checksumAlgorithm, hash, err := fs.verifyLocalFileChecksum(irodsSrcPath, localFilePath, entry.CheckSumAlgorithm, entry.CheckSum, transferCallback)
+		fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
 		fileTransferResult.LocalCheckSum = hash
 
-		if !bytes.Equal(entry.CheckSum, hash) {
-			return fileTransferResult, errors.Errorf("checksum verification failed, download failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+		if err != nil {
+			return fileTransferResult, errors.Wrapf(err, "checksum verification failed, download failed")
 		}
 	}
 
@@ -1054,7 +1055,8 @@ func (fs *FileSystem) UploadFile(localPath string, irodsPath string, resource st
 
 		// verify checksum
 		alg := types.ChecksumAlgorithmUnknown
-		if entry != nil && entry.CheckSumAlgorithm != types.ChecksumAlgorithmUnknown {
+		if entry != nil && entry.CheckSumAlgorithm != types.ChecksumAlgorithmUnknown && fs.account.MatchHashPolicy != types.MatchHashPolicyStrict {
+			// use the algorithm of the existing data object
 			alg = entry.CheckSumAlgorithm
 		}
 
@@ -1076,7 +1078,7 @@ func (fs *FileSystem) UploadFile(localPath string, irodsPath string, resource st
 
 	err = irods_fs.UploadDataObject(fs.ioSession, localSrcPath, irodsFilePath, resource, replicate, keywords, transferCallback)
 	if err != nil {
-		return fileTransferResult, err
+		return fileTransferResult, fs.checkUploadChecksumError(err, fileTransferResult)
 	}
 
 	if entry == nil {
@@ -1100,17 +1102,15 @@ func (fs *FileSystem) UploadFile(localPath string, irodsPath string, resource st
 
 	if verifyChecksum {
 		if len(entry.CheckSum) > 0 && len(fileTransferResult.LocalCheckSumAlgorithm) > 0 && fileTransferResult.LocalCheckSumAlgorithm != entry.CheckSumAlgorithm {
-			// different algorithm was used
-			_, hash, err := fs.calculateLocalFileHash(localSrcPath, entry.CheckSumAlgorithm, transferCallback)
-			if err != nil {
-				return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localSrcPath)
+			// different algorithm was used, hash again with the algorithm of the data object
+			checksumAlgorithm, hash, err := fs.verifyLocalFileChecksum(irodsFilePath, localSrcPath, entry.CheckSumAlgorithm, entry.CheckSum, transferCallback)
+			if len(hash) > 0 {
+				fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
+				fileTransferResult.LocalCheckSum = hash
 			}
 
-			fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
-			fileTransferResult.LocalCheckSum = hash
-
-			if !bytes.Equal(entry.CheckSum, hash) {
-				return fileTransferResult, errors.Errorf("checksum verification failed, upload failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+			if err != nil {
+				return fileTransferResult, errors.Wrapf(err, "checksum verification failed, upload failed")
 			}
 		}
 	}
@@ -1175,7 +1175,8 @@ func (fs *FileSystem) UploadFileWithConnection(conn *connection.IRODSConnection,
 
 		// verify checksum
 		alg := types.ChecksumAlgorithmUnknown
-		if entry != nil && entry.CheckSumAlgorithm != types.ChecksumAlgorithmUnknown {
+		if entry != nil && entry.CheckSumAlgorithm != types.ChecksumAlgorithmUnknown && fs.account.MatchHashPolicy != types.MatchHashPolicyStrict {
+			// use the algorithm of the existing data object
 			alg = entry.CheckSumAlgorithm
 		}
 
@@ -1197,7 +1198,7 @@ func (fs *FileSystem) UploadFileWithConnection(conn *connection.IRODSConnection,
 
 	err = irods_fs.UploadDataObjectWithConnection(conn, localSrcPath, irodsFilePath, resource, replicate, keywords, transferCallback)
 	if err != nil {
-		return fileTransferResult, err
+		return fileTransferResult, fs.checkUploadChecksumError(err, fileTransferResult)
 	}
 
 	if entry == nil {
@@ -1221,17 +1222,15 @@ func (fs *FileSystem) UploadFileWithConnection(conn *connection.IRODSConnection,
 
 	if verifyChecksum {
 		if len(entry.CheckSum) > 0 && len(fileTransferResult.LocalCheckSumAlgorithm) > 0 && fileTransferResult.LocalCheckSumAlgorithm != entry.CheckSumAlgorithm {
-			// different algorithm was used
-			_, hash, err := fs.calculateLocalFileHash(localSrcPath, entry.CheckSumAlgorithm, transferCallback)
-			if err != nil {
-				return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localSrcPath)
+			// different algorithm was used, hash again with the algorithm of the data object
+			checksumAlgorithm, hash, err := fs.verifyLocalFileChecksum(irodsFilePath, localSrcPath, entry.CheckSumAlgorithm, entry.CheckSum, transferCallback)
+			if len(hash) > 0 {
+				fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
+				fileTransferResult.LocalCheckSum = hash
 			}
 
-			fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
-			fileTransferResult.LocalCheckSum = hash
-
-			if !bytes.Equal(entry.CheckSum, hash) {
-				return fileTransferResult, errors.Errorf("checksum verification failed, upload failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+			if err != nil {
+				return fileTransferResult, errors.Wrapf(err, "checksum verification failed, upload failed")
 			}
 		}
 	}
@@ -1278,7 +1277,8 @@ func (fs *FileSystem) UploadFileFromBuffer(buffer *bytes.Buffer, irodsPath strin
 
 		// verify checksum
 		alg := types.ChecksumAlgorithmUnknown
-		if entry != nil && entry.CheckSumAlgorithm != types.ChecksumAlgorithmUnknown {
+		if entry != nil && entry.CheckSumAlgorithm != types.ChecksumAlgorithmUnknown && fs.account.MatchHashPolicy != types.MatchHashPolicyStrict {
+			// use the algorithm of the existing data object
 			alg = entry.CheckSumAlgorithm
 		}
 
@@ -1300,7 +1300,7 @@ func (fs *FileSystem) UploadFileFromBuffer(buffer *bytes.Buffer, irodsPath strin
 
 	err = irods_fs.UploadDataObjectFromBuffer(fs.ioSession, buffer, irodsFilePath, resource, replicate, keywords, transferCallback)
 	if err != nil {
-		return fileTransferResult, err
+		return fileTransferResult, fs.checkUploadChecksumError(err, fileTransferResult)
 	}
 
 	if entry == nil {
@@ -1324,17 +1324,15 @@ func (fs *FileSystem) UploadFileFromBuffer(buffer *bytes.Buffer, irodsPath strin
 
 	if verifyChecksum {
 		if len(entry.CheckSum) > 0 && len(fileTransferResult.LocalCheckSumAlgorithm) > 0 && fileTransferResult.LocalCheckSumAlgorithm != entry.CheckSumAlgorithm {
-			// different algorithm was used
-			_, hash, err := fs.calculateBufferHash(buffer, entry.CheckSumAlgorithm, transferCallback)
-			if err != nil {
-				return fileTransferResult, errors.Wrapf(err, "failed to get hash of buffer data")
+			// different algorithm was used, hash again with the algorithm of the data object
+			checksumAlgorithm, hash, err := fs.verifyBufferChecksum(irodsFilePath, buffer, entry.CheckSumAlgorithm, entry.CheckSum, transferCallback)
+			if len(hash) > 0 {
+				fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
+				fileTransferResult.LocalCheckSum = hash
 			}
 
-			fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
-			fileTransferResult.LocalCheckSum = hash
-
-			if !bytes.Equal(entry.CheckSum, hash) {
-				return fileTransferResult, errors.Errorf("checksum verification failed, upload failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+			if err != nil {
+				return fileTransferResult, errors.Wrapf(err, "checksum verification failed, upload failed")
 			}
 		}
 	}
@@ -1381,7 +1379,8 @@ func (fs *FileSystem) UploadFileFromBufferWithConnection(conn *connection.IRODSC
 
 		// verify checksum
 		alg := types.ChecksumAlgorithmUnknown
-		if entry != nil && entry.CheckSumAlgorithm != types.ChecksumAlgorithmUnknown {
+		if entry != nil && entry.CheckSumAlgorithm != types.ChecksumAlgorithmUnknown && fs.account.MatchHashPolicy != types.MatchHashPolicyStrict {
+			// use the algorithm of the existing data object
 			alg = entry.CheckSumAlgorithm
 		}
 
@@ -1403,7 +1402,7 @@ func (fs *FileSystem) UploadFileFromBufferWithConnection(conn *connection.IRODSC
 
 	err = irods_fs.UploadDataObjectFromBufferWithConnection(conn, buffer, irodsFilePath, resource, replicate, keywords, transferCallback)
 	if err != nil {
-		return fileTransferResult, err
+		return fileTransferResult, fs.checkUploadChecksumError(err, fileTransferResult)
 	}
 
 	if entry == nil {
@@ -1427,17 +1426,15 @@ func (fs *FileSystem) UploadFileFromBufferWithConnection(conn *connection.IRODSC
 
 	if verifyChecksum {
 		if len(entry.CheckSum) > 0 && len(fileTransferResult.LocalCheckSumAlgorithm) > 0 && fileTransferResult.LocalCheckSumAlgorithm != entry.CheckSumAlgorithm {
-			// different algorithm was used
-			_, hash, err := fs.calculateBufferHash(buffer, entry.CheckSumAlgorithm, transferCallback)
-			if err != nil {
-				return fileTransferResult, errors.Wrapf(err, "failed to get hash of buffer data")
+			// different algorithm was used, hash again with the algorithm of the data object
+			checksumAlgorithm, hash, err := fs.verifyBufferChecksum(irodsFilePath, buffer, entry.CheckSumAlgorithm, entry.CheckSum, transferCallback)
+			if len(hash) > 0 {
+				fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
+				fileTransferResult.LocalCheckSum = hash
 			}
 
-			fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
-			fileTransferResult.LocalCheckSum = hash
-
-			if !bytes.Equal(entry.CheckSum, hash) {
-				return fileTransferResult, errors.Errorf("checksum verification failed, upload failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+			if err != nil {
+				return fileTransferResult, errors.Wrapf(err, "checksum verification failed, upload failed")
 			}
 		}
 	}
@@ -1502,7 +1499,8 @@ func (fs *FileSystem) UploadFileParallel(localPath string, irodsPath string, res
 
 		// verify checksum
 		alg := types.ChecksumAlgorithmUnknown
-		if entry != nil && entry.CheckSumAlgorithm != types.ChecksumAlgorithmUnknown {
+		if entry != nil && entry.CheckSumAlgorithm != types.ChecksumAlgorithmUnknown && fs.account.MatchHashPolicy != types.MatchHashPolicyStrict {
+			// use the algorithm of the existing data object
 			alg = entry.CheckSumAlgorithm
 		}
 
@@ -1524,7 +1522,7 @@ func (fs *FileSystem) UploadFileParallel(localPath string, irodsPath string, res
 
 	err = irods_fs.UploadDataObjectParallel(fs.ioSession, localSrcPath, irodsFilePath, resource, taskNum, replicate, keywords, transferCallback)
 	if err != nil {
-		return fileTransferResult, err
+		return fileTransferResult, fs.checkUploadChecksumError(err, fileTransferResult)
 	}
 
 	if entry == nil {
@@ -1548,17 +1546,15 @@ func (fs *FileSystem) UploadFileParallel(localPath string, irodsPath string, res
 
 	if verifyChecksum {
 		if len(entry.CheckSum) > 0 && len(fileTransferResult.LocalCheckSumAlgorithm) > 0 && fileTransferResult.LocalCheckSumAlgorithm != entry.CheckSumAlgorithm {
-			// different algorithm was used
-			_, hash, err := fs.calculateLocalFileHash(localSrcPath, entry.CheckSumAlgorithm, transferCallback)
-			if err != nil {
-				return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localSrcPath)
+			// different algorithm was used, hash again with the algorithm of the data object
+			checksumAlgorithm, hash, err := fs.verifyLocalFileChecksum(irodsFilePath, localSrcPath, entry.CheckSumAlgorithm, entry.CheckSum, transferCallback)
+			if len(hash) > 0 {
+				fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
+				fileTransferResult.LocalCheckSum = hash
 			}
 
-			fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
-			fileTransferResult.LocalCheckSum = hash
-
-			if !bytes.Equal(entry.CheckSum, hash) {
-				return fileTransferResult, errors.Errorf("checksum verification failed, upload failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+			if err != nil {
+				return fileTransferResult, errors.Wrapf(err, "checksum verification failed, upload failed")
 			}
 		}
 	}
@@ -1623,7 +1619,8 @@ func (fs *FileSystem) UploadFileParallelWithConnections(conns []*connection.IROD
 
 		// verify checksum
 		alg := types.ChecksumAlgorithmUnknown
-		if entry != nil && entry.CheckSumAlgorithm != types.ChecksumAlgorithmUnknown {
+		if entry != nil && entry.CheckSumAlgorithm != types.ChecksumAlgorithmUnknown && fs.account.MatchHashPolicy != types.MatchHashPolicyStrict {
+			// use the algorithm of the existing data object
 			alg = entry.CheckSumAlgorithm
 		}
 
@@ -1644,10 +1641,131 @@ func (fs *FileSystem) UploadFileParallelWithConnections(conns []*connection.IROD
 	}
 
 	err = irods_fs.UploadDataObjectParallelWithConnections(conns, localSrcPath, irodsFilePath, resource, replicate, keywords, transferCallback)
+	if err != nil {
+		return fileTransferResult, fs.checkUploadChecksumError(err, fileTransferResult)
+	}
+
+	if entry == nil {
+		// create
+		fs.InvalidateCacheForFileCreate(irodsFilePath)
+		fs.cachePropagation.PropagateFileCreate(irodsFilePath)
+	} else {
+		// ovewrite update
+		fs.InvalidateCacheForFileUpdate(irodsFilePath)
+		fs.cachePropagation.PropagateFileUpdate(irodsFilePath)
+	}
+
+	entry, err = fs.Stat(irodsFilePath)
 	if err != nil {
 		return fileTransferResult, err
 	}
 
+	fileTransferResult.IRODSCheckSumAlgorithm = entry.CheckSumAlgorithm
+	fileTransferResult.IRODSCheckSum = entry.CheckSum
+	fileTransferResult.IRODSSize = entry.Size
+
+	if verifyChecksum {
+		if len(entry.CheckSum) > 0 && len(fileTransferResult.LocalCheckSumAlgorithm) > 0 && fileTransferResult.LocalCheckSumAlgorithm != entry.CheckSumAlgorithm {
+			// different algorithm was used, hash again with the algorithm of the data object
+			checksumAlgorithm, hash, err := fs.verifyLocalFileChecksum(irodsFilePath, localSrcPath, entry.CheckSumAlgorithm, entry.CheckSum, transferCallback)
+			if len(hash) > 0 {
+				fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
+				fileTransferResult.LocalCheckSum = hash
+			}
+
+			if err != nil {
+				return fileTransferResult, errors.Wrapf(err, "checksum verification failed, upload failed")
+			}
+		}
+	}
+
+	fileTransferResult.EndTime = time.Now()
+
+	return fileTransferResult, nil
+}
+
+// UploadFileResumable uploads a local file to irods with support of transfer resume
+// progress is recorded in a status file next to the local file, calling it again after a failure continues the upload
+func (fs *FileSystem) UploadFileResumable(localPath string, irodsPath string, resource string, replicate bool, verifyChecksum bool, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
+	localSrcPath := util.GetCorrectLocalPath(localPath)
+	irodsDestPath := util.GetCorrectIRODSPath(irodsPath)
+
+	irodsFilePath := irodsDestPath
+
+	fileTransferResult := &FileTransferResult{}
+	fileTransferResult.LocalPath = localSrcPath
+	fileTransferResult.StartTime = time.Now()
+
+	stat, err := os.Stat(localSrcPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// file not exists
+			newErr := errors.Join(err, types.NewFileNotFoundError(localSrcPath))
+			return fileTransferResult, errors.Wrapf(newErr, "failed to find a file for local path %q", localSrcPath)
+		}
+		return fileTransferResult, err
+	}
+
+	if stat.IsDir() {
+		newErr := types.NewFileNotFoundError(localSrcPath)
+		return fileTransferResult, errors.Wrapf(newErr, "failed to find a file for local path %q, the path is for a directory", localSrcPath)
+	}
+
+	entry, err := fs.Stat(irodsDestPath)
+	if err != nil {
+		if !types.IsFileNotFoundError(err) {
+			return fileTransferResult, err
+		}
+	} else {
+		if entry.IsDir() {
+			localFileName := filepath.Base(localSrcPath)
+			irodsFilePath = util.MakeIRODSPath(irodsDestPath, localFileName)
+		} else {
+			// if file exists, truncate the file to the target size
+			if stat.Size() < entry.Size {
+				err := fs.prepareOverwriteFile(irodsDestPath, stat.Size())
+				if err != nil {
+					return fileTransferResult, errors.Wrapf(err, "failed to prepare data object %q for overwrite", irodsDestPath)
+				}
+			}
+		}
+	}
+
+	fileTransferResult.LocalSize = stat.Size()
+	fileTransferResult.IRODSPath = irodsFilePath
+
+	keywords := map[common.KeyWord]string{}
+	if verifyChecksum {
+		keywords[common.REG_CHKSUM_KW] = ""
+
+		// verify checksum
+		alg := types.ChecksumAlgorithmUnknown
+		if entry != nil && entry.CheckSumAlgorithm != types.ChecksumAlgorithmUnknown && fs.account.MatchHashPolicy != types.MatchHashPolicyStrict {
+			// use the algorithm of the existing data object
+			alg = entry.CheckSumAlgorithm
+		}
+
+		checksumAlgorithm, hashBytes, err := fs.calculateLocalFileHash(localSrcPath, alg, transferCallback)
+		if err != nil {
+			return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localSrcPath)
+		}
+
+		hashString, err := types.MakeIRODSChecksumString(checksumAlgorithm, hashBytes)
+		if err != nil {
+			return fileTransferResult, errors.Wrapf(err, "failed to get irods checksum string from algorithm %q", checksumAlgorithm)
+		}
+
+		fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
+		fileTransferResult.LocalCheckSum = hashBytes
+
+		keywords[common.VERIFY_CHKSUM_KW] = hashString
+	}
+
+	err = irods_fs.UploadDataObjectResumable(fs.ioSession, localSrcPath, irodsFilePath, resource, replicate, keywords, transferCallback)
+	if err != nil {
+		return fileTransferResult, fs.checkUploadChecksumError(err, fileTransferResult)
+	}
+
 	if entry == nil {
 		// create
 		fs.InvalidateCacheForFileCreate(irodsFilePath)
@@ -1669,17 +1787,136 @@ func (fs *FileSystem) UploadFileParallelWithConnections(conns []*connection.IROD
 
 	if verifyChecksum {
 		if len(entry.CheckSum) > 0 && len(fileTransferResult.LocalCheckSumAlgorithm) > 0 && fileTransferResult.LocalCheckSumAlgorithm != entry.CheckSumAlgorithm {
-			// different algorithm was used
-			_, hash, err := fs.calculateLocalFileHash(localSrcPath, entry.CheckSumAlgorithm, transferCallback)
+			// different algorithm was used, hash again with the algorithm of the data object
+			checksumAlgorithm, hash, err := fs.verifyLocalFileChecksum(irodsFilePath, localSrcPath, entry.CheckSumAlgorithm, entry.CheckSum, transferCallback)
+			if len(hash) > 0 {
+				fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
+				fileTransferResult.LocalCheckSum = hash
+			}
+
 			if err != nil {
-				return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localSrcPath)
+				return fileTransferResult, errors.Wrapf(err, "checksum verification failed, upload failed")
 			}
+		}
+	}
+
+	fileTransferResult.EndTime = time.Now()
 
-			fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
-			fileTransferResult.LocalCheckSum = hash
+	return fileTransferResult, nil
+}
 
-			if !bytes.Equal(entry.CheckSum, hash) {
-				return fileTransferResult, errors.Errorf("checksum verification failed, upload failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+// UploadFileParallelResumable uploads a local file to irods in parallel with support of transfer resume
+// progress is recorded in a status file next to the local file, calling it again after a failure continues the upload
+func (fs *FileSystem) UploadFileParallelResumable(localPath string, irodsPath string, resource string, taskNum int, replicate bool, verifyChecksum bool, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
+	localSrcPath := util.GetCorrectLocalPath(localPath)
+	irodsDestPath := util.GetCorrectIRODSPath(irodsPath)
+
+	irodsFilePath := irodsDestPath
+
+	fileTransferResult := &FileTransferResult{}
+	fileTransferResult.LocalPath = localSrcPath
+	fileTransferResult.StartTime = time.Now()
+
+	stat, err := os.Stat(localSrcPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// file not exists
+			newErr := errors.Join(err, types.NewFileNotFoundError(localSrcPath))
+			return fileTransferResult, errors.Wrapf(newErr, "failed to find a file for local path %q", localSrcPath)
+		}
+		return fileTransferResult, err
+	}
+
+	if stat.IsDir() {
+		newErr := types.NewFileNotFoundError(localSrcPath)
+		return fileTransferResult, errors.Wrapf(newErr, "failed to find a file for local path %q, the path is for a directory", localSrcPath)
+	}
+
+	entry, err := fs.Stat(irodsDestPath)
+	if err != nil {
+		if !types.IsFileNotFoundError(err) {
+			return fileTransferResult, err
+		}
+	} else {
+		if entry.IsDir() {
+			localFileName := filepath.Base(localSrcPath)
+			irodsFilePath = util.MakeIRODSPath(irodsDestPath, localFileName)
+		} else {
+			// if file exists, truncate the file to the target size
+			if stat.Size() < entry.Size {
+				err := fs.prepareOverwriteFile(irodsDestPath, stat.Size())
+				if err != nil {
+					return fileTransferResult, errors.Wrapf(err, "failed to prepare data object %q for overwrite", irodsDestPath)
+				}
+			}
+		}
+	}
+
+	fileTransferResult.LocalSize = stat.Size()
+	fileTransferResult.IRODSPath = irodsFilePath
+
+	keywords := map[common.KeyWord]string{}
+	if verifyChecksum {
+		keywords[common.REG_CHKSUM_KW] = ""
+
+		// verify checksum
+		alg := types.ChecksumAlgorithmUnknown
+		if entry != nil && entry.CheckSumAlgorithm != types.ChecksumAlgorithmUnknown && fs.account.MatchHashPolicy != types.MatchHashPolicyStrict {
+			// use the algorithm of the existing data object
+			alg = entry.CheckSumAlgorithm
+		}
+
+		checksumAlgorithm, hashBytes, err := fs.calculateLocalFileHash(localSrcPath, alg, transferCallback)
+		if err != nil {
+			return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localSrcPath)
+		}
+
+		hashString, err := types.MakeIRODSChecksumString(checksumAlgorithm, hashBytes)
+		if err != nil {
+			return fileTransferResult, errors.Wrapf(err, "failed to get irods checksum string from algorithm %q", checksumAlgorithm)
+		}
+
+		fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
+		fileTransferResult.LocalCheckSum = hashBytes
+
+		keywords[common.VERIFY_CHKSUM_KW] = hashString
+	}
+
+	err = irods_fs.UploadDataObjectParallelResumable(fs.ioSession, localSrcPath, irodsFilePath, resource, taskNum, replicate, keywords, transferCallback)
+	if err != nil {
+		return fileTransferResult, fs.checkUploadChecksumError(err, fileTransferResult)
+	}
+
+	if entry == nil {
+		// create
+		fs.InvalidateCacheForFileCreate(irodsFilePath)
+		fs.cachePropagation.PropagateFileCreate(irodsFilePath)
+	} else {
+		// ovewrite update
+		fs.InvalidateCacheForFileUpdate(irodsFilePath)
+		fs.cachePropagation.PropagateFileUpdate(irodsFilePath)
+	}
+
+	entry, err = fs.Stat(irodsFilePath)
+	if err != nil {
+		return fileTransferResult, err
+	}
+
+	fileTransferResult.IRODSCheckSumAlgorithm = entry.CheckSumAlgorithm
+	fileTransferResult.IRODSCheckSum = entry.CheckSum
+	fileTransferResult.IRODSSize = entry.Size
+
+	if verifyChecksum {
+		if len(entry.CheckSum) > 0 && len(fileTransferResult.LocalCheckSumAlgorithm) > 0 && fileTransferResult.LocalCheckSumAlgorithm != entry.CheckSumAlgorithm {
+			// different algorithm was used, hash again with the algorithm of the data object
+			checksumAlgorithm, hash, err := fs.verifyLocalFileChecksum(irodsFilePath, localSrcPath, entry.CheckSumAlgorithm, entry.CheckSum, transferCallback)
+			if len(hash) > 0 {
+				fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
+				fileTransferResult.LocalCheckSum = hash
+			}
+
+			if err != nil {
+				return fileTransferResult, errors.Wrapf(err, "checksum verification failed, upload failed")
 			}
 		}
 	}
@@ -1744,7 +1981,8 @@ func (fs *FileSystem) UploadFileRedirectToResource(localPath string, irodsPath s
 
 		// verify checksum
 		alg := types.ChecksumAlgorithmUnknown
-		if entry != nil && entry.CheckSumAlgorithm != types.ChecksumAlgorithmUnknown {
+		if entry != nil && entry.CheckSumAlgorithm != types.ChecksumAlgorithmUnknown && fs.account.MatchHashPolicy != types.MatchHashPolicyStrict {
+			// use the algorithm of the existing data object
 			alg = entry.CheckSumAlgorithm
 		}
 
@@ -1766,7 +2004,7 @@ func (fs *FileSystem) UploadFileRedirectToResource(localPath string, irodsPath s
 
 	err = irods_fs.UploadDataObjectToResourceServer(fs.ioSession, localSrcPath, irodsFilePath, resource, taskNum, replicate, keywords, transferCallback)
 	if err != nil {
-		return fileTransferResult, err
+		return fileTransferResult, fs.checkUploadChecksumError(err, fileTransferResult)
 	}
 
 	if entry == nil {
@@ -1790,17 +2028,15 @@ func (fs *FileSystem) UploadFileRedirectToResource(localPath string, irodsPath s
 
 	if verifyChecksum {
 		if len(entry.CheckSum) > 0 && len(fileTransferResult.LocalCheckSumAlgorithm) > 0 && fileTransferResult.LocalCheckSumAlgorithm != entry.CheckSumAlgorithm {
-			// different algorithm was used
-			_, hash, err := fs.calculateLocalFileHash(localSrcPath, entry.CheckSumAlgorithm, transferCallback)
-			if err != nil {
-				return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localSrcPath)
+			// different algorithm was used, hash again with the algorithm of the data object
+			checksumAlgorithm, hash, err := fs.verifyLocalFileChecksum(irodsFilePath, localSrcPath, entry.CheckSumAlgorithm, entry.CheckSum, transferCallback)
+			if len(hash) > 0 {
+				fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
+				fileTransferResult.LocalCheckSum = hash
 			}
 
-			fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
-			fileTransferResult.LocalCheckSum = hash
-
-			if !bytes.Equal(entry.CheckSum, hash) {
-				return fileTransferResult, errors.Errorf("checksum verification failed, upload failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+			if err != nil {
+				return fileTransferResult, errors.Wrapf(err, "checksum verification failed, upload failed")
 			}
 		}
 	}
@@ -1865,7 +2101,8 @@ func (fs *FileSystem) UploadFileRedirectToResourceWithConnection(controlConn *co
 
 		// verify checksum
 		alg := types.ChecksumAlgorithmUnknown
-		if entry != nil && entry.CheckSumAlgorithm != types.ChecksumAlgorithmUnknown {
+		if entry != nil && entry.CheckSumAlgorithm != types.ChecksumAlgorithmUnknown && fs.account.MatchHashPolicy != types.MatchHashPolicyStrict {
+			// use the algorithm of the existing data object
 			alg = entry.CheckSumAlgorithm
 		}
 
@@ -1887,7 +2124,7 @@ func (fs *FileSystem) UploadFileRedirectToResourceWithConnection(controlConn *co
 
 	err = irods_fs.UploadDataObjectToResourceServerWithConnection(fs.ioSession, controlConn, localSrcPath, irodsFilePath, resource, taskNum, replicate, keywords, transferCallback)
 	if err != nil {
-		return fileTransferResult, err
+		return fileTransferResult, fs.checkUploadChecksumError(err, fileTransferResult)
 	}
 
 	if entry == nil {
@@ -1911,17 +2148,15 @@ func (fs *FileSystem) UploadFileRedirectToResourceWithConnection(controlConn *co
 
 	if verifyChecksum {
 		if len(entry.CheckSum) > 0 && len(fileTransferResult.LocalCheckSumAlgorithm) > 0 && fileTransferResult.LocalCheckSumAlgorithm != entry.CheckSumAlgorithm {
-			// different algorithm was used
-			_, hash, err := fs.calculateLocalFileHash(localSrcPath, entry.CheckSumAlgorithm, transferCallback)
-			if err != nil {
-				return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localSrcPath)
+			// different algorithm was used, hash again with the algorithm of the data object
+			checksumAlgorithm, hash, err := fs.verifyLocalFileChecksum(irodsFilePath, localSrcPath, entry.CheckSumAlgorithm, entry.CheckSum, transferCallback)
+			if len(hash) > 0 {
+				fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
+				fileTransferResult.LocalCheckSum = hash
 			}
 
-			fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
-			fileTransferResult.LocalCheckSum = hash
-
-			if !bytes.Equal(entry.CheckSum, hash) {
-				return fileTransferResult, errors.Errorf("checksum verification failed, upload failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+			if err != nil {
+				return fileTransferResult, errors.Wrapf(err, "checksum verification failed, upload failed")
 			}
 		}
 	}
@@ -1981,6 +2216,89 @@ func (fs *FileSystem) calculateBufferHash(buffer *bytes.Buffer, algorithm types.
 	return algorithm, hashBytes, nil
 }
 
+// getChecksumAlgorithmForVerification returns checksum algorithm to verify data against the checksum of a data object
+// with strict match hash policy, the algorithm of the data object must be the default hash scheme
+func (fs *FileSystem) getChecksumAlgorithmForVerification(irodsPath string, irodsAlgorithm types.ChecksumAlgorithm) (types.ChecksumAlgorithm, error) {
+	defaultAlgorithm := types.GetChecksumAlgorithm(fs.account.DefaultHashScheme)
+
+	if irodsAlgorithm == types.ChecksumAlgorithmUnknown {
+		if defaultAlgorithm == types.ChecksumAlgorithmUnknown {
+			return defaultChecksumAlgorithm, nil
+		}
+		return defaultAlgorithm, nil
+	}
+
+	if fs.account.MatchHashPolicy == types.MatchHashPolicyStrict && defaultAlgorithm != types.ChecksumAlgorithmUnknown && irodsAlgorithm != defaultAlgorithm {
+		newErr := types.NewChecksumMismatchError(irodsPath, irodsAlgorithm, nil, defaultAlgorithm, nil)
+		return types.ChecksumAlgorithmUnknown, errors.Wrapf(newErr, "checksum algorithm %q is not allowed by strict match hash policy", irodsAlgorithm)
+	}
+
+	// compatible, use the algorithm of the data object
+	return irodsAlgorithm, nil
+}
+
+// verifyLocalFileChecksum calculates local file hash with the algorithm of the data object and compares it with the checksum
+func (fs *FileSystem) verifyLocalFileChecksum(irodsPath string, localPath string, irodsAlgorithm types.ChecksumAlgorithm, irodsChecksum []byte, processCallback common.TransferTrackerCallback) (types.ChecksumAlgorithm, []byte, error) {
+	algorithm, err := fs.getChecksumAlgorithmForVerification(irodsPath, irodsAlgorithm)
+	if err != nil {
+		return types.ChecksumAlgorithmUnknown, nil, err
+	}
+
+	algorithm, hash, err := fs.calculateLocalFileHash(localPath, algorithm, processCallback)
+	if err != nil {
+		return types.ChecksumAlgorithmUnknown, nil, errors.Wrapf(err, "failed to get hash of %q", localPath)
+	}
+
+	if !bytes.Equal(irodsChecksum, hash) {
+		newErr := types.NewChecksumMismatchError(irodsPath, irodsAlgorithm, irodsChecksum, algorithm, hash)
+		return algorithm, hash, errors.Wrapf(newErr, "checksum of %q does not match", localPath)
+	}
+
+	return algorithm, hash, nil
+}
+
+// verifyBufferChecksum calculates buffer hash with the algorithm of the data object and compares it with the checksum
+func (fs *FileSystem) verifyBufferChecksum(irodsPath string, buffer *bytes.Buffer, irodsAlgorithm types.ChecksumAlgorithm, irodsChecksum []byte, processCallback common.TransferTrackerCallback) (types.ChecksumAlgorithm, []byte, error) {
+	algorithm, err := fs.getChecksumAlgorithmForVerification(irodsPath, irodsAlgorithm)
+	if err != nil {
+		return types.ChecksumAlgorithmUnknown, nil, err
+	}
+
+	algorithm, hash, err := fs.calculateBufferHash(buffer, algorithm, processCallback)
+	if err != nil {
+		return types.ChecksumAlgorithmUnknown, nil, errors.Wrapf(err, "failed to get hash of buffer data")
+	}
+
+	if !bytes.Equal(irodsChecksum, hash) {
+		newErr := types.NewChecksumMismatchError(irodsPath, irodsAlgorithm, irodsChecksum, algorithm, hash)
+		return algorithm, hash, errors.Wrapf(newErr, "checksum of buffer data does not match")
+	}
+
+	return algorithm, hash, nil
+}
+
+// checkUploadChecksumError converts checksum mismatch reported by the server to ChecksumMismatchError
+// the checksum computed by the server is read from the data object, left empty if not available
+// other errors are returned unchanged
+func (fs *FileSystem) checkUploadChecksumError(err error, fileTransferResult *FileTransferResult) error {
+	errCode := types.GetIRODSErrorCode(err)
+	if errCode != common.USER_CHKSUM_MISMATCH && errCode != common.USER_FILE_SIZE_MISMATCH {
+		return err
+	}
+
+	irodsAlgorithm := types.ChecksumAlgorithmUnknown
+	var irodsChecksum []byte
+
+	entry, statErr := fs.getDataObjectNoCache(fileTransferResult.IRODSPath)
+	if statErr == nil {
+		irodsAlgorithm = entry.CheckSumAlgorithm
+		irodsChecksum = entry.CheckSum
+	}
+
+	newErr := errors.Join(err, types.NewChecksumMismatchError(fileTransferResult.IRODSPath, irodsAlgorithm, irodsChecksum, fileTransferResult.LocalCheckSumAlgorithm, fileTransferResult.LocalCheckSum))
+	return errors.Wrapf(newErr, "checksum verification failed, upload failed")
+}
+
 func (fs *FileSystem) prepareOverwriteFile(irodsPath string, size int64) error {
 	err := fs.TruncateFile(irodsPath, size)
 	if err == nil {
@@ -0,0 +1,235 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+)
+
+const (
+	// files smaller than this are batched for bulk upload
+	bulkUploadFileSizeThreshold int64 = int64(common.BulkOperationBufSize / 4)
+)
+
+// UploadDirBulk uploads a local dir to irods recursively, the local dir is created at irodsPath
+// small files are batched and uploaded with bulk put, others are uploaded one by one
+// returns transfer results of all uploaded files
+func (fs *FileSystem) UploadDirBulk(localPath string, irodsPath string, resource string, force bool, verifyChecksum bool, transferCallback common.TransferTrackerCallback) ([]*FileTransferResult, error) {
+	localSrcPath := util.GetCorrectLocalPath(localPath)
+	irodsDestPath := util.GetCorrectIRODSPath(irodsPath)
+
+	results := []*FileTransferResult{}
+
+	stat, err := os.Stat(localSrcPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// dir not exists
+			newErr := errors.Join(err, types.NewFileNotFoundError(localSrcPath))
+			return results, errors.Wrapf(newErr, "failed to find a dir for local path %q", localSrcPath)
+		}
+		return results, err
+	}
+
+	if !stat.IsDir() {
+		newErr := types.NewFileNotFoundError(localSrcPath)
+		return results, errors.Wrapf(newErr, "failed to find a dir for local path %q, the path is for a file", localSrcPath)
+	}
+
+	err = fs.uploadDirBulkInternal(localSrcPath, irodsDestPath, resource, force, verifyChecksum, transferCallback, &results)
+	if err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
+
+func (fs *FileSystem) uploadDirBulkInternal(localPath string, irodsPath string, resource string, force bool, verifyChecksum bool, transferCallback common.TransferTrackerCallback, results *[]*FileTransferResult) error {
+	err := fs.MakeDir(irodsPath, true)
+	if err != nil {
+		return errors.Wrapf(err, "failed to make dir %q", irodsPath)
+	}
+
+	dirEntries, err := os.ReadDir(localPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read dir %q", localPath)
+	}
+
+	subDirs := []string{}
+	batch := []string{}
+	batchSize := int64(0)
+
+	for _, dirEntry := range dirEntries {
+		entryPath := filepath.Join(localPath, dirEntry.Name())
+
+		if dirEntry.IsDir() {
+			subDirs = append(subDirs, entryPath)
+			continue
+		}
+
+		if !dirEntry.Type().IsRegular() {
+			// skip special files
+			continue
+		}
+
+		info, err := dirEntry.Info()
+		if err != nil {
+			return errors.Wrapf(err, "failed to stat file %q", entryPath)
+		}
+
+		if info.Size() > bulkUploadFileSizeThreshold {
+			// large file, upload it one by one
+			result, err := fs.uploadFileForBulk(entryPath, util.MakeIRODSPath(irodsPath, dirEntry.Name()), resource, force, verifyChecksum, transferCallback)
+			if result != nil {
+				*results = append(*results, result)
+			}
+
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		// file data are sent back to back, the batch size is the sum of file sizes
+		if len(batch) >= common.MaxBulkOperationFiles || batchSize+info.Size() > int64(common.BulkOperationBufSize) {
+			err = fs.uploadFilesBulk(batch, irodsPath, resource, force, verifyChecksum, transferCallback, results)
+			if err != nil {
+				return err
+			}
+
+			batch = []string{}
+			batchSize = 0
+		}
+
+		batch = append(batch, entryPath)
+		batchSize += info.Size()
+	}
+
+	err = fs.uploadFilesBulk(batch, irodsPath, resource, force, verifyChecksum, transferCallback, results)
+	if err != nil {
+		return err
+	}
+
+	for _, subDir := range subDirs {
+		err = fs.uploadDirBulkInternal(subDir, util.MakeIRODSPath(irodsPath, filepath.Base(subDir)), resource, force, verifyChecksum, transferCallback, results)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (fs *FileSystem) uploadFileForBulk(localPath string, irodsPath string, resource string, force bool, verifyChecksum bool, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
+	if !force && fs.ExistsFile(irodsPath) {
+		newErr := types.NewFileAlreadyExistError(irodsPath)
+		return nil, errors.Wrapf(newErr, "failed to upload %q", localPath)
+	}
+
+	return fs.UploadFile(localPath, irodsPath, resource, false, verifyChecksum, transferCallback)
+}
+
+func (fs *FileSystem) uploadFilesBulk(localPaths []string, irodsPath string, resource string, force bool, verifyChecksum bool, transferCallback common.TransferTrackerCallback, results *[]*FileTransferResult) error {
+	if len(localPaths) == 0 {
+		return nil
+	}
+
+	batchResults := map[string]*FileTransferResult{}
+	checksums := map[string]string{}
+
+	for _, localPath := range localPaths {
+		stat, err := os.Stat(localPath)
+		if err != nil {
+			return errors.Wrapf(err, "failed to stat file %q", localPath)
+		}
+
+		irodsFilePath := util.MakeIRODSPath(irodsPath, filepath.Base(localPath))
+
+		result := &FileTransferResult{
+			LocalPath: localPath,
+			LocalSize: stat.Size(),
+			IRODSPath: irodsFilePath,
+			StartTime: time.Now(),
+		}
+
+		if verifyChecksum {
+			checksumAlgorithm, hashBytes, err := fs.calculateLocalFileHash(localPath, types.ChecksumAlgorithmUnknown, nil)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get hash of %q", localPath)
+			}
+
+			hashString, err := types.MakeIRODSChecksumString(checksumAlgorithm, hashBytes)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get irods checksum string from algorithm %q", checksumAlgorithm)
+			}
+
+			result.LocalCheckSumAlgorithm = checksumAlgorithm
+			result.LocalCheckSum = hashBytes
+			checksums[localPath] = hashString
+		}
+
+		batchResults[irodsFilePath] = result
+	}
+
+	// check existing files for cache invalidation
+	existingEntries, err := fs.List(irodsPath)
+	if err != nil {
+		return err
+	}
+
+	existing := map[string]bool{}
+	for _, entry := range existingEntries {
+		existing[entry.Path] = true
+	}
+
+	// we use ioSession to acquire connection as it make take a long time
+	conn, err := fs.ioSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.ioSession.ReturnConnection(conn) //nolint
+
+	err = irods_fs.UploadDataObjectsBulk(conn, localPaths, irodsPath, resource, force, checksums, verifyChecksum, transferCallback)
+	if err != nil {
+		return err
+	}
+
+	for irodsFilePath := range batchResults {
+		if existing[irodsFilePath] {
+			// ovewrite update
+			fs.InvalidateCacheForFileUpdate(irodsFilePath)
+			fs.cachePropagation.PropagateFileUpdate(irodsFilePath)
+		} else {
+			// create
+			fs.InvalidateCacheForFileCreate(irodsFilePath)
+			fs.cachePropagation.PropagateFileCreate(irodsFilePath)
+		}
+	}
+
+	// fill irods side info
+	entries, err := fs.List(irodsPath)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if result, ok := batchResults[entry.Path]; ok {
+			result.IRODSCheckSumAlgorithm = entry.CheckSumAlgorithm
+			result.IRODSCheckSum = entry.CheckSum
+			result.IRODSSize = entry.Size
+		}
+	}
+
+	for _, localPath := range localPaths {
+		result := batchResults[util.MakeIRODSPath(irodsPath, filepath.Base(localPath))]
+		result.EndTime = time.Now()
+		*results = append(*results, result)
+	}
+
+	return nil
+}
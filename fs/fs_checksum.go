@@ -0,0 +1,160 @@
+package fs
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+)
+
+// FileChecksumResult contains the result of checksum computation or verification of a file
+type FileChecksumResult struct {
+	IRODSPath string               `json:"irods_path"`
+	Checksum  *types.IRODSChecksum `json:"checksum,omitempty"`
+	// Mismatch is set when the checksum or the size of a replica does not match the catalog
+	Mismatch bool  `json:"mismatch"`
+	Error    error `json:"-"`
+}
+
+// DirChecksumResult contains the results of checksum computation or verification of files in a dir
+type DirChecksumResult struct {
+	IRODSPath string                `json:"irods_path"`
+	Files     []*FileChecksumResult `json:"files"`
+	StartTime time.Time             `json:"start_time"`
+	EndTime   time.Time             `json:"end_time"`
+}
+
+// GetMismatches returns results of files having mismatching checksums
+func (result *DirChecksumResult) GetMismatches() []*FileChecksumResult {
+	mismatches := []*FileChecksumResult{}
+	for _, file := range result.Files {
+		if file.Mismatch {
+			mismatches = append(mismatches, file)
+		}
+	}
+	return mismatches
+}
+
+// GetFailures returns results of files failed by other reasons than checksum mismatch
+func (result *DirChecksumResult) GetFailures() []*FileChecksumResult {
+	failures := []*FileChecksumResult{}
+	for _, file := range result.Files {
+		if !file.Mismatch && file.Error != nil {
+			failures = append(failures, file)
+		}
+	}
+	return failures
+}
+
+// ComputeFileChecksum computes checksum of a file and registers it, like ichksum
+func (fs *FileSystem) ComputeFileChecksum(irodsPath string, resource string, force bool, allReplicas bool, adminFlag bool) (*types.IRODSChecksum, error) {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	// we use ioSession to acquire connection as it make take a long time
+	conn, err := fs.ioSession.AcquireConnection(true)
+	if err != nil {
+		return nil, err
+	}
+	defer fs.ioSession.ReturnConnection(conn) //nolint
+
+	checksum, err := irods_fs.ComputeDataObjectChecksum(conn, irodsCorrectPath, resource, force, allReplicas, adminFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	fs.InvalidateCacheForFileUpdate(irodsCorrectPath)
+	fs.cachePropagation.PropagateFileUpdate(irodsCorrectPath)
+	return checksum, nil
+}
+
+// VerifyFileChecksum verifies checksum of a file against the catalog, like ichksum -K
+func (fs *FileSystem) VerifyFileChecksum(irodsPath string, resource string, allReplicas bool, adminFlag bool) error {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	// we use ioSession to acquire connection as it make take a long time
+	conn, err := fs.ioSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.ioSession.ReturnConnection(conn) //nolint
+
+	return irods_fs.VerifyDataObjectChecksum(conn, irodsCorrectPath, resource, allReplicas, adminFlag)
+}
+
+// ChecksumDir computes (or verifies if verifyOnly is set) checksums of all files under the dir recursively
+// failures on individual files do not stop the operation, they are reported in the result
+func (fs *FileSystem) ChecksumDir(irodsPath string, resource string, force bool, allReplicas bool, verifyOnly bool, adminFlag bool) (*DirChecksumResult, error) {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	result := &DirChecksumResult{
+		IRODSPath: irodsCorrectPath,
+		Files:     []*FileChecksumResult{},
+		StartTime: time.Now(),
+	}
+
+	entry, err := fs.StatDir(irodsCorrectPath)
+	if err != nil {
+		return result, err
+	}
+
+	// we use ioSession to acquire connection as it make take a long time
+	conn, err := fs.ioSession.AcquireConnection(true)
+	if err != nil {
+		return result, err
+	}
+	defer fs.ioSession.ReturnConnection(conn) //nolint
+
+	err = fs.checksumDirInternal(conn, entry.Path, resource, force, allReplicas, verifyOnly, adminFlag, result)
+	result.EndTime = time.Now()
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+func (fs *FileSystem) checksumDirInternal(conn *connection.IRODSConnection, irodsPath string, resource string, force bool, allReplicas bool, verifyOnly bool, adminFlag bool, result *DirChecksumResult) error {
+	entries, err := fs.List(irodsPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list dir %q", irodsPath)
+	}
+
+	for _, entry := range entries {
+		if entry.Type == DirectoryEntry {
+			err = fs.checksumDirInternal(conn, entry.Path, resource, force, allReplicas, verifyOnly, adminFlag, result)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		fileResult := &FileChecksumResult{
+			IRODSPath: entry.Path,
+		}
+
+		if verifyOnly {
+			fileResult.Error = irods_fs.VerifyDataObjectChecksum(conn, entry.Path, resource, allReplicas, adminFlag)
+		} else {
+			fileResult.Checksum, fileResult.Error = irods_fs.ComputeDataObjectChecksum(conn, entry.Path, resource, force, allReplicas, adminFlag)
+
+			fs.InvalidateCacheForFileUpdate(entry.Path)
+			fs.cachePropagation.PropagateFileUpdate(entry.Path)
+		}
+
+		if fileResult.Error != nil {
+			errCode := types.GetIRODSErrorCode(fileResult.Error)
+			// the server reports verification failures of replicas with CHECK_VERIFICATION_RESULTS
+			if errCode == common.USER_CHKSUM_MISMATCH || errCode == common.USER_FILE_SIZE_MISMATCH || errCode == common.CHECK_VERIFICATION_RESULTS {
+				fileResult.Mismatch = true
+			}
+		}
+
+		result.Files = append(result.Files, fileResult)
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package fs
+
+import (
+	"context"
+	"os"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+)
+
+// GetIOConnectionContext returns irods connection for IO, bound to the given context
+// the connection is not shared, return it with ReturnIOConnection after use
+func (fs *FileSystem) GetIOConnectionContext(ctx context.Context) (*connection.IRODSConnection, error) {
+	return fs.ioSession.AcquireConnectionContext(ctx)
+}
+
+// GetMetadataConnectionContext returns irods connection for metadata operations, bound to the given context
+// the connection is not shared, return it with ReturnMetadataConnection after use
+func (fs *FileSystem) GetMetadataConnectionContext(ctx context.Context) (*connection.IRODSConnection, error) {
+	return fs.metadataSession.AcquireConnectionContext(ctx)
+}
+
+// StatContext returns file status, the operation is aborted when the given context is done
+func (fs *FileSystem) StatContext(ctx context.Context, irodsPath string) (*Entry, error) {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, errors.Wrapf(ctxErr, "failed to stat %q", irodsCorrectPath)
+	}
+
+	cachedEntry, err := fs.statFromCache(irodsCorrectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if cachedEntry != nil {
+		return cachedEntry, nil
+	}
+
+	conn, err := fs.metadataSession.AcquireConnectionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	// check dir first
+	dirStat, err := fs.getCollectionWithConnectionNoCache(conn, irodsCorrectPath)
+	if err != nil {
+		if !types.IsFileNotFoundError(err) {
+			return nil, err
+		}
+	} else {
+		return dirStat, nil
+	}
+
+	// if it's not dir, check file
+	fileStat, err := fs.getDataObjectWithConnectionNoCache(conn, irodsCorrectPath)
+	if err != nil {
+		if !types.IsFileNotFoundError(err) {
+			return nil, err
+		}
+	} else {
+		return fileStat, nil
+	}
+
+	// not a collection, not a data object
+	fs.cache.AddNegativeEntryCache(irodsCorrectPath)
+	newErr := types.NewFileNotFoundError(irodsCorrectPath)
+	return nil, errors.Wrapf(newErr, "failed to find the data object or the collection for path %q", irodsCorrectPath)
+}
+
+// ListContext lists all file system entries under the given path, the operation is aborted when the given context is done
+func (fs *FileSystem) ListContext(ctx context.Context, irodsPath string) ([]*Entry, error) {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, errors.Wrapf(ctxErr, "failed to list %q", irodsCorrectPath)
+	}
+
+	// check cache first
+	cachedEntries, ok := fs.listEntriesFromCache(irodsCorrectPath)
+	if ok {
+		return cachedEntries, nil
+	}
+
+	conn, err := fs.metadataSession.AcquireConnectionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	return fs.listEntriesWithConnection(conn, irodsCorrectPath)
+}
+
+// DownloadFileContext downloads a file to local, the transfer is aborted when the given context is done
+func (fs *FileSystem) DownloadFileContext(ctx context.Context, irodsPath string, resource string, localPath string, verifyChecksum bool, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
+	// stat first to fill the cache with the given context
+	_, err := fs.StatContext(ctx, irodsPath)
+	if err != nil && !types.IsFileNotFoundError(err) {
+		return &FileTransferResult{}, err
+	}
+
+	conn, err := fs.ioSession.AcquireConnectionContext(ctx)
+	if err != nil {
+		return &FileTransferResult{}, err
+	}
+	defer fs.ioSession.ReturnConnection(conn) //nolint
+
+	return fs.DownloadFileWithConnection(conn, irodsPath, resource, localPath, verifyChecksum, transferCallback)
+}
+
+// DownloadFileParallelContext downloads a file to local in parallel, the transfer is aborted when the given context is done
+func (fs *FileSystem) DownloadFileParallelContext(ctx context.Context, irodsPath string, resource string, localPath string, taskNum int, verifyChecksum bool, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
+	entry, err := fs.StatContext(ctx, irodsPath)
+	if err != nil && !types.IsFileNotFoundError(err) {
+		return &FileTransferResult{}, err
+	}
+
+	numTasks := taskNum
+	if numTasks <= 0 && entry != nil {
+		numTasks = util.GetNumTasksForParallelTransfer(entry.Size)
+	}
+
+	if numTasks <= 0 {
+		numTasks = 1
+	}
+
+	conns, err := fs.ioSession.AcquireConnectionsMultiContext(ctx, numTasks)
+	if err != nil && len(conns) == 0 {
+		return &FileTransferResult{}, errors.Wrapf(err, "failed to get %d connections", numTasks)
+	}
+	defer fs.ioSession.ReturnConnectionsMulti(conns) //nolint
+
+	return fs.DownloadFileParallelWithConnections(conns, irodsPath, resource, localPath, verifyChecksum, transferCallback)
+}
+
+// UploadFileContext uploads a local file to irods, the transfer is aborted when the given context is done
+func (fs *FileSystem) UploadFileContext(ctx context.Context, localPath string, irodsPath string, resource string, replicate bool, verifyChecksum bool, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
+	// stat first to fill the cache with the given context
+	_, err := fs.StatContext(ctx, irodsPath)
+	if err != nil && !types.IsFileNotFoundError(err) {
+		return &FileTransferResult{}, err
+	}
+
+	conn, err := fs.ioSession.AcquireConnectionContext(ctx)
+	if err != nil {
+		return &FileTransferResult{}, err
+	}
+	defer fs.ioSession.ReturnConnection(conn) //nolint
+
+	return fs.UploadFileWithConnection(conn, localPath, irodsPath, resource, replicate, verifyChecksum, transferCallback)
+}
+
+// UploadFileParallelContext uploads a local file to irods in parallel, the transfer is aborted when the given context is done
+func (fs *FileSystem) UploadFileParallelContext(ctx context.Context, localPath string, irodsPath string, resource string, taskNum int, replicate bool, verifyChecksum bool, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
+	// stat first to fill the cache with the given context
+	_, err := fs.StatContext(ctx, irodsPath)
+	if err != nil && !types.IsFileNotFoundError(err) {
+		return &FileTransferResult{}, err
+	}
+
+	numTasks := taskNum
+	if numTasks <= 0 {
+		stat, err := os.Stat(localPath)
+		if err != nil {
+			return &FileTransferResult{}, errors.Wrapf(err, "failed to stat local file %q", localPath)
+		}
+
+		numTasks = util.GetNumTasksForParallelTransfer(stat.Size())
+	}
+
+	if numTasks <= 0 {
+		numTasks = 1
+	}
+
+	conns, err := fs.ioSession.AcquireConnectionsMultiContext(ctx, numTasks)
+	if err != nil && len(conns) == 0 {
+		return &FileTransferResult{}, errors.Wrapf(err, "failed to get %d connections", numTasks)
+	}
+	defer fs.ioSession.ReturnConnectionsMulti(conns) //nolint
+
+	return fs.UploadFileParallelWithConnections(conns, localPath, irodsPath, resource, numTasks, replicate, verifyChecksum, transferCallback)
+}
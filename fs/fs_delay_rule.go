@@ -0,0 +1,112 @@
+package fs
+
+import (
+	"time"
+
+	"github.com/cyverse/go-irodsclient/irods/common"
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// GetDelayRule gets delay rule information
+func (fs *FileSystem) GetDelayRule(ruleID int64) (*types.IRODSDelayRule, error) {
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return nil, err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	rule, err := irods_fs.GetDelayRule(conn, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, err
+}
+
+// ListDelayRules lists all delay rules visible to the user
+func (fs *FileSystem) ListDelayRules() ([]*types.IRODSDelayRule, error) {
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return nil, err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	rules, err := irods_fs.ListDelayRules(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, err
+}
+
+// ListDelayRulesForUser lists delay rules owned by the given user
+func (fs *FileSystem) ListDelayRulesForUser(userName string) ([]*types.IRODSDelayRule, error) {
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return nil, err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	rules, err := irods_fs.ListDelayRulesForUser(conn, userName)
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, err
+}
+
+// DeleteDelayRule deletes the delay rule
+func (fs *FileSystem) DeleteDelayRule(ruleID int64) error {
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	return irods_fs.DeleteDelayRule(conn, ruleID)
+}
+
+// ModifyDelayRule modifies the given attributes of the delay rule
+func (fs *FileSystem) ModifyDelayRule(ruleID int64, attributes map[common.KeyWord]string) error {
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	return irods_fs.ModifyDelayRule(conn, ruleID, attributes)
+}
+
+// ModifyDelayRuleExecutionTime modifies the execution time of the delay rule
+func (fs *FileSystem) ModifyDelayRuleExecutionTime(ruleID int64, executionTime time.Time) error {
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	return irods_fs.ModifyDelayRuleExecutionTime(conn, ruleID, executionTime)
+}
+
+// ModifyDelayRuleFrequency modifies the frequency of the delay rule
+func (fs *FileSystem) ModifyDelayRuleFrequency(ruleID int64, frequency string) error {
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	return irods_fs.ModifyDelayRuleFrequency(conn, ruleID, frequency)
+}
+
+// ModifyDelayRulePriority modifies the priority of the delay rule
+func (fs *FileSystem) ModifyDelayRulePriority(ruleID int64, priority int) error {
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	return irods_fs.ModifyDelayRulePriority(conn, ruleID, priority)
+}
@@ -2,6 +2,7 @@ package fs
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/cyverse/go-irodsclient/irods/types"
@@ -130,3 +131,20 @@ func (entry *Entry) ToDataObject() *types.IRODSDataObject {
 		Replicas: replicas,
 	}
 }
+
+// HasReplicaOnResource returns true if the entry has a replica on the given resource
+// the resource can be a leaf resource or a root of resource hierarchy
+func (entry *Entry) HasReplicaOnResource(resource string) bool {
+	for _, replica := range entry.IRODSReplicas {
+		if replica.ResourceName == resource {
+			return true
+		}
+
+		hierarchy := strings.Split(replica.ResourceHierarchy, ";")
+		if len(hierarchy) > 0 && hierarchy[0] == resource {
+			return true
+		}
+	}
+
+	return false
+}
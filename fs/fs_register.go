@@ -0,0 +1,83 @@
+package fs
+
+import (
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+)
+
+// RegisterFile registers a physical file on the resource as a data object, like ireg
+// if asReplica is set, the file is registered as a new replica of the existing data object
+func (fs *FileSystem) RegisterFile(physicalPath string, irodsPath string, resource string, asReplica bool, force bool, registerChecksum bool, verifyChecksum bool) error {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	// we use ioSession to acquire connection as it make take a long time
+	conn, err := fs.ioSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.ioSession.ReturnConnection(conn) //nolint
+
+	err = irods_fs.RegisterDataObject(conn, irodsCorrectPath, physicalPath, resource, asReplica, force, registerChecksum, verifyChecksum)
+	if err != nil {
+		return err
+	}
+
+	if asReplica || force {
+		fs.InvalidateCacheForFileUpdate(irodsCorrectPath)
+		fs.cachePropagation.PropagateFileUpdate(irodsCorrectPath)
+		return nil
+	}
+
+	fs.InvalidateCacheForFileCreate(irodsCorrectPath)
+	fs.cachePropagation.PropagateFileCreate(irodsCorrectPath)
+	return nil
+}
+
+// RegisterDir registers a physical directory on the resource recursively as a collection, like ireg -C
+func (fs *FileSystem) RegisterDir(physicalPath string, irodsPath string, resource string, force bool, registerChecksum bool) error {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	// we use ioSession to acquire connection as it make take a long time
+	conn, err := fs.ioSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.ioSession.ReturnConnection(conn) //nolint
+
+	err = irods_fs.RegisterCollection(conn, irodsCorrectPath, physicalPath, resource, force, registerChecksum)
+	if err != nil {
+		return err
+	}
+
+	// sub-directories and files are registered too
+	fs.cache.RemoveAllNegativeEntryCacheForPath(irodsCorrectPath)
+	fs.cache.RemoveDirCache(irodsCorrectPath)
+	fs.InvalidateCacheForDirCreate(irodsCorrectPath)
+	fs.cachePropagation.PropagateDirCreate(irodsCorrectPath)
+	return nil
+}
+
+// UnregisterFile removes a file from the catalog without deleting its physical file, like irm -U
+func (fs *FileSystem) UnregisterFile(irodsPath string) error {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	err = irods_fs.UnregisterDataObject(conn, irodsCorrectPath)
+	if err != nil {
+		if types.IsFileNotFoundError(err) {
+			fs.InvalidateCacheForFileRemove(irodsCorrectPath)
+			fs.cachePropagation.PropagateFileRemove(irodsCorrectPath)
+		}
+		return err
+	}
+
+	fs.InvalidateCacheForFileRemove(irodsCorrectPath)
+	fs.cachePropagation.PropagateFileRemove(irodsCorrectPath)
+	return nil
+}
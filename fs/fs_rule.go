@@ -0,0 +1,52 @@
+package fs
+
+import (
+	"sort"
+
+	"github.com/cockroachdb/errors"
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// ExecuteRule executes a rule with input parameters and returns output parameters
+// input values are converted to rule parameters by their types (string, int, int64, []byte, map[string]string)
+// outputLabels lists the labels of output parameters to return, ruleExecOut (stdout/stderr) is returned if empty
+// instanceName selects the rule engine plugin instance, e.g., types.RuleEngineInstancePython
+// cache is not invalidated as the effect of the rule is unknown, call ClearCache if needed
+func (fs *FileSystem) ExecuteRule(rule string, inputs map[string]interface{}, outputLabels []string, instanceName string) (*types.IRODSRuleResult, error) {
+	labels := []string{}
+	for label := range inputs {
+		labels = append(labels, label)
+	}
+
+	// keep the order of parameters stable
+	sort.Strings(labels)
+
+	inputParams := []*types.IRODSRuleParameter{}
+	for _, label := range labels {
+		param, err := types.NewIRODSRuleParameter(label, inputs[label])
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert rule input parameter %q", label)
+		}
+
+		inputParams = append(inputParams, param)
+	}
+
+	return fs.ExecuteRuleWithParameters(rule, inputParams, outputLabels, instanceName)
+}
+
+// ExecuteRuleWithParameters executes a rule with typed input parameters and returns output parameters
+func (fs *FileSystem) ExecuteRuleWithParameters(rule string, inputParams []*types.IRODSRuleParameter, outputLabels []string, instanceName string) (*types.IRODSRuleResult, error) {
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return nil, err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	result, err := irods_fs.ExecuteRule(conn, rule, inputParams, outputLabels, instanceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
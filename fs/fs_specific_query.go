@@ -0,0 +1,87 @@
+package fs
+
+import (
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// ExecuteSpecificQuery executes a specific query registered with the alias and returns all rows
+func (fs *FileSystem) ExecuteSpecificQuery(alias string, args []string, zoneName string) ([][]string, error) {
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return nil, err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	rows, err := irods_fs.ExecuteSpecificQuery(conn, alias, args, zoneName)
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// ExecuteSpecificQueryWithHandler executes a specific query registered with the alias and calls rowHandler for each row
+func (fs *FileSystem) ExecuteSpecificQueryWithHandler(alias string, args []string, zoneName string, rowHandler irods_fs.SpecificQueryRowHandler) error {
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	return irods_fs.ExecuteSpecificQueryWithHandler(conn, alias, args, zoneName, rowHandler)
+}
+
+// ListSpecificQueries lists all registered specific queries
+func (fs *FileSystem) ListSpecificQueries() ([]*types.IRODSSpecificQuery, error) {
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return nil, err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	queries, err := irods_fs.ListSpecificQueries(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	return queries, nil
+}
+
+// ListSpecificQueriesByAlias lists registered specific queries matching the alias pattern
+func (fs *FileSystem) ListSpecificQueriesByAlias(aliasPattern string) ([]*types.IRODSSpecificQuery, error) {
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return nil, err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	queries, err := irods_fs.ListSpecificQueriesByAlias(conn, aliasPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return queries, nil
+}
+
+// AddSpecificQuery registers a specific query with the alias, requires admin privilege
+func (fs *FileSystem) AddSpecificQuery(alias string, sql string) error {
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	return irods_fs.AddSpecificQuery(conn, alias, sql)
+}
+
+// RemoveSpecificQuery removes the specific query having the alias, requires admin privilege
+func (fs *FileSystem) RemoveSpecificQuery(alias string) error {
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	return irods_fs.RemoveSpecificQuery(conn, alias)
+}
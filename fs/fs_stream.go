@@ -0,0 +1,237 @@
+package fs
+
+import (
+	"bytes"
+	"hash"
+	"io"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+)
+
+// StreamTransferOptions is options for streaming transfer, UploadFromReader and DownloadToWriter
+type StreamTransferOptions struct {
+	// BufferSize is the size of the buffer used for streaming, memory use is bounded by it
+	BufferSize int
+	// Size is the expected size of data to upload, only used for transfer callback, -1 if unknown
+	Size int64
+	// Resource is the resource to upload to or download from, the default resource is used if it is empty
+	Resource string
+	// Replicate replicates the data object after upload
+	Replicate bool
+	// VerifyChecksum computes checksum of data streamed and verifies it against the checksum of the data object
+	// for upload, the checksum is registered when the data object is closed
+	VerifyChecksum bool
+	// ChecksumAlgorithm is the algorithm to compute checksum of data uploaded
+	// the algorithm of the existing data object or the default hash scheme is used if it is empty
+	ChecksumAlgorithm types.ChecksumAlgorithm
+	// TransferCallback is called to report progress
+	TransferCallback common.TransferTrackerCallback
+}
+
+// NewDefaultStreamTransferOptions creates a new StreamTransferOptions with default values
+func NewDefaultStreamTransferOptions() *StreamTransferOptions {
+	return &StreamTransferOptions{
+		BufferSize:        common.ReadWriteBufferSize,
+		Size:              -1,
+		Resource:          "",
+		Replicate:         false,
+		VerifyChecksum:    true,
+		ChecksumAlgorithm: types.ChecksumAlgorithmUnknown,
+		TransferCallback:  nil,
+	}
+}
+
+// UploadFromReader uploads data read from reader to irods without buffering the whole data
+// checksum is computed while streaming, it is registered and verified when the data object is closed
+// the data object is removed if reading or writing data fails
+func (fs *FileSystem) UploadFromReader(reader io.Reader, irodsPath string, opts *StreamTransferOptions) (*FileTransferResult, error) {
+	irodsFilePath := util.GetCorrectIRODSPath(irodsPath)
+
+	if opts == nil {
+		opts = NewDefaultStreamTransferOptions()
+	}
+
+	fileTransferResult := &FileTransferResult{}
+	fileTransferResult.IRODSPath = irodsFilePath
+	fileTransferResult.StartTime = time.Now()
+
+	entry, err := fs.Stat(irodsFilePath)
+	if err != nil {
+		if !types.IsFileNotFoundError(err) {
+			return fileTransferResult, err
+		}
+	} else {
+		if entry.IsDir() {
+			return fileTransferResult, errors.Errorf("invalid entry type %q. Destination must be a file", entry.Type)
+		}
+	}
+
+	keywords := map[common.KeyWord]string{}
+
+	var hashAlg hash.Hash
+	if opts.VerifyChecksum {
+		// let the server register checksum on close
+		keywords[common.REG_CHKSUM_KW] = ""
+
+		algorithm := opts.ChecksumAlgorithm
+		if algorithm == types.ChecksumAlgorithmUnknown && entry != nil && entry.CheckSumAlgorithm != types.ChecksumAlgorithmUnknown && fs.account.MatchHashPolicy != types.MatchHashPolicyStrict {
+			// use the algorithm of the existing data object
+			algorithm = entry.CheckSumAlgorithm
+		}
+
+		if algorithm == types.ChecksumAlgorithmUnknown {
+			algorithm = types.GetChecksumAlgorithm(fs.account.DefaultHashScheme)
+		}
+
+		if algorithm == types.ChecksumAlgorithmUnknown {
+			algorithm = defaultChecksumAlgorithm
+		}
+
+		hashAlg, err = util.GetHashAlgorithm(string(algorithm))
+		if err != nil {
+			return fileTransferResult, errors.Wrapf(err, "failed to get hash algorithm %q", algorithm)
+		}
+
+		fileTransferResult.LocalCheckSumAlgorithm = algorithm
+
+		// hash data as it is read
+		reader = io.TeeReader(reader, hashAlg)
+	}
+
+	size, err := irods_fs.UploadDataObjectFromReader(fs.ioSession, reader, irodsFilePath, opts.Resource, opts.Size, opts.BufferSize, opts.Replicate, keywords, opts.TransferCallback)
+	fileTransferResult.LocalSize = size
+
+	if hashAlg != nil {
+		fileTransferResult.LocalCheckSum = hashAlg.Sum(nil)
+	}
+
+	if err != nil {
+		// partial data object is removed
+		fs.InvalidateCacheForFileRemove(irodsFilePath)
+		fs.cachePropagation.PropagateFileRemove(irodsFilePath)
+
+		return fileTransferResult, fs.checkUploadChecksumError(err, fileTransferResult)
+	}
+
+	if entry == nil {
+		// create
+		fs.InvalidateCacheForFileCreate(irodsFilePath)
+		fs.cachePropagation.PropagateFileCreate(irodsFilePath)
+	} else {
+		// ovewrite update
+		fs.InvalidateCacheForFileUpdate(irodsFilePath)
+		fs.cachePropagation.PropagateFileUpdate(irodsFilePath)
+	}
+
+	entry, err = fs.Stat(irodsFilePath)
+	if err != nil {
+		return fileTransferResult, err
+	}
+
+	fileTransferResult.IRODSCheckSumAlgorithm = entry.CheckSumAlgorithm
+	fileTransferResult.IRODSCheckSum = entry.CheckSum
+	fileTransferResult.IRODSSize = entry.Size
+
+	if opts.VerifyChecksum {
+		if entry.Size != fileTransferResult.LocalSize {
+			newErr := types.NewChecksumMismatchError(irodsFilePath, entry.CheckSumAlgorithm, entry.CheckSum, fileTransferResult.LocalCheckSumAlgorithm, fileTransferResult.LocalCheckSum)
+			return fileTransferResult, errors.Wrapf(newErr, "size of data object %q (%d) does not match %d bytes uploaded", irodsFilePath, entry.Size, fileTransferResult.LocalSize)
+		}
+
+		if len(entry.CheckSum) == 0 {
+			return fileTransferResult, errors.Errorf("failed to get checksum of the data object for path %q", irodsFilePath)
+		}
+
+		if entry.CheckSumAlgorithm != fileTransferResult.LocalCheckSumAlgorithm {
+			// data streamed cannot be hashed again with another algorithm
+			newErr := types.NewChecksumMismatchError(irodsFilePath, entry.CheckSumAlgorithm, entry.CheckSum, fileTransferResult.LocalCheckSumAlgorithm, fileTransferResult.LocalCheckSum)
+			return fileTransferResult, errors.Wrapf(newErr, "checksum algorithm %q of data object %q differs from %q used for streaming", entry.CheckSumAlgorithm, irodsFilePath, fileTransferResult.LocalCheckSumAlgorithm)
+		}
+
+		if !bytes.Equal(entry.CheckSum, fileTransferResult.LocalCheckSum) {
+			newErr := types.NewChecksumMismatchError(irodsFilePath, entry.CheckSumAlgorithm, entry.CheckSum, fileTransferResult.LocalCheckSumAlgorithm, fileTransferResult.LocalCheckSum)
+			return fileTransferResult, errors.Wrapf(newErr, "checksum verification failed, upload failed")
+		}
+	}
+
+	fileTransferResult.EndTime = time.Now()
+
+	return fileTransferResult, nil
+}
+
+// DownloadToWriter downloads a file to writer without buffering the whole data
+// checksum is computed while streaming and verified against the checksum of the data object after reading all data
+func (fs *FileSystem) DownloadToWriter(irodsPath string, writer io.Writer, opts *StreamTransferOptions) (*FileTransferResult, error) {
+	irodsSrcPath := util.GetCorrectIRODSPath(irodsPath)
+
+	if opts == nil {
+		opts = NewDefaultStreamTransferOptions()
+	}
+
+	fileTransferResult := &FileTransferResult{}
+	fileTransferResult.IRODSPath = irodsSrcPath
+	fileTransferResult.StartTime = time.Now()
+
+	entry, err := fs.Stat(irodsSrcPath)
+	if err != nil {
+		return fileTransferResult, errors.Wrapf(err, "failed to find a data object for path %q", irodsSrcPath)
+	}
+
+	if entry.Type == DirectoryEntry {
+		return fileTransferResult, errors.Errorf("cannot download a collection %q", irodsSrcPath)
+	}
+
+	fileTransferResult.IRODSCheckSumAlgorithm = entry.CheckSumAlgorithm
+	fileTransferResult.IRODSCheckSum = entry.CheckSum
+	fileTransferResult.IRODSSize = entry.Size
+
+	keywords := map[common.KeyWord]string{}
+
+	var hashAlg hash.Hash
+	if opts.VerifyChecksum {
+		if len(entry.CheckSum) == 0 {
+			return fileTransferResult, errors.Errorf("failed to get checksum of the source data object for path %q", irodsSrcPath)
+		}
+
+		algorithm, err := fs.getChecksumAlgorithmForVerification(irodsSrcPath, entry.CheckSumAlgorithm)
+		if err != nil {
+			return fileTransferResult, err
+		}
+
+		hashAlg, err = util.GetHashAlgorithm(string(algorithm))
+		if err != nil {
+			return fileTransferResult, errors.Wrapf(err, "failed to get hash algorithm %q", algorithm)
+		}
+
+		fileTransferResult.LocalCheckSumAlgorithm = algorithm
+
+		// hash data as it is written
+		writer = io.MultiWriter(writer, hashAlg)
+
+		keywords[common.VERIFY_CHKSUM_KW] = ""
+	}
+
+	size, err := irods_fs.DownloadDataObjectToWriter(fs.ioSession, entry.ToDataObject(), opts.Resource, writer, opts.BufferSize, keywords, opts.TransferCallback)
+	fileTransferResult.LocalSize = size
+	if err != nil {
+		return fileTransferResult, errors.Wrapf(err, "failed to download a data object for path %q", irodsSrcPath)
+	}
+
+	if opts.VerifyChecksum {
+		fileTransferResult.LocalCheckSum = hashAlg.Sum(nil)
+
+		if !bytes.Equal(entry.CheckSum, fileTransferResult.LocalCheckSum) {
+			newErr := types.NewChecksumMismatchError(irodsSrcPath, entry.CheckSumAlgorithm, entry.CheckSum, fileTransferResult.LocalCheckSumAlgorithm, fileTransferResult.LocalCheckSum)
+			return fileTransferResult, errors.Wrapf(newErr, "checksum verification failed, download failed")
+		}
+	}
+
+	fileTransferResult.EndTime = time.Now()
+
+	return fileTransferResult, nil
+}
@@ -0,0 +1,791 @@
+package fs
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+)
+
+// SyncEntry is a file or a dir compared during sync, local or irods
+type SyncEntry struct {
+	Name              string
+	Path              string
+	Local             bool
+	Type              EntryType
+	Size              int64
+	ModifyTime        time.Time
+	CheckSumAlgorithm types.ChecksumAlgorithm
+	CheckSum          []byte
+
+	symlink bool
+	err     error
+	dryRun  bool
+}
+
+// IsDir returns true if the entry is for a dir
+func (entry *SyncEntry) IsDir() bool {
+	return entry.Type == DirectoryEntry
+}
+
+// ErrSyncCompareDeferred can be returned by SyncComparator in dry-run if comparison requires changes, e.g., computing irods checksums
+// the file is reported with SyncActionCompare
+var ErrSyncCompareDeferred = errors.New("comparison deferred in dry-run")
+
+// SyncComparator compares a source file and a dest file, returns true if the dest file needs to be updated
+type SyncComparator func(fs *FileSystem, source *SyncEntry, dest *SyncEntry) (bool, error)
+
+// SyncCompareSize compares sizes
+func SyncCompareSize(fs *FileSystem, source *SyncEntry, dest *SyncEntry) (bool, error) {
+	return source.Size != dest.Size, nil
+}
+
+// SyncCompareSizeAndModTime compares sizes and returns true if the source file is newer than the dest file
+func SyncCompareSizeAndModTime(fs *FileSystem, source *SyncEntry, dest *SyncEntry) (bool, error) {
+	if source.Size != dest.Size {
+		return true, nil
+	}
+
+	// irods stores timestamps in seconds
+	return source.ModifyTime.Truncate(time.Second).After(dest.ModifyTime.Truncate(time.Second)), nil
+}
+
+// SyncCompareChecksum compares sizes and checksums, like irsync
+// checksums of irods files are computed if missing, local files are hashed with the algorithm of the irods file
+// in dry-run, missing checksums of irods files are not computed, ErrSyncCompareDeferred is returned instead
+func SyncCompareChecksum(fs *FileSystem, source *SyncEntry, dest *SyncEntry) (bool, error) {
+	if source.Size != dest.Size {
+		return true, nil
+	}
+
+	// irods checksum determines the algorithm
+	first, second := dest, source
+	if dest.Local {
+		first, second = source, dest
+	}
+
+	firstAlgorithm, firstChecksum, err := fs.getSyncEntryChecksum(first, types.ChecksumAlgorithmUnknown)
+	if err != nil {
+		return false, err
+	}
+
+	secondAlgorithm, secondChecksum, err := fs.getSyncEntryChecksum(second, firstAlgorithm)
+	if err != nil {
+		return false, err
+	}
+
+	if firstAlgorithm != secondAlgorithm {
+		// cannot compare
+		return true, nil
+	}
+
+	return !bytes.Equal(firstChecksum, secondChecksum), nil
+}
+
+// getSyncEntryChecksum returns checksum of the entry, computes it if missing
+func (fs *FileSystem) getSyncEntryChecksum(entry *SyncEntry, algorithm types.ChecksumAlgorithm) (types.ChecksumAlgorithm, []byte, error) {
+	if entry.Local {
+		if len(entry.CheckSum) > 0 && (algorithm == types.ChecksumAlgorithmUnknown || entry.CheckSumAlgorithm == algorithm) {
+			return entry.CheckSumAlgorithm, entry.CheckSum, nil
+		}
+
+		checksumAlgorithm, checksum, err := fs.calculateLocalFileHash(entry.Path, algorithm, nil)
+		if err != nil {
+			return "", nil, err
+		}
+
+		entry.CheckSumAlgorithm = checksumAlgorithm
+		entry.CheckSum = checksum
+		return checksumAlgorithm, checksum, nil
+	}
+
+	if len(entry.CheckSum) == 0 {
+		if entry.dryRun {
+			// computing checksum stores it in the catalog
+			return "", nil, ErrSyncCompareDeferred
+		}
+
+		checksum, err := fs.ComputeFileChecksum(entry.Path, "", false, false, false)
+		if err != nil {
+			return "", nil, errors.Wrapf(err, "failed to compute checksum of %q", entry.Path)
+		}
+
+		entry.CheckSumAlgorithm = checksum.Algorithm
+		entry.CheckSum = checksum.Checksum
+	}
+
+	return entry.CheckSumAlgorithm, entry.CheckSum, nil
+}
+
+// SyncConfig is a configuration for sync
+type SyncConfig struct {
+	// Comparator determines if a dest file needs to be updated
+	Comparator SyncComparator
+	// DeleteExtraneous deletes dest files and dirs not existing in the source, excluded ones are kept
+	DeleteExtraneous bool
+	// DeleteAfterErrors deletes extraneous dest files and dirs even if other entries failed to sync, like rsync --ignore-errors
+	// if false, deletions are skipped when any entry failed to avoid deleting dest data after a partial sync
+	DeleteAfterErrors bool
+	// DryRun only reports actions without running them
+	DryRun bool
+	// Transfer is used for transfers, filters and symlink policy, Force is ignored as dest files are always overwritten
+	Transfer *DirTransferConfig
+}
+
+// NewDefaultSyncConfig creates a default SyncConfig
+func NewDefaultSyncConfig() *SyncConfig {
+	return &SyncConfig{
+		Comparator:        SyncCompareChecksum,
+		DeleteExtraneous:  false,
+		DeleteAfterErrors: false,
+		DryRun:            false,
+		Transfer:          NewDefaultDirTransferConfig(),
+	}
+}
+
+// SyncActionType is a type of sync action
+type SyncActionType string
+
+const (
+	// SyncActionMakeDir creates a dest dir
+	SyncActionMakeDir SyncActionType = "mkdir"
+	// SyncActionCreate transfers a file not existing in the dest
+	SyncActionCreate SyncActionType = "create"
+	// SyncActionUpdate transfers a file different from the dest file
+	SyncActionUpdate SyncActionType = "update"
+	// SyncActionDelete deletes an extraneous dest file or dir
+	SyncActionDelete SyncActionType = "delete"
+	// SyncActionCompare is for a file that would be compared in dry-run, comparison requires changes like computing irods checksums
+	SyncActionCompare SyncActionType = "compare"
+)
+
+// SyncAction is an action taken (or to be taken in dry-run) during sync
+type SyncAction struct {
+	Type       SyncActionType
+	SourcePath string
+	DestPath   string
+	Size       int64
+	Dir        bool
+	// Result is set for transfers between local and irods
+	Result *FileTransferResult
+	Error  error
+
+	destEntry *SyncEntry
+}
+
+// SyncResult is a result of sync
+type SyncResult struct {
+	SourcePath string
+	DestPath   string
+	DryRun     bool
+	Actions    []*SyncAction
+	// Unchanged is a list of source files identical to dest files
+	Unchanged []string
+	// Skipped is a list of source paths skipped by filters or symlink policy
+	Skipped   []string
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// GetActions returns actions of the given type
+func (result *SyncResult) GetActions(actionType SyncActionType) []*SyncAction {
+	actions := []*SyncAction{}
+	for _, action := range result.Actions {
+		if action.Type == actionType {
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
+
+// GetErrors returns errors of failed actions
+func (result *SyncResult) GetErrors() []error {
+	errs := []error{}
+	for _, action := range result.Actions {
+		if action.Error != nil {
+			errs = append(errs, action.Error)
+		}
+	}
+	return errs
+}
+
+// syncTree is a local or irods tree accessed during sync
+type syncTree interface {
+	stat(p string) (*SyncEntry, error)
+	list(p string) ([]*SyncEntry, error)
+	join(p string, name string) string
+	makeDir(p string) error
+	remove(entry *SyncEntry) error
+}
+
+// syncLocalTree is a local tree
+type syncLocalTree struct {
+	followSymlinks bool
+}
+
+func newLocalSyncEntry(localPath string, info os.FileInfo) *SyncEntry {
+	entryType := FileEntry
+	if info.IsDir() {
+		entryType = DirectoryEntry
+	}
+
+	return &SyncEntry{
+		Name:       filepath.Base(localPath),
+		Path:       localPath,
+		Local:      true,
+		Type:       entryType,
+		Size:       info.Size(),
+		ModifyTime: info.ModTime(),
+	}
+}
+
+func (tree *syncLocalTree) stat(p string) (*SyncEntry, error) {
+	info, err := os.Stat(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to stat %q", p)
+	}
+
+	return newLocalSyncEntry(p, info), nil
+}
+
+func (tree *syncLocalTree) list(p string) ([]*SyncEntry, error) {
+	dirEntries, err := os.ReadDir(p)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read dir %q", p)
+	}
+
+	entries := []*SyncEntry{}
+	for _, dirEntry := range dirEntries {
+		entryPath := filepath.Join(p, dirEntry.Name())
+
+		info, err := dirEntry.Info()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to stat %q", entryPath)
+		}
+
+		if dirEntry.Type()&os.ModeSymlink == 0 {
+			if dirEntry.IsDir() || dirEntry.Type().IsRegular() {
+				entries = append(entries, newLocalSyncEntry(entryPath, info))
+			}
+			continue
+		}
+
+		entry := newLocalSyncEntry(entryPath, info)
+		entry.symlink = true
+
+		if tree.followSymlinks {
+			targetInfo, err := os.Stat(entryPath)
+			if err != nil {
+				entry.err = errors.Wrapf(err, "failed to follow symlink %q", entryPath)
+			} else {
+				entry = newLocalSyncEntry(entryPath, targetInfo)
+				entry.symlink = true
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func (tree *syncLocalTree) join(p string, name string) string {
+	return filepath.Join(p, name)
+}
+
+func (tree *syncLocalTree) makeDir(p string) error {
+	err := os.MkdirAll(p, 0o755)
+	if err != nil {
+		return errors.Wrapf(err, "failed to make dir %q", p)
+	}
+	return nil
+}
+
+func (tree *syncLocalTree) remove(entry *SyncEntry) error {
+	err := os.RemoveAll(entry.Path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to remove %q", entry.Path)
+	}
+	return nil
+}
+
+// syncIRODSTree is an irods tree
+type syncIRODSTree struct {
+	filesystem *FileSystem
+}
+
+func newIRODSSyncEntry(entry *Entry) *SyncEntry {
+	return &SyncEntry{
+		Name:              entry.Name,
+		Path:              entry.Path,
+		Local:             false,
+		Type:              entry.Type,
+		Size:              entry.Size,
+		ModifyTime:        entry.ModifyTime,
+		CheckSumAlgorithm: entry.CheckSumAlgorithm,
+		CheckSum:          entry.CheckSum,
+	}
+}
+
+func (tree *syncIRODSTree) stat(p string) (*SyncEntry, error) {
+	entry, err := tree.filesystem.Stat(p)
+	if err != nil {
+		if types.IsFileNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return newIRODSSyncEntry(entry), nil
+}
+
+func (tree *syncIRODSTree) list(p string) ([]*SyncEntry, error) {
+	entries, err := tree.filesystem.List(p)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list dir %q", p)
+	}
+
+	syncEntries := make([]*SyncEntry, 0, len(entries))
+	for _, entry := range entries {
+		syncEntries = append(syncEntries, newIRODSSyncEntry(entry))
+	}
+
+	return syncEntries, nil
+}
+
+func (tree *syncIRODSTree) join(p string, name string) string {
+	return util.MakeIRODSPath(p, name)
+}
+
+func (tree *syncIRODSTree) makeDir(p string) error {
+	err := tree.filesystem.MakeDir(p, true)
+	if err != nil {
+		return errors.Wrapf(err, "failed to make dir %q", p)
+	}
+	return nil
+}
+
+func (tree *syncIRODSTree) remove(entry *SyncEntry) error {
+	if entry.IsDir() {
+		return tree.filesystem.RemoveDir(entry.Path, true, true)
+	}
+	return tree.filesystem.RemoveFile(entry.Path, true)
+}
+
+// syncer walks source and dest trees and runs sync actions
+type syncer struct {
+	filesystem *FileSystem
+	config     *SyncConfig
+	source     syncTree
+	dest       syncTree
+	result     *SyncResult
+	visited    map[string]bool
+	transfers  []*SyncAction
+	deletes    []*SyncAction
+	// transfer transfers a file, used by a worker pool
+	transfer func(action *SyncAction, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error)
+}
+
+// SyncLocalToIRODS syncs a local dir to an irods dir, the content of the local dir is synced into irodsPath
+func (fs *FileSystem) SyncLocalToIRODS(localPath string, irodsPath string, config *SyncConfig) (*SyncResult, error) {
+	localSrcPath := util.GetCorrectLocalPath(localPath)
+	irodsDestPath := util.GetCorrectIRODSPath(irodsPath)
+
+	config = getSyncConfig(config)
+
+	s := fs.newSyncer(config, &syncLocalTree{followSymlinks: config.Transfer.SymlinkPolicy == SymlinkPolicyFollow}, &syncIRODSTree{filesystem: fs}, localSrcPath, irodsDestPath)
+	s.transfer = func(action *SyncAction, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
+		file := &DirTransferFileResult{
+			LocalPath: action.SourcePath,
+			IRODSPath: action.DestPath,
+			Size:      action.Size,
+			Method:    config.Transfer.getTransferMethod(action.Size),
+		}
+		return fs.uploadFileForDir(file, config.Transfer, transferCallback)
+	}
+
+	return s.run()
+}
+
+// SyncIRODSToLocal syncs an irods dir to a local dir, the content of the irods dir is synced into localPath
+func (fs *FileSystem) SyncIRODSToLocal(irodsPath string, localPath string, config *SyncConfig) (*SyncResult, error) {
+	irodsSrcPath := util.GetCorrectIRODSPath(irodsPath)
+	localDestPath := util.GetCorrectLocalPath(localPath)
+
+	config = getSyncConfig(config)
+
+	s := fs.newSyncer(config, &syncIRODSTree{filesystem: fs}, &syncLocalTree{}, irodsSrcPath, localDestPath)
+	s.transfer = func(action *SyncAction, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
+		file := &DirTransferFileResult{
+			LocalPath: action.DestPath,
+			IRODSPath: action.SourcePath,
+			Size:      action.Size,
+			Method:    config.Transfer.getTransferMethod(action.Size),
+		}
+		return fs.downloadFileForDir(file, config.Transfer, transferCallback)
+	}
+
+	return s.run()
+}
+
+// SyncIRODSToIRODS syncs an irods dir to another irods dir, files are copied at the server side
+func (fs *FileSystem) SyncIRODSToIRODS(srcPath string, destPath string, config *SyncConfig) (*SyncResult, error) {
+	irodsSrcPath := util.GetCorrectIRODSPath(srcPath)
+	irodsDestPath := util.GetCorrectIRODSPath(destPath)
+
+	config = getSyncConfig(config)
+
+	s := fs.newSyncer(config, &syncIRODSTree{filesystem: fs}, &syncIRODSTree{filesystem: fs}, irodsSrcPath, irodsDestPath)
+	s.transfer = func(action *SyncAction, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
+		return nil, fs.CopyFileToFile(action.SourcePath, action.DestPath, true)
+	}
+
+	return s.run()
+}
+
+// getSyncConfig returns a copy of config filled with defaults
+func getSyncConfig(config *SyncConfig) *SyncConfig {
+	if config == nil {
+		config = NewDefaultSyncConfig()
+	}
+
+	newConfig := *config
+	if newConfig.Comparator == nil {
+		newConfig.Comparator = SyncCompareChecksum
+	}
+
+	transferConfig := NewDefaultDirTransferConfig()
+	if newConfig.Transfer != nil {
+		*transferConfig = *newConfig.Transfer
+	}
+
+	// dest files are always overwritten
+	transferConfig.Force = true
+	newConfig.Transfer = transferConfig
+
+	return &newConfig
+}
+
+func (fs *FileSystem) newSyncer(config *SyncConfig, source syncTree, dest syncTree, sourcePath string, destPath string) *syncer {
+	return &syncer{
+		filesystem: fs,
+		config:     config,
+		source:     source,
+		dest:       dest,
+		result: &SyncResult{
+			SourcePath: sourcePath,
+			DestPath:   destPath,
+			DryRun:     config.DryRun,
+			Actions:    []*SyncAction{},
+			Unchanged:  []string{},
+			Skipped:    []string{},
+			StartTime:  time.Now(),
+		},
+		visited:   map[string]bool{},
+		transfers: []*SyncAction{},
+		deletes:   []*SyncAction{},
+	}
+}
+
+func (s *syncer) run() (*SyncResult, error) {
+	err := s.config.Transfer.Validate()
+	if err != nil {
+		return s.result, err
+	}
+
+	sourceEntry, err := s.source.stat(s.result.SourcePath)
+	if err != nil {
+		return s.result, err
+	}
+
+	if sourceEntry == nil || !sourceEntry.IsDir() {
+		newErr := types.NewFileNotFoundError(s.result.SourcePath)
+		return s.result, errors.Wrapf(newErr, "failed to find a dir for source path %q", s.result.SourcePath)
+	}
+
+	destEntry, err := s.dest.stat(s.result.DestPath)
+	if err != nil {
+		return s.result, err
+	}
+
+	if destEntry != nil && !destEntry.IsDir() {
+		return s.result, errors.Errorf("failed to sync to %q, the path is for a file", s.result.DestPath)
+	}
+
+	err = s.syncDir(s.result.SourcePath, s.result.DestPath, "", destEntry != nil)
+	if err != nil {
+		return s.result, err
+	}
+
+	if !s.config.DryRun {
+		s.runTransfers()
+
+		if len(s.result.GetErrors()) > 0 && !s.config.DeleteAfterErrors {
+			s.skipDeletes()
+		} else {
+			s.runDeletes()
+		}
+	}
+
+	s.result.EndTime = time.Now()
+
+	errs := s.result.GetErrors()
+	if len(errs) > 0 {
+		return s.result, errors.Wrapf(errors.Join(errs...), "failed to sync %d entries", len(errs))
+	}
+
+	return s.result, nil
+}
+
+func (s *syncer) addAction(action *SyncAction) {
+	s.result.Actions = append(s.result.Actions, action)
+}
+
+func (s *syncer) syncDir(sourcePath string, destPath string, relPath string, destExists bool) error {
+	if _, ok := s.source.(*syncLocalTree); ok {
+		realPath, err := filepath.EvalSymlinks(sourcePath)
+		if err != nil {
+			return errors.Wrapf(err, "failed to resolve dir %q", sourcePath)
+		}
+
+		if s.visited[realPath] {
+			// loop by symlinks
+			s.result.Skipped = append(s.result.Skipped, sourcePath)
+			return nil
+		}
+		s.visited[realPath] = true
+	}
+
+	if !destExists {
+		action := &SyncAction{
+			Type:       SyncActionMakeDir,
+			SourcePath: sourcePath,
+			DestPath:   destPath,
+			Dir:        true,
+		}
+		s.addAction(action)
+
+		if !s.config.DryRun {
+			err := s.dest.makeDir(destPath)
+			if err != nil {
+				// skip the subtree
+				action.Error = err
+				return nil
+			}
+		}
+	}
+
+	sourceEntries, err := s.source.list(sourcePath)
+	if err != nil {
+		return err
+	}
+
+	destEntryList := []*SyncEntry{}
+	if destExists {
+		destEntryList, err = s.dest.list(destPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	destEntries := map[string]*SyncEntry{}
+	for _, entry := range destEntryList {
+		destEntries[entry.Name] = entry
+	}
+
+	transferConfig := s.config.Transfer
+	sourceNames := map[string]bool{}
+
+	for _, sourceEntry := range sourceEntries {
+		sourceNames[sourceEntry.Name] = true
+
+		entryRelPath := path.Join(relPath, sourceEntry.Name)
+		entryDestPath := s.dest.join(destPath, sourceEntry.Name)
+		destEntry := destEntries[sourceEntry.Name]
+
+		if transferConfig.isExcluded(entryRelPath) {
+			s.result.Skipped = append(s.result.Skipped, sourceEntry.Path)
+			continue
+		}
+
+		if sourceEntry.symlink {
+			switch transferConfig.SymlinkPolicy {
+			case SymlinkPolicyFollow:
+				if sourceEntry.err != nil {
+					s.addAction(&SyncAction{
+						Type:       SyncActionCreate,
+						SourcePath: sourceEntry.Path,
+						DestPath:   entryDestPath,
+						Error:      sourceEntry.err,
+					})
+					continue
+				}
+			case SymlinkPolicyError:
+				s.addAction(&SyncAction{
+					Type:       SyncActionCreate,
+					SourcePath: sourceEntry.Path,
+					DestPath:   entryDestPath,
+					Error:      errors.Errorf("failed to sync symlink %q", sourceEntry.Path),
+				})
+				continue
+			default:
+				s.result.Skipped = append(s.result.Skipped, sourceEntry.Path)
+				continue
+			}
+		}
+
+		if sourceEntry.IsDir() {
+			if destEntry != nil && !destEntry.IsDir() {
+				s.addAction(&SyncAction{
+					Type:       SyncActionMakeDir,
+					SourcePath: sourceEntry.Path,
+					DestPath:   entryDestPath,
+					Dir:        true,
+					Error:      errors.Errorf("failed to sync dir %q, dest %q is a file", sourceEntry.Path, entryDestPath),
+				})
+				continue
+			}
+
+			err = s.syncDir(sourceEntry.Path, entryDestPath, entryRelPath, destEntry != nil)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !transferConfig.isIncluded(entryRelPath) {
+			s.result.Skipped = append(s.result.Skipped, sourceEntry.Path)
+			continue
+		}
+
+		action := &SyncAction{
+			Type:       SyncActionCreate,
+			SourcePath: sourceEntry.Path,
+			DestPath:   entryDestPath,
+			Size:       sourceEntry.Size,
+		}
+
+		if destEntry != nil {
+			action.Type = SyncActionUpdate
+
+			if destEntry.IsDir() {
+				action.Error = errors.Errorf("failed to sync file %q, dest %q is a dir", sourceEntry.Path, entryDestPath)
+				s.addAction(action)
+				continue
+			}
+
+			sourceEntry.dryRun = s.config.DryRun
+			destEntry.dryRun = s.config.DryRun
+
+			different, err := s.config.Comparator(s.filesystem, sourceEntry, destEntry)
+			if errors.Is(err, ErrSyncCompareDeferred) {
+				action.Type = SyncActionCompare
+				s.addAction(action)
+				continue
+			}
+
+			if err != nil {
+				action.Error = errors.Wrapf(err, "failed to compare %q and %q", sourceEntry.Path, entryDestPath)
+				s.addAction(action)
+				continue
+			}
+
+			if !different {
+				s.result.Unchanged = append(s.result.Unchanged, sourceEntry.Path)
+				continue
+			}
+		}
+
+		s.addAction(action)
+		s.transfers = append(s.transfers, action)
+	}
+
+	if s.config.DeleteExtraneous {
+		for _, destEntry := range destEntryList {
+			if sourceNames[destEntry.Name] {
+				continue
+			}
+
+			entryRelPath := path.Join(relPath, destEntry.Name)
+			if transferConfig.isExcluded(entryRelPath) {
+				continue
+			}
+
+			if !destEntry.IsDir() && !transferConfig.isIncluded(entryRelPath) {
+				continue
+			}
+
+			action := &SyncAction{
+				Type:      SyncActionDelete,
+				DestPath:  destEntry.Path,
+				Size:      destEntry.Size,
+				Dir:       destEntry.IsDir(),
+				destEntry: destEntry,
+			}
+			s.addAction(action)
+			s.deletes = append(s.deletes, action)
+		}
+	}
+
+	return nil
+}
+
+// runTransfers transfers files concurrently
+func (s *syncer) runTransfers() {
+	files := make([]*DirTransferFileResult, 0, len(s.transfers))
+	actionMap := map[*DirTransferFileResult]*SyncAction{}
+
+	_, downloading := s.dest.(*syncLocalTree)
+
+	for _, action := range s.transfers {
+		file := &DirTransferFileResult{
+			LocalPath: action.SourcePath,
+			IRODSPath: action.DestPath,
+			Size:      action.Size,
+		}
+
+		if downloading {
+			file.LocalPath = action.DestPath
+			file.IRODSPath = action.SourcePath
+		}
+
+		files = append(files, file)
+		actionMap[file] = action
+	}
+
+	s.filesystem.transferFiles(files, s.config.Transfer, func(file *DirTransferFileResult, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
+		return s.transfer(actionMap[file], transferCallback)
+	})
+
+	for _, file := range files {
+		action := actionMap[file]
+		action.Result = file.Result
+		action.Error = file.Error
+	}
+}
+
+// skipDeletes marks deletions as failed without running them, because other entries failed to sync
+func (s *syncer) skipDeletes() {
+	for _, action := range s.deletes {
+		action.Error = errors.Errorf("skipped deleting %q because other entries failed to sync", action.DestPath)
+	}
+}
+
+// runDeletes deletes extraneous dest entries
+func (s *syncer) runDeletes() {
+	for _, action := range s.deletes {
+		err := s.dest.remove(action.destEntry)
+		if err != nil && !types.IsFileNotFoundError(err) {
+			action.Error = err
+		}
+	}
+}
@@ -0,0 +1,578 @@
+package fs
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DirTransferConcurrentFilesDefault is a default number of files transferred concurrently
+	DirTransferConcurrentFilesDefault int = 4
+	// DirTransferParallelThresholdDefault is a default file size to use parallel transfer
+	DirTransferParallelThresholdDefault int64 = 2 * util.TransferTaskMinLength
+)
+
+// SymlinkPolicy determines how symbolic links are handled while walking local dirs
+type SymlinkPolicy string
+
+const (
+	// SymlinkPolicySkip skips symbolic links
+	SymlinkPolicySkip SymlinkPolicy = "skip"
+	// SymlinkPolicyFollow follows symbolic links, loops are skipped
+	SymlinkPolicyFollow SymlinkPolicy = "follow"
+	// SymlinkPolicyError reports symbolic links as errors
+	SymlinkPolicyError SymlinkPolicy = "error"
+)
+
+// FileTransferMethod is a method used to transfer a file
+type FileTransferMethod string
+
+const (
+	// FileTransferMethodSingle transfers a file over a connection
+	FileTransferMethodSingle FileTransferMethod = "single"
+	// FileTransferMethodParallel transfers a file over multiple connections
+	FileTransferMethodParallel FileTransferMethod = "parallel"
+	// FileTransferMethodRedirectToResource transfers a file via resource server
+	FileTransferMethodRedirectToResource FileTransferMethod = "redirect_to_resource"
+)
+
+// DirTransferTrackerCallback is a callback to track transfer of each file in a dir transfer
+type DirTransferTrackerCallback func(localPath string, irodsPath string, processed int64, total int64)
+
+// DirTransferConfig is a configuration for UploadDir and DownloadDir
+type DirTransferConfig struct {
+	Resource string
+	// ConcurrentFiles is a number of files transferred concurrently
+	ConcurrentFiles int
+	// ParallelThreshold is a file size to use parallel transfer, 0 disables parallel transfer
+	ParallelThreshold int64
+	// RedirectToResourceThreshold is a file size to use redirect-to-resource transfer, 0 disables redirect-to-resource transfer
+	RedirectToResourceThreshold int64
+	// TaskNum is a number of tasks for parallel and redirect-to-resource transfer, 0 determines it from file size
+	TaskNum int
+	// Include is a list of globs, files not matching any of them are skipped if given
+	// globs having "/" match relative paths, others match file names
+	Include []string
+	// Exclude is a list of globs, files and dirs matching any of them are skipped
+	Exclude        []string
+	SymlinkPolicy  SymlinkPolicy
+	Force          bool // overwrite existing files
+	Replicate      bool
+	VerifyChecksum bool
+	// StopOnError stops scheduling new files after the first failure
+	StopOnError      bool
+	TransferCallback DirTransferTrackerCallback
+}
+
+// NewDefaultDirTransferConfig creates a default DirTransferConfig
+func NewDefaultDirTransferConfig() *DirTransferConfig {
+	return &DirTransferConfig{
+		Resource:                    "",
+		ConcurrentFiles:             DirTransferConcurrentFilesDefault,
+		ParallelThreshold:           DirTransferParallelThresholdDefault,
+		RedirectToResourceThreshold: 0,
+		TaskNum:                     0,
+		Include:                     []string{},
+		Exclude:                     []string{},
+		SymlinkPolicy:               SymlinkPolicySkip,
+		Force:                       false,
+		Replicate:                   false,
+		VerifyChecksum:              true,
+		StopOnError:                 false,
+		TransferCallback:            nil,
+	}
+}
+
+// Validate validates glob patterns
+func (config *DirTransferConfig) Validate() error {
+	for _, pattern := range append(append([]string{}, config.Include...), config.Exclude...) {
+		_, err := path.Match(pattern, "")
+		if err != nil {
+			return errors.Wrapf(err, "invalid glob pattern %q", pattern)
+		}
+	}
+
+	return nil
+}
+
+// getTransferMethod returns transfer method for the file size
+func (config *DirTransferConfig) getTransferMethod(size int64) FileTransferMethod {
+	if config.RedirectToResourceThreshold > 0 && size >= config.RedirectToResourceThreshold {
+		return FileTransferMethodRedirectToResource
+	}
+
+	if config.ParallelThreshold > 0 && size >= config.ParallelThreshold {
+		return FileTransferMethodParallel
+	}
+
+	return FileTransferMethodSingle
+}
+
+// isExcluded returns true if the relative path matches exclude globs
+func (config *DirTransferConfig) isExcluded(relPath string) bool {
+	return matchTransferGlobs(config.Exclude, relPath)
+}
+
+// isIncluded returns true if the relative path of a file matches include globs or no include globs are given
+func (config *DirTransferConfig) isIncluded(relPath string) bool {
+	if len(config.Include) == 0 {
+		return true
+	}
+
+	return matchTransferGlobs(config.Include, relPath)
+}
+
+func matchTransferGlobs(patterns []string, relPath string) bool {
+	name := path.Base(relPath)
+	for _, pattern := range patterns {
+		target := name
+		if strings.Contains(pattern, "/") {
+			target = relPath
+		}
+
+		matched, _ := path.Match(pattern, target)
+		if matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DirTransferFileResult is a transfer result of a file in a dir transfer
+type DirTransferFileResult struct {
+	LocalPath string
+	IRODSPath string
+	Size      int64
+	Method    FileTransferMethod
+	Result    *FileTransferResult
+	Error     error
+}
+
+// DirTransferResult is an aggregated result of a dir transfer
+type DirTransferResult struct {
+	LocalPath string
+	IRODSPath string
+	// Dirs is a list of dirs created, iRODS paths for upload and local paths for download
+	Dirs []string
+	// Files is a list of file results, sorted by iRODS path
+	Files []*DirTransferFileResult
+	// Skipped is a list of paths skipped by filters or symlink policy
+	Skipped   []string
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// GetErrors returns errors of failed files
+func (result *DirTransferResult) GetErrors() []error {
+	errs := []error{}
+	for _, fileResult := range result.Files {
+		if fileResult.Error != nil {
+			errs = append(errs, fileResult.Error)
+		}
+	}
+	return errs
+}
+
+// GetFailedFiles returns results of failed files
+func (result *DirTransferResult) GetFailedFiles() []*DirTransferFileResult {
+	failed := []*DirTransferFileResult{}
+	for _, fileResult := range result.Files {
+		if fileResult.Error != nil {
+			failed = append(failed, fileResult)
+		}
+	}
+	return failed
+}
+
+// GetTransferredSize returns the total size of files transferred successfully
+func (result *DirTransferResult) GetTransferredSize() int64 {
+	size := int64(0)
+	for _, fileResult := range result.Files {
+		if fileResult.Error == nil {
+			size += fileResult.Size
+		}
+	}
+	return size
+}
+
+// UploadDir uploads a local dir to irods recursively, the content of the local dir is uploaded into irodsPath
+// files are uploaded concurrently, returns an aggregated result and joined errors of failed files
+func (fs *FileSystem) UploadDir(localPath string, irodsPath string, config *DirTransferConfig) (*DirTransferResult, error) {
+	localSrcPath := util.GetCorrectLocalPath(localPath)
+	irodsDestPath := util.GetCorrectIRODSPath(irodsPath)
+
+	if config == nil {
+		config = NewDefaultDirTransferConfig()
+	}
+
+	result := &DirTransferResult{
+		LocalPath: localSrcPath,
+		IRODSPath: irodsDestPath,
+		Dirs:      []string{},
+		Files:     []*DirTransferFileResult{},
+		Skipped:   []string{},
+		StartTime: time.Now(),
+	}
+
+	err := config.Validate()
+	if err != nil {
+		return result, err
+	}
+
+	stat, err := os.Stat(localSrcPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// dir not exists
+			newErr := errors.Join(err, types.NewFileNotFoundError(localSrcPath))
+			return result, errors.Wrapf(newErr, "failed to find a dir for local path %q", localSrcPath)
+		}
+		return result, err
+	}
+
+	if !stat.IsDir() {
+		newErr := types.NewFileNotFoundError(localSrcPath)
+		return result, errors.Wrapf(newErr, "failed to find a dir for local path %q, the path is for a file", localSrcPath)
+	}
+
+	// walk
+	files := []*DirTransferFileResult{}
+	visited := map[string]bool{}
+	err = fs.walkLocalDirForUpload(localSrcPath, irodsDestPath, "", config, visited, result, &files)
+	if err != nil {
+		return result, err
+	}
+
+	fs.transferFiles(files, config, func(file *DirTransferFileResult, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
+		return fs.uploadFileForDir(file, config, transferCallback)
+	})
+
+	return fs.finishDirTransfer(result, files)
+}
+
+func (fs *FileSystem) walkLocalDirForUpload(localPath string, irodsPath string, relPath string, config *DirTransferConfig, visited map[string]bool, result *DirTransferResult, files *[]*DirTransferFileResult) error {
+	realPath, err := filepath.EvalSymlinks(localPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to resolve dir %q", localPath)
+	}
+
+	if visited[realPath] {
+		// loop by symlinks
+		result.Skipped = append(result.Skipped, localPath)
+		return nil
+	}
+	visited[realPath] = true
+
+	if !fs.ExistsDir(irodsPath) {
+		err = fs.MakeDir(irodsPath, true)
+		if err != nil {
+			return errors.Wrapf(err, "failed to make dir %q", irodsPath)
+		}
+
+		result.Dirs = append(result.Dirs, irodsPath)
+	}
+
+	dirEntries, err := os.ReadDir(localPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read dir %q", localPath)
+	}
+
+	for _, dirEntry := range dirEntries {
+		entryPath := filepath.Join(localPath, dirEntry.Name())
+		entryIRODSPath := util.MakeIRODSPath(irodsPath, dirEntry.Name())
+		entryRelPath := path.Join(relPath, dirEntry.Name())
+
+		if config.isExcluded(entryRelPath) {
+			result.Skipped = append(result.Skipped, entryPath)
+			continue
+		}
+
+		entryType := dirEntry.Type()
+		if entryType&os.ModeSymlink != 0 {
+			switch config.SymlinkPolicy {
+			case SymlinkPolicyFollow:
+				stat, err := os.Stat(entryPath)
+				if err != nil {
+					*files = append(*files, &DirTransferFileResult{
+						LocalPath: entryPath,
+						IRODSPath: entryIRODSPath,
+						Error:     errors.Wrapf(err, "failed to follow symlink %q", entryPath),
+					})
+					continue
+				}
+
+				entryType = stat.Mode().Type()
+			case SymlinkPolicyError:
+				*files = append(*files, &DirTransferFileResult{
+					LocalPath: entryPath,
+					IRODSPath: entryIRODSPath,
+					Error:     errors.Errorf("failed to upload symlink %q", entryPath),
+				})
+				continue
+			default:
+				result.Skipped = append(result.Skipped, entryPath)
+				continue
+			}
+		}
+
+		if entryType.IsDir() {
+			err = fs.walkLocalDirForUpload(entryPath, entryIRODSPath, entryRelPath, config, visited, result, files)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !entryType.IsRegular() || !config.isIncluded(entryRelPath) {
+			// skip special files
+			result.Skipped = append(result.Skipped, entryPath)
+			continue
+		}
+
+		stat, err := os.Stat(entryPath)
+		if err != nil {
+			return errors.Wrapf(err, "failed to stat file %q", entryPath)
+		}
+
+		*files = append(*files, &DirTransferFileResult{
+			LocalPath: entryPath,
+			IRODSPath: entryIRODSPath,
+			Size:      stat.Size(),
+			Method:    config.getTransferMethod(stat.Size()),
+		})
+	}
+
+	return nil
+}
+
+func (fs *FileSystem) uploadFileForDir(file *DirTransferFileResult, config *DirTransferConfig, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
+	if !config.Force && fs.ExistsFile(file.IRODSPath) {
+		newErr := types.NewFileAlreadyExistError(file.IRODSPath)
+		return nil, errors.Wrapf(newErr, "failed to upload %q", file.LocalPath)
+	}
+
+	switch file.Method {
+	case FileTransferMethodRedirectToResource:
+		return fs.UploadFileRedirectToResource(file.LocalPath, file.IRODSPath, config.Resource, config.TaskNum, config.Replicate, config.VerifyChecksum, transferCallback)
+	case FileTransferMethodParallel:
+		return fs.UploadFileParallel(file.LocalPath, file.IRODSPath, config.Resource, config.TaskNum, config.Replicate, config.VerifyChecksum, transferCallback)
+	default:
+		return fs.UploadFile(file.LocalPath, file.IRODSPath, config.Resource, config.Replicate, config.VerifyChecksum, transferCallback)
+	}
+}
+
+// DownloadDir downloads an irods dir to local recursively, the content of the irods dir is downloaded into localPath
+// files are downloaded concurrently, returns an aggregated result and joined errors of failed files
+func (fs *FileSystem) DownloadDir(irodsPath string, localPath string, config *DirTransferConfig) (*DirTransferResult, error) {
+	irodsSrcPath := util.GetCorrectIRODSPath(irodsPath)
+	localDestPath := util.GetCorrectLocalPath(localPath)
+
+	if config == nil {
+		config = NewDefaultDirTransferConfig()
+	}
+
+	result := &DirTransferResult{
+		LocalPath: localDestPath,
+		IRODSPath: irodsSrcPath,
+		Dirs:      []string{},
+		Files:     []*DirTransferFileResult{},
+		Skipped:   []string{},
+		StartTime: time.Now(),
+	}
+
+	err := config.Validate()
+	if err != nil {
+		return result, err
+	}
+
+	entry, err := fs.Stat(irodsSrcPath)
+	if err != nil {
+		return result, errors.Wrapf(err, "failed to find a dir for irods path %q", irodsSrcPath)
+	}
+
+	if !entry.IsDir() {
+		newErr := types.NewFileNotFoundError(irodsSrcPath)
+		return result, errors.Wrapf(newErr, "failed to find a dir for irods path %q, the path is for a file", irodsSrcPath)
+	}
+
+	// walk
+	files := []*DirTransferFileResult{}
+	err = fs.walkIRODSDirForDownload(irodsSrcPath, localDestPath, "", config, result, &files)
+	if err != nil {
+		return result, err
+	}
+
+	fs.transferFiles(files, config, func(file *DirTransferFileResult, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
+		return fs.downloadFileForDir(file, config, transferCallback)
+	})
+
+	return fs.finishDirTransfer(result, files)
+}
+
+func (fs *FileSystem) walkIRODSDirForDownload(irodsPath string, localPath string, relPath string, config *DirTransferConfig, result *DirTransferResult, files *[]*DirTransferFileResult) error {
+	_, err := os.Stat(localPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return errors.Wrapf(err, "failed to stat dir %q", localPath)
+		}
+
+		err = os.MkdirAll(localPath, 0o755)
+		if err != nil {
+			return errors.Wrapf(err, "failed to make dir %q", localPath)
+		}
+
+		result.Dirs = append(result.Dirs, localPath)
+	}
+
+	entries, err := fs.List(irodsPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list dir %q", irodsPath)
+	}
+
+	for _, entry := range entries {
+		entryLocalPath := filepath.Join(localPath, entry.Name)
+		entryRelPath := path.Join(relPath, entry.Name)
+
+		if config.isExcluded(entryRelPath) {
+			result.Skipped = append(result.Skipped, entry.Path)
+			continue
+		}
+
+		if entry.IsDir() {
+			err = fs.walkIRODSDirForDownload(entry.Path, entryLocalPath, entryRelPath, config, result, files)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !config.isIncluded(entryRelPath) {
+			result.Skipped = append(result.Skipped, entry.Path)
+			continue
+		}
+
+		*files = append(*files, &DirTransferFileResult{
+			LocalPath: entryLocalPath,
+			IRODSPath: entry.Path,
+			Size:      entry.Size,
+			Method:    config.getTransferMethod(entry.Size),
+		})
+	}
+
+	return nil
+}
+
+func (fs *FileSystem) downloadFileForDir(file *DirTransferFileResult, config *DirTransferConfig, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
+	if !config.Force {
+		_, err := os.Stat(file.LocalPath)
+		if err == nil {
+			newErr := types.NewFileAlreadyExistError(file.LocalPath)
+			return nil, errors.Wrapf(newErr, "failed to download %q", file.IRODSPath)
+		}
+	}
+
+	switch file.Method {
+	case FileTransferMethodRedirectToResource:
+		return fs.DownloadFileRedirectToResource(file.IRODSPath, config.Resource, file.LocalPath, config.TaskNum, config.VerifyChecksum, transferCallback)
+	case FileTransferMethodParallel:
+		return fs.DownloadFileParallel(file.IRODSPath, config.Resource, file.LocalPath, config.TaskNum, config.VerifyChecksum, transferCallback)
+	default:
+		return fs.DownloadFile(file.IRODSPath, config.Resource, file.LocalPath, config.VerifyChecksum, transferCallback)
+	}
+}
+
+// transferFiles runs transfer of files concurrently, results are stored in files
+func (fs *FileSystem) transferFiles(files []*DirTransferFileResult, config *DirTransferConfig, transfer func(file *DirTransferFileResult, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error)) {
+	concurrentFiles := config.ConcurrentFiles
+	if concurrentFiles <= 0 {
+		concurrentFiles = DirTransferConcurrentFilesDefault
+	}
+
+	// do not use more workers than connections to avoid waiting on the pool
+	maxConnections := fs.ioSession.GetMaxConnections()
+	if maxConnections > 0 && concurrentFiles > maxConnections {
+		concurrentFiles = maxConnections
+	}
+
+	fileChan := make(chan *DirTransferFileResult, len(files))
+	for _, file := range files {
+		if file.Error == nil {
+			fileChan <- file
+		}
+	}
+	close(fileChan)
+
+	stopMutex := sync.Mutex{}
+	stopped := false
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < concurrentFiles; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for file := range fileChan {
+				stopMutex.Lock()
+				stop := stopped
+				stopMutex.Unlock()
+
+				if stop {
+					file.Error = errors.Errorf("transfer of %q is canceled by a previous failure", file.LocalPath)
+					continue
+				}
+
+				var transferCallback common.TransferTrackerCallback
+				if config.TransferCallback != nil {
+					transferCallback = func(taskName string, processed int64, total int64) {
+						config.TransferCallback(file.LocalPath, file.IRODSPath, processed, total)
+					}
+				}
+
+				result, err := transfer(file, transferCallback)
+				file.Result = result
+				file.Error = err
+
+				if err != nil {
+					log.Debugf("failed to transfer file %q <-> %q, %v", file.LocalPath, file.IRODSPath, err)
+
+					if config.StopOnError {
+						stopMutex.Lock()
+						stopped = true
+						stopMutex.Unlock()
+					}
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+// finishDirTransfer sorts file results and returns joined errors
+func (fs *FileSystem) finishDirTransfer(result *DirTransferResult, files []*DirTransferFileResult) (*DirTransferResult, error) {
+	sort.Slice(files, func(i int, j int) bool {
+		return files[i].IRODSPath < files[j].IRODSPath
+	})
+
+	result.Files = files
+	result.EndTime = time.Now()
+
+	errs := result.GetErrors()
+	if len(errs) > 0 {
+		return result, errors.Wrapf(errors.Join(errs...), "failed to transfer %d files", len(errs))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,376 @@
+package fs
+
+import (
+	"io"
+	io_fs "io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+)
+
+// IOFS is an adapter of FileSystem implementing io/fs interfaces, fs.FS, fs.ReadDirFS, fs.StatFS, fs.GlobFS and fs.SubFS
+// names are slash-separated paths relative to the root collection, as io/fs requires
+type IOFS struct {
+	filesystem *FileSystem
+	root       string
+}
+
+// NewIOFS creates a new IOFS rooted at the given collection
+func NewIOFS(filesystem *FileSystem, root string) *IOFS {
+	return &IOFS{
+		filesystem: filesystem,
+		root:       util.GetCorrectIRODSPath(root),
+	}
+}
+
+// GetFileSystem returns FileSystem
+func (iofs *IOFS) GetFileSystem() *FileSystem {
+	return iofs.filesystem
+}
+
+// GetRoot returns the root collection path
+func (iofs *IOFS) GetRoot() string {
+	return iofs.root
+}
+
+// getIRODSPath returns iRODS path for the name
+func (iofs *IOFS) getIRODSPath(op string, name string) (string, error) {
+	if !io_fs.ValidPath(name) {
+		return "", &io_fs.PathError{Op: op, Path: name, Err: io_fs.ErrInvalid}
+	}
+
+	if name == "." {
+		return iofs.root, nil
+	}
+
+	return util.MakeIRODSPath(iofs.root, name), nil
+}
+
+// stat returns entry for the name
+func (iofs *IOFS) stat(op string, name string) (string, *Entry, error) {
+	irodsPath, err := iofs.getIRODSPath(op, name)
+	if err != nil {
+		return "", nil, err
+	}
+
+	entry, err := iofs.filesystem.Stat(irodsPath)
+	if err != nil {
+		return "", nil, newIOFSPathError(op, name, err)
+	}
+
+	return irodsPath, entry, nil
+}
+
+// Open opens the named file or directory, implements fs.FS
+func (iofs *IOFS) Open(name string) (io_fs.File, error) {
+	irodsPath, entry, err := iofs.stat("open", name)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.IsDir() {
+		return &ioFSDir{
+			iofs:    iofs,
+			name:    name,
+			entry:   entry,
+			entries: nil,
+			offset:  0,
+		}, nil
+	}
+
+	handle, err := iofs.filesystem.OpenFile(irodsPath, "", string(types.FileOpenModeReadOnly))
+	if err != nil {
+		return nil, newIOFSPathError("open", name, err)
+	}
+
+	return &ioFSFile{
+		name:   name,
+		handle: handle,
+		entry:  entry,
+	}, nil
+}
+
+// ReadDir reads the named directory and returns its entries sorted by filename, implements fs.ReadDirFS
+func (iofs *IOFS) ReadDir(name string) ([]io_fs.DirEntry, error) {
+	irodsPath, entry, err := iofs.stat("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !entry.IsDir() {
+		return nil, &io_fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+
+	entries, err := iofs.filesystem.List(irodsPath)
+	if err != nil {
+		return nil, newIOFSPathError("readdir", name, err)
+	}
+
+	dirEntries := make([]io_fs.DirEntry, 0, len(entries))
+	for _, entry := range entries {
+		dirEntries = append(dirEntries, NewIOFSFileInfo(entry))
+	}
+
+	sort.Slice(dirEntries, func(i int, j int) bool {
+		return dirEntries[i].Name() < dirEntries[j].Name()
+	})
+
+	return dirEntries, nil
+}
+
+// Stat returns a FileInfo describing the named file, implements fs.StatFS
+func (iofs *IOFS) Stat(name string) (io_fs.FileInfo, error) {
+	_, entry, err := iofs.stat("stat", name)
+	if err != nil {
+		return nil, err
+	}
+
+	return newIOFSFileInfoWithName(entry, name), nil
+}
+
+// Glob returns the names of all files matching pattern, implements fs.GlobFS
+func (iofs *IOFS) Glob(pattern string) ([]string, error) {
+	// check pattern
+	_, err := path.Match(pattern, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.ContainsAny(pattern, `*?[\`) {
+		// no meta characters, check existence
+		_, err := iofs.Stat(pattern)
+		if err != nil {
+			return nil, nil
+		}
+		return []string{pattern}, nil
+	}
+
+	if !io_fs.ValidPath(pattern) {
+		return nil, nil
+	}
+
+	entries, err := iofs.filesystem.SearchUnixWildcard(util.MakeIRODSPath(iofs.root, pattern))
+	if err != nil {
+		return nil, newIOFSPathError("glob", pattern, err)
+	}
+
+	prefix := iofs.root + "/"
+	if iofs.root == "/" {
+		prefix = "/"
+	}
+
+	matches := []string{}
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Path, prefix) {
+			continue
+		}
+
+		name := strings.TrimPrefix(entry.Path, prefix)
+
+		// server-side wildcard may match across path separators
+		matched, _ := path.Match(pattern, name)
+		if matched {
+			matches = append(matches, name)
+		}
+	}
+
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// Sub returns an FS corresponding to the subtree rooted at dir, implements fs.SubFS
+func (iofs *IOFS) Sub(dir string) (io_fs.FS, error) {
+	irodsPath, err := iofs.getIRODSPath("sub", dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if dir == "." {
+		return iofs, nil
+	}
+
+	return &IOFS{
+		filesystem: iofs.filesystem,
+		root:       irodsPath,
+	}, nil
+}
+
+// newIOFSPathError converts the error to fs.PathError
+func newIOFSPathError(op string, name string, err error) error {
+	if types.IsFileNotFoundError(err) {
+		return &io_fs.PathError{Op: op, Path: name, Err: io_fs.ErrNotExist}
+	}
+
+	if types.IsFileAlreadyExistError(err) {
+		return &io_fs.PathError{Op: op, Path: name, Err: io_fs.ErrExist}
+	}
+
+	return &io_fs.PathError{Op: op, Path: name, Err: err}
+}
+
+// IOFSFileInfo wraps Entry to implement fs.FileInfo and fs.DirEntry
+// Sys() returns the *Entry that has owner, checksum and replicas
+type IOFSFileInfo struct {
+	entry *Entry
+	name  string
+}
+
+// NewIOFSFileInfo creates a new IOFSFileInfo
+func NewIOFSFileInfo(entry *Entry) *IOFSFileInfo {
+	return &IOFSFileInfo{
+		entry: entry,
+		name:  entry.Name,
+	}
+}
+
+func newIOFSFileInfoWithName(entry *Entry, name string) *IOFSFileInfo {
+	return &IOFSFileInfo{
+		entry: entry,
+		name:  path.Base(name),
+	}
+}
+
+// Name returns base name of the file
+func (info *IOFSFileInfo) Name() string {
+	return info.name
+}
+
+// Size returns length in bytes
+func (info *IOFSFileInfo) Size() int64 {
+	return info.entry.Size
+}
+
+// Mode returns file mode bits
+func (info *IOFSFileInfo) Mode() io_fs.FileMode {
+	if info.entry.IsDir() {
+		return io_fs.ModeDir | 0o755
+	}
+	return 0o644
+}
+
+// ModTime returns modification time
+func (info *IOFSFileInfo) ModTime() time.Time {
+	return info.entry.ModifyTime
+}
+
+// IsDir returns true if it is a directory
+func (info *IOFSFileInfo) IsDir() bool {
+	return info.entry.IsDir()
+}
+
+// Sys returns *Entry
+func (info *IOFSFileInfo) Sys() any {
+	return info.entry
+}
+
+// Type returns the type bits, implements fs.DirEntry
+func (info *IOFSFileInfo) Type() io_fs.FileMode {
+	return info.Mode().Type()
+}
+
+// Info returns FileInfo, implements fs.DirEntry
+func (info *IOFSFileInfo) Info() (io_fs.FileInfo, error) {
+	return info, nil
+}
+
+// GetEntry returns Entry
+func (info *IOFSFileInfo) GetEntry() *Entry {
+	return info.entry
+}
+
+// ioFSFile is a file opened via IOFS, implements fs.File and io.Seeker
+type ioFSFile struct {
+	name   string
+	handle *FileHandle
+	entry  *Entry
+}
+
+// Stat returns FileInfo
+func (file *ioFSFile) Stat() (io_fs.FileInfo, error) {
+	return newIOFSFileInfoWithName(file.entry, file.name), nil
+}
+
+// Read reads data
+func (file *ioFSFile) Read(buffer []byte) (int, error) {
+	readLen, err := file.handle.Read(buffer)
+	if err != nil && err != io.EOF {
+		return readLen, &io_fs.PathError{Op: "read", Path: file.name, Err: err}
+	}
+	return readLen, err
+}
+
+// Seek moves file pointer
+func (file *ioFSFile) Seek(offset int64, whence int) (int64, error) {
+	newOffset, err := file.handle.Seek(offset, whence)
+	if err != nil {
+		return newOffset, &io_fs.PathError{Op: "seek", Path: file.name, Err: err}
+	}
+	return newOffset, nil
+}
+
+// Close closes the file
+func (file *ioFSFile) Close() error {
+	err := file.handle.Close()
+	if err != nil {
+		return &io_fs.PathError{Op: "close", Path: file.name, Err: err}
+	}
+	return nil
+}
+
+// ioFSDir is a directory opened via IOFS, implements fs.ReadDirFile
+type ioFSDir struct {
+	iofs    *IOFS
+	name    string
+	entry   *Entry
+	entries []io_fs.DirEntry
+	offset  int
+}
+
+// Stat returns FileInfo
+func (dir *ioFSDir) Stat() (io_fs.FileInfo, error) {
+	return newIOFSFileInfoWithName(dir.entry, dir.name), nil
+}
+
+// Read returns an error as it is a directory
+func (dir *ioFSDir) Read(buffer []byte) (int, error) {
+	return 0, &io_fs.PathError{Op: "read", Path: dir.name, Err: errors.New("is a directory")}
+}
+
+// Close closes the directory
+func (dir *ioFSDir) Close() error {
+	return nil
+}
+
+// ReadDir reads the contents of the directory, implements fs.ReadDirFile
+func (dir *ioFSDir) ReadDir(n int) ([]io_fs.DirEntry, error) {
+	if dir.entries == nil {
+		entries, err := dir.iofs.ReadDir(dir.name)
+		if err != nil {
+			return nil, err
+		}
+
+		dir.entries = entries
+	}
+
+	remaining := dir.entries[dir.offset:]
+	if n <= 0 {
+		dir.offset = len(dir.entries)
+		return remaining, nil
+	}
+
+	if len(remaining) == 0 {
+		return nil, io.EOF
+	}
+
+	if n > len(remaining) {
+		n = len(remaining)
+	}
+
+	dir.offset += n
+	return remaining[:n], nil
+}
@@ -0,0 +1,545 @@
+package fs
+
+import (
+	"io"
+	io_fs "io/fs"
+	"os"
+	"path"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+)
+
+// WritableFS is an adapter of FileSystem that provides afero/go-billy style read-write interfaces
+// names are OS style paths resolved under the root collection, permission bits are ignored as iRODS uses ACLs
+type WritableFS struct {
+	filesystem *FileSystem
+	root       string
+}
+
+// NewWritableFS creates a new WritableFS rooted at the given collection
+func NewWritableFS(filesystem *FileSystem, root string) *WritableFS {
+	return &WritableFS{
+		filesystem: filesystem,
+		root:       util.GetCorrectIRODSPath(root),
+	}
+}
+
+// GetFileSystem returns FileSystem
+func (wfs *WritableFS) GetFileSystem() *FileSystem {
+	return wfs.filesystem
+}
+
+// Root returns the root collection path
+func (wfs *WritableFS) Root() string {
+	return wfs.root
+}
+
+// Name returns the name of the filesystem
+func (wfs *WritableFS) Name() string {
+	return "irods"
+}
+
+// getIRODSPath returns iRODS path for the name, the name cannot escape the root
+func (wfs *WritableFS) getIRODSPath(name string) string {
+	// clean ".." before joining
+	return util.GetCorrectIRODSPath(path.Join(wfs.root, path.Join("/", name)))
+}
+
+// GetFileOpenMode returns file open mode for os.O_* flags
+func GetFileOpenMode(flag int) types.FileOpenMode {
+	switch flag & (os.O_RDONLY | os.O_WRONLY | os.O_RDWR) {
+	case os.O_WRONLY:
+		if flag&os.O_APPEND != 0 {
+			return types.FileOpenModeAppend
+		}
+		if flag&os.O_TRUNC != 0 {
+			return types.FileOpenModeWriteTruncate
+		}
+		return types.FileOpenModeWriteOnly
+	case os.O_RDWR:
+		if flag&os.O_APPEND != 0 {
+			return types.FileOpenModeReadAppend
+		}
+		if flag&os.O_TRUNC != 0 {
+			return types.FileOpenModeWriteTruncate
+		}
+		return types.FileOpenModeReadWrite
+	default:
+		return types.FileOpenModeReadOnly
+	}
+}
+
+// Create creates or truncates the named file, the file is opened with read-write mode
+func (wfs *WritableFS) Create(name string) (*WritableFile, error) {
+	return wfs.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o666)
+}
+
+// Open opens the named file or directory for reading
+func (wfs *WritableFS) Open(name string) (*WritableFile, error) {
+	return wfs.OpenFile(name, os.O_RDONLY, 0)
+}
+
+// OpenFile opens the named file with os.O_* flags
+func (wfs *WritableFS) OpenFile(name string, flag int, perm os.FileMode) (*WritableFile, error) {
+	irodsPath := wfs.getIRODSPath(name)
+	mode := GetFileOpenMode(flag)
+
+	entry, err := wfs.filesystem.Stat(irodsPath)
+	if err != nil {
+		if !types.IsFileNotFoundError(err) {
+			return nil, newIOFSPathError("open", name, err)
+		}
+
+		if flag&os.O_CREATE == 0 {
+			return nil, &io_fs.PathError{Op: "open", Path: name, Err: io_fs.ErrNotExist}
+		}
+
+		// create
+		if mode.IsReadOnly() {
+			err = wfs.filesystem.Touch(irodsPath, "", false, nil, "", nil)
+			if err != nil {
+				return nil, newIOFSPathError("open", name, err)
+			}
+		} else {
+			handle, err := wfs.filesystem.CreateFile(irodsPath, "", string(mode))
+			if err != nil {
+				return nil, newIOFSPathError("open", name, err)
+			}
+
+			return newWritableFile(wfs, name, irodsPath, handle.GetEntry(), handle), nil
+		}
+	} else {
+		if flag&(os.O_CREATE|os.O_EXCL) == os.O_CREATE|os.O_EXCL {
+			return nil, &io_fs.PathError{Op: "open", Path: name, Err: io_fs.ErrExist}
+		}
+
+		if entry.IsDir() {
+			if !mode.IsReadOnly() {
+				return nil, &io_fs.PathError{Op: "open", Path: name, Err: syscall.EISDIR}
+			}
+
+			return newWritableFile(wfs, name, irodsPath, entry, nil), nil
+		}
+	}
+
+	handle, err := wfs.filesystem.OpenFile(irodsPath, "", string(mode))
+	if err != nil {
+		return nil, newIOFSPathError("open", name, err)
+	}
+
+	return newWritableFile(wfs, name, irodsPath, handle.GetEntry(), handle), nil
+}
+
+// Stat returns a FileInfo describing the named file
+func (wfs *WritableFS) Stat(name string) (os.FileInfo, error) {
+	entry, err := wfs.filesystem.Stat(wfs.getIRODSPath(name))
+	if err != nil {
+		return nil, newIOFSPathError("stat", name, err)
+	}
+
+	return NewIOFSFileInfo(entry), nil
+}
+
+// Lstat returns a FileInfo describing the named file, same as Stat as iRODS has no symbolic links
+func (wfs *WritableFS) Lstat(name string) (os.FileInfo, error) {
+	return wfs.Stat(name)
+}
+
+// Mkdir creates a directory, the parent directory must exist
+func (wfs *WritableFS) Mkdir(name string, perm os.FileMode) error {
+	irodsPath := wfs.getIRODSPath(name)
+
+	if wfs.filesystem.Exists(irodsPath) {
+		return &io_fs.PathError{Op: "mkdir", Path: name, Err: io_fs.ErrExist}
+	}
+
+	if !wfs.filesystem.ExistsDir(path.Dir(irodsPath)) {
+		return &io_fs.PathError{Op: "mkdir", Path: name, Err: io_fs.ErrNotExist}
+	}
+
+	err := wfs.filesystem.MakeDir(irodsPath, false)
+	if err != nil {
+		return newIOFSPathError("mkdir", name, err)
+	}
+
+	return nil
+}
+
+// MkdirAll creates a directory with its parents, returns nil if the directory already exists
+func (wfs *WritableFS) MkdirAll(name string, perm os.FileMode) error {
+	irodsPath := wfs.getIRODSPath(name)
+
+	entry, err := wfs.filesystem.Stat(irodsPath)
+	if err == nil {
+		if entry.IsDir() {
+			return nil
+		}
+
+		return &io_fs.PathError{Op: "mkdir", Path: name, Err: syscall.ENOTDIR}
+	}
+
+	if !types.IsFileNotFoundError(err) {
+		return newIOFSPathError("mkdir", name, err)
+	}
+
+	err = wfs.filesystem.MakeDir(irodsPath, true)
+	if err != nil {
+		return newIOFSPathError("mkdir", name, err)
+	}
+
+	return nil
+}
+
+// Remove removes the named file or empty directory
+func (wfs *WritableFS) Remove(name string) error {
+	irodsPath := wfs.getIRODSPath(name)
+
+	entry, err := wfs.filesystem.Stat(irodsPath)
+	if err != nil {
+		return newIOFSPathError("remove", name, err)
+	}
+
+	if entry.IsDir() {
+		err = wfs.filesystem.RemoveDir(irodsPath, false, true)
+		if err != nil {
+			if types.IsCollectionNotEmptyError(err) {
+				return &io_fs.PathError{Op: "remove", Path: name, Err: syscall.ENOTEMPTY}
+			}
+			return newIOFSPathError("remove", name, err)
+		}
+
+		return nil
+	}
+
+	err = wfs.filesystem.RemoveFile(irodsPath, true)
+	if err != nil {
+		return newIOFSPathError("remove", name, err)
+	}
+
+	return nil
+}
+
+// RemoveAll removes the named file or directory with its children, returns nil if it does not exist
+func (wfs *WritableFS) RemoveAll(name string) error {
+	irodsPath := wfs.getIRODSPath(name)
+
+	entry, err := wfs.filesystem.Stat(irodsPath)
+	if err != nil {
+		if types.IsFileNotFoundError(err) {
+			return nil
+		}
+
+		return newIOFSPathError("removeall", name, err)
+	}
+
+	if entry.IsDir() {
+		err = wfs.filesystem.RemoveDir(irodsPath, true, true)
+	} else {
+		err = wfs.filesystem.RemoveFile(irodsPath, true)
+	}
+
+	if err != nil && !types.IsFileNotFoundError(err) {
+		return newIOFSPathError("removeall", name, err)
+	}
+
+	return nil
+}
+
+// Rename renames oldname to newname, an existing file at newname is replaced
+func (wfs *WritableFS) Rename(oldname string, newname string) error {
+	irodsSrcPath := wfs.getIRODSPath(oldname)
+	irodsDestPath := wfs.getIRODSPath(newname)
+
+	srcEntry, err := wfs.filesystem.Stat(irodsSrcPath)
+	if err != nil {
+		if types.IsFileNotFoundError(err) {
+			return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: io_fs.ErrNotExist}
+		}
+
+		return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: err}
+	}
+
+	if irodsSrcPath == irodsDestPath {
+		return nil
+	}
+
+	destEntry, err := wfs.filesystem.Stat(irodsDestPath)
+	if err == nil {
+		if srcEntry.IsDir() || destEntry.IsDir() {
+			return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: io_fs.ErrExist}
+		}
+
+		// replace
+		err = wfs.filesystem.RemoveFile(irodsDestPath, true)
+		if err != nil {
+			return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: err}
+		}
+	} else if !types.IsFileNotFoundError(err) {
+		return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: err}
+	}
+
+	if srcEntry.IsDir() {
+		err = wfs.filesystem.RenameDirToDir(irodsSrcPath, irodsDestPath)
+	} else {
+		err = wfs.filesystem.RenameFileToFile(irodsSrcPath, irodsDestPath)
+	}
+
+	if err != nil {
+		return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: err}
+	}
+
+	return nil
+}
+
+// Chtimes changes the modification time of the named file, access time is not supported by iRODS
+func (wfs *WritableFS) Chtimes(name string, atime time.Time, mtime time.Time) error {
+	irodsPath := wfs.getIRODSPath(name)
+
+	if !wfs.filesystem.Exists(irodsPath) {
+		return &io_fs.PathError{Op: "chtimes", Path: name, Err: io_fs.ErrNotExist}
+	}
+
+	secondsSinceEpoch := int(mtime.Unix())
+	err := wfs.filesystem.Touch(irodsPath, "", true, nil, "", &secondsSinceEpoch)
+	if err != nil {
+		return newIOFSPathError("chtimes", name, err)
+	}
+
+	return nil
+}
+
+// Chmod checks existence of the named file, mode is ignored as iRODS uses ACLs
+func (wfs *WritableFS) Chmod(name string, mode os.FileMode) error {
+	if !wfs.filesystem.Exists(wfs.getIRODSPath(name)) {
+		return &io_fs.PathError{Op: "chmod", Path: name, Err: io_fs.ErrNotExist}
+	}
+
+	return nil
+}
+
+// Chown is not supported, iRODS uses ACLs
+func (wfs *WritableFS) Chown(name string, uid int, gid int) error {
+	return &io_fs.PathError{Op: "chown", Path: name, Err: syscall.ENOTSUP}
+}
+
+// WritableFile is a file or directory opened via WritableFS
+type WritableFile struct {
+	wfs       *WritableFS
+	name      string
+	irodsPath string
+	entry     *Entry
+	handle    *FileHandle // nil for directory
+
+	// for Readdir
+	dirEntries []*Entry
+	dirOffset  int
+}
+
+func newWritableFile(wfs *WritableFS, name string, irodsPath string, entry *Entry, handle *FileHandle) *WritableFile {
+	return &WritableFile{
+		wfs:        wfs,
+		name:       name,
+		irodsPath:  irodsPath,
+		entry:      entry,
+		handle:     handle,
+		dirEntries: nil,
+		dirOffset:  0,
+	}
+}
+
+// GetFileHandle returns FileHandle, returns nil for directory
+func (file *WritableFile) GetFileHandle() *FileHandle {
+	return file.handle
+}
+
+// Name returns the name of the file as presented to OpenFile
+func (file *WritableFile) Name() string {
+	return file.name
+}
+
+func (file *WritableFile) pathError(op string, err error) error {
+	if err == nil || err == io.EOF {
+		return err
+	}
+
+	return &io_fs.PathError{Op: op, Path: file.name, Err: err}
+}
+
+func (file *WritableFile) checkFile(op string) error {
+	if file.handle == nil {
+		return &io_fs.PathError{Op: op, Path: file.name, Err: syscall.EISDIR}
+	}
+	return nil
+}
+
+// Read reads data
+func (file *WritableFile) Read(buffer []byte) (int, error) {
+	if err := file.checkFile("read"); err != nil {
+		return 0, err
+	}
+
+	readLen, err := file.handle.Read(buffer)
+	return readLen, file.pathError("read", err)
+}
+
+// ReadAt reads data from given offset
+func (file *WritableFile) ReadAt(buffer []byte, offset int64) (int, error) {
+	if err := file.checkFile("read"); err != nil {
+		return 0, err
+	}
+
+	readLen, err := file.handle.ReadAt(buffer, offset)
+	return readLen, file.pathError("read", err)
+}
+
+// Write writes data
+func (file *WritableFile) Write(data []byte) (int, error) {
+	if err := file.checkFile("write"); err != nil {
+		return 0, err
+	}
+
+	writeLen, err := file.handle.Write(data)
+	return writeLen, file.pathError("write", err)
+}
+
+// WriteAt writes data to given offset
+func (file *WritableFile) WriteAt(data []byte, offset int64) (int, error) {
+	if err := file.checkFile("write"); err != nil {
+		return 0, err
+	}
+
+	writeLen, err := file.handle.WriteAt(data, offset)
+	return writeLen, file.pathError("write", err)
+}
+
+// WriteString writes a string
+func (file *WritableFile) WriteString(s string) (int, error) {
+	return file.Write([]byte(s))
+}
+
+// Seek moves file pointer
+func (file *WritableFile) Seek(offset int64, whence int) (int64, error) {
+	if err := file.checkFile("seek"); err != nil {
+		return 0, err
+	}
+
+	newOffset, err := file.handle.Seek(offset, whence)
+	return newOffset, file.pathError("seek", err)
+}
+
+// Truncate truncates the file
+func (file *WritableFile) Truncate(size int64) error {
+	if err := file.checkFile("truncate"); err != nil {
+		return err
+	}
+
+	return file.pathError("truncate", file.handle.Truncate(size))
+}
+
+// Sync flushes buffered data
+func (file *WritableFile) Sync() error {
+	if file.handle == nil {
+		return nil
+	}
+
+	return file.pathError("sync", file.handle.Flush())
+}
+
+// Lock locks the file with an exclusive data object lock
+func (file *WritableFile) Lock() error {
+	if err := file.checkFile("lock"); err != nil {
+		return err
+	}
+
+	return file.pathError("lock", file.handle.LockDataObject(true))
+}
+
+// Unlock unlocks the data object lock
+func (file *WritableFile) Unlock() error {
+	if err := file.checkFile("unlock"); err != nil {
+		return err
+	}
+
+	return file.pathError("unlock", file.handle.UnlockDataObject())
+}
+
+// Stat returns FileInfo
+func (file *WritableFile) Stat() (os.FileInfo, error) {
+	if file.handle != nil {
+		// size changes while writing
+		entry, err := file.wfs.filesystem.Stat(file.irodsPath)
+		if err == nil {
+			return NewIOFSFileInfo(entry), nil
+		}
+	}
+
+	return NewIOFSFileInfo(file.entry), nil
+}
+
+// Readdir reads the contents of the directory, returns all entries if count <= 0
+func (file *WritableFile) Readdir(count int) ([]os.FileInfo, error) {
+	if file.handle != nil {
+		return nil, &io_fs.PathError{Op: "readdir", Path: file.name, Err: syscall.ENOTDIR}
+	}
+
+	if file.dirEntries == nil {
+		entries, err := file.wfs.filesystem.List(file.irodsPath)
+		if err != nil {
+			return nil, newIOFSPathError("readdir", file.name, err)
+		}
+
+		sort.Slice(entries, func(i int, j int) bool {
+			return entries[i].Name < entries[j].Name
+		})
+
+		file.dirEntries = entries
+	}
+
+	remaining := file.dirEntries[file.dirOffset:]
+	if count > 0 {
+		if len(remaining) == 0 {
+			return nil, io.EOF
+		}
+
+		if count < len(remaining) {
+			remaining = remaining[:count]
+		}
+	}
+
+	file.dirOffset += len(remaining)
+
+	infos := make([]os.FileInfo, 0, len(remaining))
+	for _, entry := range remaining {
+		infos = append(infos, NewIOFSFileInfo(entry))
+	}
+
+	return infos, nil
+}
+
+// Readdirnames reads names of the contents of the directory, returns all names if count <= 0
+func (file *WritableFile) Readdirnames(count int) ([]string, error) {
+	infos, err := file.Readdir(count)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(infos))
+	for _, info := range infos {
+		names = append(names, info.Name())
+	}
+
+	return names, nil
+}
+
+// Close closes the file
+func (file *WritableFile) Close() error {
+	if file.handle == nil {
+		return nil
+	}
+
+	return file.pathError("close", file.handle.Close())
+}
@@ -0,0 +1,280 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+const (
+	// gsiNoDelegationFlag is sent after handshake to tell the server that credentials are not delegated
+	gsiNoDelegationFlag string = "0"
+)
+
+var (
+	x509DNAttributeNames = map[string]string{
+		"2.5.4.3":                    "CN",
+		"2.5.4.5":                    "serialNumber",
+		"2.5.4.6":                    "C",
+		"2.5.4.7":                    "L",
+		"2.5.4.8":                    "ST",
+		"2.5.4.9":                    "street",
+		"2.5.4.10":                   "O",
+		"2.5.4.11":                   "OU",
+		"0.9.2342.19200300.100.1.1":  "UID",
+		"0.9.2342.19200300.100.1.25": "DC",
+		"1.2.840.113549.1.9.1":       "emailAddress",
+	}
+)
+
+// GSITokenTransport sends and receives GSS-API tokens
+type GSITokenTransport interface {
+	SendToken(token []byte) error
+	RecvToken() ([]byte, error)
+}
+
+// GSIClient establishes GSI security context with X.509 proxy certificate
+// GSI tokens are TLS records, the TLS handshake is done by exchanging them as GSS-API tokens
+type GSIClient struct {
+	certificate *tls.Certificate
+	caCertPool  *x509.CertPool
+	serverDN    string
+}
+
+// NewGSIClient creates a GSIClient, loads proxy certificate and trusted CA certificates
+func NewGSIClient(config *types.IRODSGSIConfig) (*GSIClient, error) {
+	if config == nil {
+		config = &types.IRODSGSIConfig{}
+	}
+
+	certificate, err := config.LoadProxyCertificate()
+	if err != nil {
+		return nil, err
+	}
+
+	caCertPool, err := config.LoadCACert()
+	if err != nil {
+		return nil, err
+	}
+
+	return &GSIClient{
+		certificate: certificate,
+		caCertPool:  caCertPool,
+	}, nil
+}
+
+// GetClientDN returns DN of the proxy certificate
+func (gsi *GSIClient) GetClientDN() string {
+	dn, err := GetX509DN(gsi.certificate.Leaf)
+	if err != nil {
+		return ""
+	}
+	return dn
+}
+
+// GetServerDN returns DN of the server certificate, available after the security context is established
+func (gsi *GSIClient) GetServerDN() string {
+	return gsi.serverDN
+}
+
+// EstablishContext establishes security context with the server
+// the server certificate is verified with trusted CA certificates, and its DN must match serverDN if serverDN is not empty
+func (gsi *GSIClient) EstablishContext(transport GSITokenTransport, serverDN string) error {
+	tokenConn := newGSITokenConn(transport)
+
+	tlsConfig := &tls.Config{
+		// always send the proxy certificate, servers may not list the CA of the user certificate
+		GetClientCertificate: func(_ *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return gsi.certificate, nil
+		},
+		// GSI does not verify host name, server DN is verified instead
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return gsi.verifyServerCertificate(rawCerts, serverDN)
+		},
+		// TLS 1.3 sends post-handshake messages the GSS-API token exchange does not expect
+		MaxVersion: tls.VersionTLS12,
+	}
+
+	tlsConn := tls.Client(tokenConn, tlsConfig)
+	err := tlsConn.Handshake()
+	if err != nil {
+		return errors.Wrapf(err, "failed to establish GSI security context")
+	}
+
+	_, err = tlsConn.Write([]byte(gsiNoDelegationFlag))
+	if err != nil {
+		return errors.Wrapf(err, "failed to send GSI delegation flag")
+	}
+
+	err = tokenConn.flush()
+	if err != nil {
+		return errors.Wrapf(err, "failed to send GSI delegation flag")
+	}
+
+	return nil
+}
+
+// verifyServerCertificate verifies the server certificate chain and DN
+func (gsi *GSIClient) verifyServerCertificate(rawCerts [][]byte, serverDN string) error {
+	if len(rawCerts) == 0 {
+		return errors.Errorf("server did not send a certificate")
+	}
+
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for idx, rawCert := range rawCerts {
+		cert, err := x509.ParseCertificate(rawCert)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse server certificate")
+		}
+		certs[idx] = cert
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         gsi.caCertPool,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to verify server certificate")
+	}
+
+	dn, err := GetX509DN(certs[0])
+	if err != nil {
+		return err
+	}
+
+	if len(serverDN) > 0 && strings.TrimSpace(serverDN) != dn {
+		return errors.Errorf("server DN %q does not match expected DN %q", dn, serverDN)
+	}
+
+	gsi.serverDN = dn
+
+	return nil
+}
+
+// GetX509DN returns DN of the certificate subject in OpenSSL one-line format, e.g., /C=US/O=Grid/CN=host/irods.example.com
+func GetX509DN(cert *x509.Certificate) (string, error) {
+	var rdnSequence pkix.RDNSequence
+	_, err := asn1.Unmarshal(cert.RawSubject, &rdnSequence)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse certificate subject")
+	}
+
+	sb := strings.Builder{}
+	for _, rdn := range rdnSequence {
+		sb.WriteString("/")
+
+		for idx, attr := range rdn {
+			if idx > 0 {
+				sb.WriteString("+")
+			}
+
+			name, ok := x509DNAttributeNames[attr.Type.String()]
+			if !ok {
+				name = attr.Type.String()
+			}
+
+			sb.WriteString(fmt.Sprintf("%s=%v", name, attr.Value))
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// gsiTokenConn is a net.Conn that sends and receives TLS records as GSS-API tokens
+// records written are sent as a token when the next read happens or flush is called
+type gsiTokenConn struct {
+	transport   GSITokenTransport
+	writeBuffer bytes.Buffer
+	readBuffer  bytes.Buffer
+}
+
+func newGSITokenConn(transport GSITokenTransport) *gsiTokenConn {
+	return &gsiTokenConn{
+		transport: transport,
+	}
+}
+
+func (conn *gsiTokenConn) flush() error {
+	if conn.writeBuffer.Len() == 0 {
+		return nil
+	}
+
+	token := make([]byte, conn.writeBuffer.Len())
+	copy(token, conn.writeBuffer.Bytes())
+	conn.writeBuffer.Reset()
+
+	return conn.transport.SendToken(token)
+}
+
+func (conn *gsiTokenConn) Read(b []byte) (int, error) {
+	err := conn.flush()
+	if err != nil {
+		return 0, err
+	}
+
+	for conn.readBuffer.Len() == 0 {
+		token, err := conn.transport.RecvToken()
+		if err != nil {
+			return 0, err
+		}
+
+		conn.readBuffer.Write(token)
+	}
+
+	return conn.readBuffer.Read(b)
+}
+
+func (conn *gsiTokenConn) Write(b []byte) (int, error) {
+	return conn.writeBuffer.Write(b)
+}
+
+func (conn *gsiTokenConn) Close() error {
+	return nil
+}
+
+func (conn *gsiTokenConn) LocalAddr() net.Addr {
+	return gsiTokenAddr{}
+}
+
+func (conn *gsiTokenConn) RemoteAddr() net.Addr {
+	return gsiTokenAddr{}
+}
+
+// deadlines are handled by the transport
+func (conn *gsiTokenConn) SetDeadline(t time.Time) error {
+	return nil
+}
+
+func (conn *gsiTokenConn) SetReadDeadline(t time.Time) error {
+	return nil
+}
+
+func (conn *gsiTokenConn) SetWriteDeadline(t time.Time) error {
+	return nil
+}
+
+type gsiTokenAddr struct{}
+
+func (addr gsiTokenAddr) Network() string {
+	return "gsi"
+}
+
+func (addr gsiTokenAddr) String() string {
+	return "gsi"
+}
@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// GSSTokenMaxLength is the max length of GSS-API token exchanged
+	GSSTokenMaxLength int = 1024 * 1024
+)
+
+// MarshalGSSToken returns a GSS-API token prefixed with its length in 4 bytes big endian, as exchanged with iRODS server
+func MarshalGSSToken(token []byte) []byte {
+	buffer := make([]byte, 4+len(token))
+	binary.BigEndian.PutUint32(buffer, uint32(len(token)))
+	copy(buffer[4:], token)
+	return buffer
+}
+
+// ReadGSSToken reads a GSS-API token prefixed with its length
+func ReadGSSToken(reader io.Reader) ([]byte, error) {
+	lengthBuffer := make([]byte, 4)
+	_, err := io.ReadFull(reader, lengthBuffer)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read GSS-API token length")
+	}
+
+	tokenLength := int(binary.BigEndian.Uint32(lengthBuffer))
+	if tokenLength > GSSTokenMaxLength {
+		return nil, errors.Errorf("GSS-API token length %d exceeds max length %d", tokenLength, GSSTokenMaxLength)
+	}
+
+	token := make([]byte, tokenLength)
+	if tokenLength == 0 {
+		return token, nil
+	}
+
+	_, err = io.ReadFull(reader, token)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read GSS-API token")
+	}
+
+	return token, nil
+}
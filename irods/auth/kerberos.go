@@ -0,0 +1,208 @@
+package auth
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	krb_client "github.com/jcmturner/gokrb5/v8/client"
+	krb_config "github.com/jcmturner/gokrb5/v8/config"
+	krb_credentials "github.com/jcmturner/gokrb5/v8/credentials"
+	krb_crypto "github.com/jcmturner/gokrb5/v8/crypto"
+	krb_gssapi "github.com/jcmturner/gokrb5/v8/gssapi"
+	krb_flags "github.com/jcmturner/gokrb5/v8/iana/flags"
+	krb_keyusage "github.com/jcmturner/gokrb5/v8/iana/keyusage"
+	krb_keytab "github.com/jcmturner/gokrb5/v8/keytab"
+	krb_messages "github.com/jcmturner/gokrb5/v8/messages"
+	krb_spnego "github.com/jcmturner/gokrb5/v8/spnego"
+	krb_types "github.com/jcmturner/gokrb5/v8/types"
+)
+
+// KerberosClient creates GSS-API kerberos tokens to authenticate to iRODS server
+type KerberosClient struct {
+	client        *krb_client.Client
+	sessionKey    krb_types.EncryptionKey
+	authenticator *krb_types.Authenticator
+}
+
+// NewKerberosClient creates a KerberosClient, logs in with keytab or loads credential cache
+// defaultPrincipal is used as a client principal for keytab if it is not configured
+func NewKerberosClient(config *types.IRODSKerberosConfig, defaultPrincipal string) (*KerberosClient, error) {
+	if config == nil {
+		config = &types.IRODSKerberosConfig{}
+	}
+
+	configFile := config.GetConfigFile()
+	krb5Config, err := krb_config.Load(configFile)
+	if err != nil {
+		// gokrb5 returns a usable config with an error for unsupported directives
+		var unsupportedErr krb_config.UnsupportedDirective
+		if !errors.As(err, &unsupportedErr) {
+			return nil, errors.Wrapf(err, "failed to load kerberos configuration file %q", configFile)
+		}
+	}
+
+	var client *krb_client.Client
+	if config.UseKeytab() {
+		keytab, err := krb_keytab.Load(config.KeytabFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load keytab file %q", config.KeytabFile)
+		}
+
+		principal := config.Principal
+		if len(principal) == 0 {
+			principal = defaultPrincipal
+		}
+
+		username, realm := splitKerberosPrincipal(principal)
+		if len(realm) == 0 {
+			realm = krb5Config.LibDefaults.DefaultRealm
+		}
+
+		client = krb_client.NewWithKeytab(username, realm, keytab, krb5Config, krb_client.DisablePAFXFAST(true))
+		err = client.Login()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to login to kerberos as %q with keytab %q", principal, config.KeytabFile)
+		}
+	} else {
+		ccacheFile, err := config.GetCredentialCacheFile()
+		if err != nil {
+			return nil, err
+		}
+
+		ccache, err := krb_credentials.LoadCCache(ccacheFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load kerberos credential cache file %q", ccacheFile)
+		}
+
+		client, err = krb_client.NewFromCCache(ccache, krb5Config, krb_client.DisablePAFXFAST(true))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create kerberos client from credential cache file %q", ccacheFile)
+		}
+	}
+
+	return &KerberosClient{
+		client: client,
+	}, nil
+}
+
+// GetClientPrincipal returns the client principal (user@REALM), empty if the client is released
+func (krb *KerberosClient) GetClientPrincipal() string {
+	if krb.client == nil {
+		return ""
+	}
+
+	return krb.client.Credentials.CName().PrincipalNameString() + "@" + krb.client.Credentials.Realm()
+}
+
+// IsReleased checks if the kerberos client is released
+func (krb *KerberosClient) IsReleased() bool {
+	return krb.client == nil
+}
+
+// InitSecContext returns the initial context token (AP-REQ) for the service principal
+// if mutual is true, the server returns a token (AP-REP) that must be verified with VerifyServerToken
+func (krb *KerberosClient) InitSecContext(servicePrincipal string, mutual bool) ([]byte, error) {
+	if krb.client == nil {
+		return nil, errors.Errorf("kerberos client is released")
+	}
+
+	// realm of the service is resolved with domain_realm mapping of kerberos configuration
+	spn, _ := splitKerberosPrincipal(servicePrincipal)
+
+	ticket, sessionKey, err := krb.client.GetServiceTicket(spn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get kerberos service ticket for %q", servicePrincipal)
+	}
+
+	gssFlags := []int{krb_gssapi.ContextFlagReplay}
+	apOptions := []int{}
+	if mutual {
+		gssFlags = append(gssFlags, krb_gssapi.ContextFlagMutual)
+		apOptions = append(apOptions, krb_flags.APOptionMutualRequired)
+	}
+
+	token, err := krb_spnego.NewKRB5TokenAPREQ(krb.client, ticket, sessionKey, gssFlags, apOptions)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create kerberos AP-REQ for %q", servicePrincipal)
+	}
+
+	// keep the authenticator to verify AP-REP
+	authenticatorBytes, err := krb_crypto.DecryptEncPart(token.APReq.EncryptedAuthenticator, sessionKey, krb_keyusage.AP_REQ_AUTHENTICATOR)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decrypt kerberos authenticator")
+	}
+
+	authenticator := &krb_types.Authenticator{}
+	err = authenticator.Unmarshal(authenticatorBytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal kerberos authenticator")
+	}
+
+	tokenBytes, err := token.Marshal()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal kerberos AP-REQ")
+	}
+
+	krb.sessionKey = sessionKey
+	krb.authenticator = authenticator
+
+	return tokenBytes, nil
+}
+
+// VerifyServerToken verifies the token (AP-REP) returned by the server for mutual authentication
+func (krb *KerberosClient) VerifyServerToken(tokenBytes []byte) error {
+	if krb.authenticator == nil {
+		return errors.Errorf("kerberos security context is not initialized")
+	}
+
+	token := krb_spnego.KRB5Token{}
+	err := token.Unmarshal(tokenBytes)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal kerberos token from server")
+	}
+
+	if token.IsKRBError() {
+		return errors.Errorf("server returned kerberos error: %s", token.KRBError.Error())
+	}
+
+	if !token.IsAPRep() {
+		return errors.Errorf("server returned unexpected kerberos token")
+	}
+
+	encPartBytes, err := krb_crypto.DecryptEncPart(token.APRep.EncPart, krb.sessionKey, krb_keyusage.AP_REP_ENCPART)
+	if err != nil {
+		return errors.Wrapf(err, "failed to decrypt kerberos AP-REP")
+	}
+
+	encPart := krb_messages.EncAPRepPart{}
+	err = encPart.Unmarshal(encPartBytes)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal kerberos AP-REP")
+	}
+
+	// RFC 4120 3.2.5, the server must return the timestamp of the authenticator
+	if !encPart.CTime.Equal(krb.authenticator.CTime) || encPart.Cusec != krb.authenticator.Cusec {
+		return errors.Errorf("kerberos AP-REP does not match the authenticator, mutual authentication failed")
+	}
+
+	return nil
+}
+
+// Release releases the kerberos client
+func (krb *KerberosClient) Release() {
+	if krb.client != nil {
+		krb.client.Destroy()
+		krb.client = nil
+	}
+}
+
+// splitKerberosPrincipal splits a principal into name and realm
+func splitKerberosPrincipal(principal string) (string, string) {
+	idx := strings.LastIndex(principal, "@")
+	if idx < 0 {
+		return principal, ""
+	}
+
+	return principal[:idx], principal[idx+1:]
+}
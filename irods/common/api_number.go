@@ -186,6 +186,8 @@ const (
 	AUTH_PLUG_REQ_AN  APINumber = 1201
 	AUTH_PLUG_RESP_AN APINumber = 1202
 
+	GENQUERY2_AN APINumber = 10221
+
 	GET_FILE_DESCRIPTOR_INFO_APN         APINumber = 20000
 	ATOMIC_APPLY_METADATA_OPERATIONS_APN APINumber = 20002
 	REPLICA_CLOSE_APN                    APINumber = 20004
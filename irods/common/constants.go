@@ -12,6 +12,10 @@ const (
 	MaxNameLength       int = 64
 	ReadWriteBufferSize int = 1024 * 1024 * 4 // 4MB
 
+	// bulk operation
+	MaxBulkOperationFiles int = 50
+	BulkOperationBufSize  int = 1024 * 1024 * 4 // 4MB
+
 	/*
 		MAX_SQL_ATTR               int = 50
 		MAX_PATH_ALLOWED           int = 1024
@@ -0,0 +1,29 @@
+package common
+
+// GenQuerySelectOption is an option for a selected column in GenQuery (aggregates and ordering)
+type GenQuerySelectOption int
+
+// select options
+const (
+	GENQUERY_SELECT_DEFAULT GenQuerySelectOption = 1
+	GENQUERY_SELECT_MIN     GenQuerySelectOption = 2
+	GENQUERY_SELECT_MAX     GenQuerySelectOption = 3
+	GENQUERY_SELECT_SUM     GenQuerySelectOption = 4
+	GENQUERY_SELECT_AVG     GenQuerySelectOption = 5
+	GENQUERY_SELECT_COUNT   GenQuerySelectOption = 6
+
+	GENQUERY_ORDER_BY      GenQuerySelectOption = 0x400
+	GENQUERY_ORDER_BY_DESC GenQuerySelectOption = 0x800
+)
+
+// GenQueryOption is an option for GenQuery
+type GenQueryOption int
+
+// query options
+const (
+	GENQUERY_RETURN_TOTAL_ROW_COUNT GenQueryOption = 0x20
+	GENQUERY_NO_DISTINCT            GenQueryOption = 0x40
+	GENQUERY_QUOTA_QUERY            GenQueryOption = 0x80
+	GENQUERY_AUTO_CLOSE             GenQueryOption = 0x100
+	GENQUERY_UPPER_CASE_WHERE       GenQueryOption = 0x200
+)
@@ -35,6 +35,7 @@ const (
 	ICAT_COLUMN_D_COMMENTS      ICATColumnNumber = 418
 	ICAT_COLUMN_D_CREATE_TIME   ICATColumnNumber = 419
 	ICAT_COLUMN_D_MODIFY_TIME   ICATColumnNumber = 420
+	ICAT_COLUMN_DATA_MODE       ICATColumnNumber = 421
 	ICAT_COLUMN_D_RESC_HIER     ICATColumnNumber = 422
 	ICAT_COLUMN_D_RESC_ID       ICATColumnNumber = 423
 	ICAT_COLUMN_D_ACCESS_TIME   ICATColumnNumber = 424
@@ -202,6 +203,21 @@ const (
 	ICAT_COLUMN_TICKET_OWNER_NAME              ICATColumnNumber = 2229
 	ICAT_COLUMN_TICKET_OWNER_ZONE              ICATColumnNumber = 2230
 
+	// Delay Rule
+	ICAT_COLUMN_RULE_EXEC_ID                 ICATColumnNumber = 1000
+	ICAT_COLUMN_RULE_EXEC_NAME               ICATColumnNumber = 1001
+	ICAT_COLUMN_RULE_EXEC_REI_FILE_PATH      ICATColumnNumber = 1002
+	ICAT_COLUMN_RULE_EXEC_USER_NAME          ICATColumnNumber = 1003
+	ICAT_COLUMN_RULE_EXEC_ADDRESS            ICATColumnNumber = 1004
+	ICAT_COLUMN_RULE_EXEC_TIME               ICATColumnNumber = 1005
+	ICAT_COLUMN_RULE_EXEC_FREQUENCY          ICATColumnNumber = 1006
+	ICAT_COLUMN_RULE_EXEC_PRIORITY           ICATColumnNumber = 1007
+	ICAT_COLUMN_RULE_EXEC_ESTIMATED_EXE_TIME ICATColumnNumber = 1008
+	ICAT_COLUMN_RULE_EXEC_NOTIFICATION_ADDR  ICATColumnNumber = 1009
+	ICAT_COLUMN_RULE_EXEC_LAST_EXE_TIME      ICATColumnNumber = 1010
+	ICAT_COLUMN_RULE_EXEC_STATUS             ICATColumnNumber = 1011
+	ICAT_COLUMN_RULE_EXEC_CONTEXT            ICATColumnNumber = 1012
+
 	// fake attri index for procStatOut
 	ICAT_COLUMN_PROCESS_ID  ICATColumnNumber = 1000001
 	ICAT_COLUMN_STARTTIME   ICATColumnNumber = 1000002
@@ -212,4 +228,7 @@ const (
 	ICAT_COLUMN_REMOTE_ADDR ICATColumnNumber = 1000007
 	ICAT_COLUMN_PROG_NAME   ICATColumnNumber = 1000008
 	ICAT_COLUMN_SERVER_ADDR ICATColumnNumber = 1000009
+
+	// fake attri index for bulkOprInp
+	ICAT_COLUMN_BULK_OPR_OFFSET ICATColumnNumber = 999998
 )
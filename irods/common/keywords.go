@@ -264,4 +264,8 @@ const (
 	SPEC_COLL_REPL_NUM     KeyWord = "spec_coll_repl_num"
 
 	DISABLE_STRICT_ACL_KW KeyWord = "disable_strict_acls"
+
+	// =-=-=-=-=-=-=-
+	// irods rule engine keyword definitions
+	INSTANCE_NAME_KW KeyWord = "instance_name"
 )
@@ -179,9 +179,9 @@ func (ctx *IRODSAuthContext) GetRedacted() *IRODSAuthContext {
 	copy := NewIRODSAuthContext()
 	ctx.CopyTo(copy)
 
-	// redact password
+	// redact password and access token
 	for k := range copy.context {
-		if k == AUTH_PASSWORD_KEY || k == "password" {
+		if k == AUTH_PASSWORD_KEY || k == "password" || k == OPENID_AUTH_ACCESS_TOKEN_KEY {
 			copy.context[k] = "REDACTED"
 		}
 	}
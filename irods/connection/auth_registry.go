@@ -0,0 +1,127 @@
+package connection
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// IRODSAuthPluginFactory creates an auth plugin and its initial request context for the connection
+// used with servers supporting the auth plugin framework (iRODS 4.3+)
+type IRODSAuthPluginFactory func(conn *IRODSConnection) (IRODSAuthPlugin, *IRODSAuthContext, error)
+
+// IRODSLegacyAuthFunc authenticates the connection without the auth plugin framework
+// used with servers older than iRODS 4.3, or when no plugin factory is registered
+type IRODSLegacyAuthFunc func(conn *IRODSConnection) error
+
+// IRODSAuthSchemeHandler is a handler of an auth scheme
+type IRODSAuthSchemeHandler struct {
+	PluginFactory IRODSAuthPluginFactory
+	Legacy        IRODSLegacyAuthFunc
+}
+
+var (
+	authSchemeHandlers = map[types.AuthScheme]*IRODSAuthSchemeHandler{
+		types.AuthSchemeNative: {
+			PluginFactory: newNativeAuthPluginForConnection,
+			Legacy:        (*IRODSConnection).loginNativeLegacy,
+		},
+		types.AuthSchemePAM: {
+			PluginFactory: newPAMAuthPluginForConnection,
+			Legacy:        (*IRODSConnection).loginPAMLegacy,
+		},
+		types.AuthSchemePAMPassword: {
+			PluginFactory: newPAMAuthPluginForConnection,
+			Legacy:        (*IRODSConnection).loginPAMLegacy,
+		},
+		types.AuthSchemePAMInteractive: {
+			PluginFactory: newPAMInteractiveAuthPluginForConnection,
+			Legacy:        nil,
+		},
+		types.AuthSchemeKRB: {
+			PluginFactory: nil,
+			Legacy:        (*IRODSConnection).loginKRBLegacy,
+		},
+		types.AuthSchemeGSI: {
+			PluginFactory: nil,
+			Legacy:        (*IRODSConnection).loginGSILegacy,
+		},
+		types.AuthSchemeOpenID: {
+			PluginFactory: newOpenIDAuthPluginForConnection,
+			Legacy:        nil,
+		},
+	}
+	authSchemeHandlersLock sync.RWMutex
+)
+
+// RegisterAuthScheme registers an auth scheme with an auth plugin factory and a legacy auth func
+// one of them can be nil, registering a built-in auth scheme replaces its handler
+// the auth scheme name is also registered to types.GetAuthScheme
+func RegisterAuthScheme(authScheme types.AuthScheme, pluginFactory IRODSAuthPluginFactory, legacy IRODSLegacyAuthFunc) error {
+	if pluginFactory == nil && legacy == nil {
+		return errors.Errorf("both auth plugin factory and legacy auth func are nil for auth scheme %q", authScheme)
+	}
+
+	scheme := types.RegisterAuthScheme(string(authScheme))
+	if scheme == types.AuthSchemeUnknown {
+		return errors.Errorf("invalid auth scheme %q", authScheme)
+	}
+
+	authSchemeHandlersLock.Lock()
+	defer authSchemeHandlersLock.Unlock()
+
+	authSchemeHandlers[scheme] = &IRODSAuthSchemeHandler{
+		PluginFactory: pluginFactory,
+		Legacy:        legacy,
+	}
+
+	return nil
+}
+
+// UnregisterAuthScheme unregisters an auth scheme
+func UnregisterAuthScheme(authScheme types.AuthScheme) {
+	// keys are normalized when registered
+	scheme := types.AuthScheme(strings.TrimSpace(strings.ToLower(string(authScheme))))
+
+	authSchemeHandlersLock.Lock()
+	defer authSchemeHandlersLock.Unlock()
+
+	delete(authSchemeHandlers, scheme)
+	types.UnregisterAuthScheme(string(scheme))
+}
+
+// GetAuthSchemeHandler returns the handler registered for the auth scheme
+func GetAuthSchemeHandler(authScheme types.AuthScheme) (*IRODSAuthSchemeHandler, bool) {
+	authSchemeHandlersLock.RLock()
+	defer authSchemeHandlersLock.RUnlock()
+
+	handler, ok := authSchemeHandlers[authScheme]
+	return handler, ok
+}
+
+// login authenticates with the handler registered for the auth scheme of the account
+func (conn *IRODSConnection) login() error {
+	handler, ok := GetAuthSchemeHandler(conn.account.AuthenticationScheme)
+	if !ok {
+		newErr := types.NewConnectionConfigError(conn.account)
+		return errors.Wrapf(newErr, "unknown Authentication Scheme %q", conn.account.AuthenticationScheme)
+	}
+
+	if conn.requireNewAuthFramework() && handler.PluginFactory != nil {
+		plugin, authContext, err := handler.PluginFactory(conn)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create auth plugin for auth scheme %q", conn.account.AuthenticationScheme)
+		}
+
+		return AuthenticateClient(conn, plugin, authContext)
+	}
+
+	if handler.Legacy != nil {
+		return handler.Legacy(conn)
+	}
+
+	newErr := types.NewConnectionConfigError(conn.account)
+	return errors.Wrapf(newErr, "auth scheme %q requires auth plugin framework, not supported by the server", conn.account.AuthenticationScheme)
+}
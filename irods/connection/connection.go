@@ -40,6 +40,10 @@ type IRODSConnection struct {
 	dirtyTransaction     bool
 	mutex                sync.Mutex
 	locked               bool // true if mutex is locked
+
+	ctx         context.Context // context bound to the connection, can be nil
+	ctxStopFunc func() bool
+	ctxMutex    sync.Mutex // guards ctx and socket against concurrent interruption
 }
 
 // NewIRODSConnection create a IRODSConnection
@@ -89,6 +93,103 @@ func (conn *IRODSConnection) Unlock() {
 	conn.mutex.Unlock()
 }
 
+// SetContext binds a context to the connection
+// while the context is bound, socket IO is aborted as soon as the context is done, and the connection is marked as failed
+// pass nil to unbind the context
+func (conn *IRODSConnection) SetContext(ctx context.Context) {
+	conn.ctxMutex.Lock()
+	defer conn.ctxMutex.Unlock()
+
+	if conn.ctxStopFunc != nil {
+		conn.ctxStopFunc()
+		conn.ctxStopFunc = nil
+	}
+
+	conn.ctx = ctx
+
+	if ctx != nil && ctx.Done() != nil {
+		conn.ctxStopFunc = context.AfterFunc(ctx, func() {
+			conn.interruptIO(ctx)
+		})
+	}
+}
+
+// GetContext returns the context bound to the connection
+// returns context.Background() if no context is bound
+func (conn *IRODSConnection) GetContext() context.Context {
+	conn.ctxMutex.Lock()
+	defer conn.ctxMutex.Unlock()
+
+	if conn.ctx == nil {
+		return context.Background()
+	}
+	return conn.ctx
+}
+
+// withContext binds the given context during the call of fn, and restores the previous one
+func (conn *IRODSConnection) withContext(ctx context.Context, fn func() error) error {
+	conn.ctxMutex.Lock()
+	prevCtx := conn.ctx
+	conn.ctxMutex.Unlock()
+
+	conn.SetContext(ctx)
+	defer conn.SetContext(prevCtx)
+
+	return fn()
+}
+
+// interruptIO aborts pending socket IO when the bound context is done
+func (conn *IRODSConnection) interruptIO(ctx context.Context) {
+	conn.ctxMutex.Lock()
+	defer conn.ctxMutex.Unlock()
+
+	if conn.ctx != ctx {
+		// context has been replaced
+		return
+	}
+
+	if conn.socket != nil {
+		// unblock pending read/write
+		_ = conn.socket.SetDeadline(time.Now())
+	}
+}
+
+// contextError returns an error if the bound context is done
+func (conn *IRODSConnection) contextError() error {
+	conn.ctxMutex.Lock()
+	defer conn.ctxMutex.Unlock()
+
+	if conn.ctx == nil {
+		return nil
+	}
+	return conn.ctx.Err()
+}
+
+// getDeadline returns a deadline for socket IO, ctxMutex must be held
+func (conn *IRODSConnection) getDeadline(timeout time.Duration) time.Time {
+	deadline := time.Now().Add(timeout)
+	if conn.ctx == nil {
+		return deadline
+	}
+
+	if conn.ctx.Err() != nil {
+		// already done, expire immediately
+		return time.Now()
+	}
+
+	if ctxDeadline, ok := conn.ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		return ctxDeadline
+	}
+	return deadline
+}
+
+func (conn *IRODSConnection) setSocket(socket net.Conn) {
+	conn.ctxMutex.Lock()
+	defer conn.ctxMutex.Unlock()
+
+	conn.socket = socket
+}
+
 // GetAccount returns iRODSAccount
 func (conn *IRODSConnection) GetAccount() *types.IRODSAccount {
 	return conn.account
@@ -109,7 +210,9 @@ func (conn *IRODSConnection) SetWriteTimeout(timeout time.Duration) error {
 		return errors.Errorf("connection is not locked")
 	}
 
-	err := conn.socket.SetWriteDeadline(time.Now().Add(timeout))
+	conn.ctxMutex.Lock()
+	err := conn.socket.SetWriteDeadline(conn.getDeadline(timeout))
+	conn.ctxMutex.Unlock()
 	if err != nil {
 		return errors.Wrapf(err, "failed to set write deadline")
 	}
@@ -126,7 +229,9 @@ func (conn *IRODSConnection) SetReadTimeout(timeout time.Duration) error {
 		return errors.Errorf("connection is not locked")
 	}
 
-	err := conn.socket.SetReadDeadline(time.Now().Add(timeout))
+	conn.ctxMutex.Lock()
+	err := conn.socket.SetReadDeadline(conn.getDeadline(timeout))
+	conn.ctxMutex.Unlock()
 	if err != nil {
 		return errors.Wrapf(err, "failed to set read deadline")
 	}
@@ -139,6 +244,12 @@ func (conn *IRODSConnection) SupportParallelUpload() bool {
 	return conn.serverVersion.HasHigherVersionThan(4, 2, 9)
 }
 
+// SupportGenQuery2 checks if the server supports GenQuery2
+// available from 4.3.2
+func (conn *IRODSConnection) SupportGenQuery2() bool {
+	return conn.serverVersion.HasHigherVersionThan(4, 3, 2)
+}
+
 func (conn *IRODSConnection) requireNewAuthFramework() bool {
 	return conn.serverVersion.HasHigherVersionThan(4, 3, 0)
 }
@@ -259,11 +370,15 @@ func (conn *IRODSConnection) connectTCP() error {
 
 	// must connect to the server within ConnectTimeout
 	var dialer net.Dialer
-	ctx, cancelFunc := context.WithTimeout(context.Background(), conn.config.ConnectTimeout)
+	ctx, cancelFunc := context.WithTimeout(conn.GetContext(), conn.config.ConnectTimeout)
 	defer cancelFunc()
 
 	socket, err := dialer.DialContext(ctx, "tcp", server)
 	if err != nil {
+		if ctxErr := conn.contextError(); ctxErr != nil {
+			return errors.Wrapf(ctxErr, "failed to connect to specified host %q and port %d", conn.account.Host, conn.account.Port)
+		}
+
 		newErr := errors.Join(err, types.NewConnectionError())
 		connErr := errors.Wrapf(newErr, "failed to connect to specified host %q and port %d", conn.account.Host, conn.account.Port)
 
@@ -279,7 +394,7 @@ func (conn *IRODSConnection) connectTCP() error {
 		conn.config.Metrics.IncreaseConnectionsOpened(1)
 	}
 
-	conn.socket = socket
+	conn.setSocket(socket)
 	return nil
 }
 
@@ -292,6 +407,15 @@ func (conn *IRODSConnection) Connect() error {
 	return conn.connectInternal()
 }
 
+// ConnectContext connects to iRODS, connecting is aborted when the given context is done
+func (conn *IRODSConnection) ConnectContext(ctx context.Context) error {
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	return conn.withContext(ctx, conn.connectInternal)
+}
+
 func (conn *IRODSConnection) connectInternal() error {
 	timeout := conn.GetOperationTimeout()
 
@@ -315,58 +439,7 @@ func (conn *IRODSConnection) connectInternal() error {
 
 	conn.serverVersion = irodsVersion
 
-	switch conn.account.AuthenticationScheme {
-	case types.AuthSchemeNative:
-		if conn.requireNewAuthFramework() {
-			err = conn.loginNativePlugin()
-		} else {
-			err = conn.loginNativeLegacy()
-		}
-	case types.AuthSchemePAM, types.AuthSchemePAMPassword:
-		if conn.requireNewAuthFramework() {
-			if len(conn.account.PAMToken) > 0 {
-				err = conn.loginPAMWithTokenPlugin()
-			} else {
-				err = conn.loginPAMWithPasswordPlugin()
-			}
-		} else {
-			if len(conn.account.PAMToken) > 0 {
-				err = conn.loginPAMWithTokenLegacy()
-			} else {
-				err = conn.loginPAMWithPasswordLegacy()
-				if err != nil {
-					return errors.Wrapf(err, "failed to login to irods using PAM authentication")
-				}
-
-				// reconnect when success
-				_ = conn.logout()
-				_ = conn.disconnectNow()
-
-				// connect TCP
-				err = conn.connectTCP()
-				if err != nil {
-					return err
-				}
-
-				_, err = conn.startup()
-				if err != nil {
-					connErr := errors.Wrapf(err, "failed to startup an iRODS connection to server %q and port %d", conn.account.Host, conn.account.Port)
-					_ = conn.logout()
-					_ = conn.disconnectNow()
-					if conn.config.Metrics != nil {
-						conn.config.Metrics.IncreaseCounterForConnectionFailures(1)
-					}
-					return connErr
-				}
-
-				err = conn.loginPAMWithTokenLegacy()
-			}
-		}
-	default:
-		newErr := types.NewConnectionConfigError(conn.account)
-		err = errors.Wrapf(newErr, "unknown Authentication Scheme %q", conn.account.AuthenticationScheme)
-	}
-
+	err = conn.login()
 	if err != nil {
 		connErr := errors.Wrapf(err, "failed to login to irods")
 		_ = conn.logout()
@@ -534,14 +607,14 @@ func (conn *IRODSConnection) sslStartup() error {
 	// Create a side connection using the existing socket
 	sslSocket := tls.Client(conn.socket, tlsConfig)
 
-	err = sslSocket.Handshake()
+	err = sslSocket.HandshakeContext(conn.GetContext())
 	if err != nil {
 		newErr := errors.Join(err, types.NewConnectionError())
 		return errors.Wrapf(newErr, "SSL Handshake error")
 	}
 
 	// from now on use ssl socket
-	conn.socket = sslSocket
+	conn.setSocket(sslSocket)
 	conn.isSSLSocket = true
 
 	// Generate a key (shared secret)
@@ -580,7 +653,8 @@ func (conn *IRODSConnection) loginNativeLegacy() error {
 	return AuthenticateNative(conn, conn.account.Password)
 }
 
-func (conn *IRODSConnection) loginNativePlugin() error {
+// newNativeAuthPluginForConnection creates a native auth plugin, used for native auth scheme
+func newNativeAuthPluginForConnection(conn *IRODSConnection) (IRODSAuthPlugin, *IRODSAuthContext, error) {
 	logger := log.WithFields(log.Fields{})
 	logger.Debug("Logging in using native authentication method with plugin")
 
@@ -589,7 +663,7 @@ func (conn *IRODSConnection) loginNativePlugin() error {
 	authContext.Set("password", conn.account.Password)
 	authContext.Set(AUTH_TTL_KEY, "0")
 
-	return AuthenticateClient(conn, plugin, authContext)
+	return plugin, authContext, nil
 }
 
 func (conn *IRODSConnection) loginPAMWithPasswordLegacy() error {
@@ -599,15 +673,37 @@ func (conn *IRODSConnection) loginPAMWithPasswordLegacy() error {
 	return AuthenticatePAMWithPassword(conn, conn.account.Password)
 }
 
-func (conn *IRODSConnection) loginPAMWithPasswordPlugin() error {
+// newPAMAuthPluginForConnection creates an auth plugin for pam auth scheme
+// pam token is used with native auth plugin if it is available
+func newPAMAuthPluginForConnection(conn *IRODSConnection) (IRODSAuthPlugin, *IRODSAuthContext, error) {
 	logger := log.WithFields(log.Fields{})
-
 	logger.Debug("Logging in using pam authentication method with plugin")
 
+	if len(conn.account.PAMToken) > 0 {
+		plugin := NewNativeAuthPlugin()
+		authContext := NewIRODSAuthContext()
+		authContext.Set("password", conn.account.PAMToken)
+		authContext.Set(AUTH_TTL_KEY, "0")
+
+		return plugin, authContext, nil
+	}
+
 	plugin := NewPAMPasswordAuthPlugin(conn.isSSLSocket)
 	authContext := NewIRODSAuthContext()
 
-	return AuthenticateClient(conn, plugin, authContext)
+	return plugin, authContext, nil
+}
+
+// newPAMInteractiveAuthPluginForConnection creates an auth plugin for pam_interactive auth scheme
+// the plugin reuses PAM token issued before, and prompts users if the token is rejected
+func newPAMInteractiveAuthPluginForConnection(conn *IRODSConnection) (IRODSAuthPlugin, *IRODSAuthContext, error) {
+	logger := log.WithFields(log.Fields{})
+	logger.Debug("Logging in using pam_interactive authentication method with plugin")
+
+	plugin := NewPAMInteractiveAuthPluginWithPAMInteractiveHandler(conn.isSSLSocket, conn.account.PAMInteractiveHandler)
+	authContext := NewIRODSAuthContext()
+
+	return plugin, authContext, nil
 }
 
 func (conn *IRODSConnection) loginPAMWithTokenLegacy() error {
@@ -617,16 +713,84 @@ func (conn *IRODSConnection) loginPAMWithTokenLegacy() error {
 	return AuthenticatePAMWithToken(conn, conn.account.PAMToken)
 }
 
-func (conn *IRODSConnection) loginPAMWithTokenPlugin() error {
+// loginPAMLegacy logs in using legacy pam authentication method
+// it reconnects to the server to login with pam token obtained
+func (conn *IRODSConnection) loginPAMLegacy() error {
+	if len(conn.account.PAMToken) > 0 {
+		return conn.loginPAMWithTokenLegacy()
+	}
+
+	err := conn.loginPAMWithPasswordLegacy()
+	if err != nil {
+		return errors.Wrapf(err, "failed to login to irods using PAM authentication")
+	}
+
+	// reconnect when success
+	_ = conn.logout()
+	_ = conn.disconnectNow()
+
+	// connect TCP
+	err = conn.connectTCP()
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.startup()
+	if err != nil {
+		// the caller disconnects
+		if conn.config.Metrics != nil {
+			conn.config.Metrics.IncreaseCounterForConnectionFailures(1)
+		}
+		return errors.Wrapf(err, "failed to startup an iRODS connection to server %q and port %d", conn.account.Host, conn.account.Port)
+	}
+
+	return conn.loginPAMWithTokenLegacy()
+}
+
+// loginKRBLegacy logs in using legacy kerberos authentication method
+func (conn *IRODSConnection) loginKRBLegacy() error {
 	logger := log.WithFields(log.Fields{})
-	logger.Debug("Logging in using pam authentication method with plugin")
+	logger.Debug("Logging in using legacy kerberos authentication method")
 
-	plugin := NewNativeAuthPlugin()
+	kerberosClient, err := newKerberosClientForConnection(conn)
+	if err != nil {
+		return err
+	}
+	defer kerberosClient.Release()
+
+	return AuthenticateKRB(conn, kerberosClient)
+}
+
+// newOpenIDAuthPluginForConnection creates an auth plugin for openid auth scheme
+// a fresh token is obtained from the token source of the account for every login
+func newOpenIDAuthPluginForConnection(conn *IRODSConnection) (IRODSAuthPlugin, *IRODSAuthContext, error) {
+	logger := log.WithFields(log.Fields{})
+	logger.Debug("Logging in using openid authentication method with plugin")
+
+	token, err := conn.account.GetAuthToken()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plugin := NewOpenIDAuthPlugin()
 	authContext := NewIRODSAuthContext()
-	authContext.Set("password", conn.account.PAMToken)
-	authContext.Set(AUTH_TTL_KEY, "0")
+	authContext.Set(OPENID_AUTH_ACCESS_TOKEN_KEY, token.AccessToken)
+
+	return plugin, authContext, nil
+}
+
+// loginGSILegacy logs in using legacy GSI authentication method
+// GSI is not available with the auth plugin framework
+func (conn *IRODSConnection) loginGSILegacy() error {
+	logger := log.WithFields(log.Fields{})
+	logger.Debug("Logging in using legacy GSI authentication method")
 
-	return AuthenticateClient(conn, plugin, authContext)
+	gsiClient, err := newGSIClientForConnection(conn)
+	if err != nil {
+		return err
+	}
+
+	return AuthenticateGSI(conn, gsiClient)
 }
 
 // logout sends logout
@@ -651,7 +815,7 @@ func (conn *IRODSConnection) disconnectNow() error {
 	var err error
 	if conn.socket != nil {
 		err = conn.socket.Close()
-		conn.socket = nil
+		conn.setSocket(nil)
 	}
 
 	if conn.config.Metrics != nil {
@@ -707,7 +871,7 @@ func (conn *IRODSConnection) Reconnect() error {
 	conn.connected = false
 	conn.failed = false
 	conn.isSSLSocket = false
-	conn.socket = nil
+	conn.setSocket(nil)
 
 	conn.serverVersion = nil
 	conn.sslSharedSecret = nil
@@ -735,6 +899,11 @@ func (conn *IRODSConnection) SendWithTrackerCallBack(buffer []byte, size int, ti
 		return errors.Errorf("connection must be locked before use")
 	}
 
+	if ctxErr := conn.contextError(); ctxErr != nil {
+		conn.socketFail()
+		return errors.Wrapf(ctxErr, "failed to send data")
+	}
+
 	if timeout != nil {
 		err := conn.SetWriteTimeout(*timeout)
 		if err != nil {
@@ -745,6 +914,10 @@ func (conn *IRODSConnection) SendWithTrackerCallBack(buffer []byte, size int, ti
 	err := util.WriteBytesWithTrackerCallBack(conn.socket, buffer, size, callback)
 	if err != nil {
 		conn.socketFail()
+
+		if ctxErr := conn.contextError(); ctxErr != nil {
+			return errors.Wrapf(ctxErr, "failed to send data")
+		}
 		return errors.Wrapf(err, "failed to send data")
 	}
 
@@ -769,6 +942,11 @@ func (conn *IRODSConnection) SendFromReader(src io.Reader, size int64, timeout *
 		return 0, errors.Errorf("connection must be locked before use")
 	}
 
+	if ctxErr := conn.contextError(); ctxErr != nil {
+		conn.socketFail()
+		return 0, errors.Wrapf(ctxErr, "failed to send data")
+	}
+
 	if timeout != nil {
 		err := conn.SetWriteTimeout(*timeout)
 		if err != nil {
@@ -784,6 +962,11 @@ func (conn *IRODSConnection) SendFromReader(src io.Reader, size int64, timeout *
 	}
 
 	if err != nil {
+		if ctxErr := conn.contextError(); ctxErr != nil {
+			conn.socketFail()
+			return copyLen, errors.Wrapf(ctxErr, "failed to send data (req: %d, sent: %d)", size, copyLen)
+		}
+
 		if err == io.EOF {
 			return copyLen, io.EOF
 		}
@@ -812,6 +995,11 @@ func (conn *IRODSConnection) RecvWithTrackerCallBack(buffer []byte, size int, ti
 		return 0, errors.Errorf("connection must be locked before use")
 	}
 
+	if ctxErr := conn.contextError(); ctxErr != nil {
+		conn.socketFail()
+		return 0, errors.Wrapf(ctxErr, "failed to receive data")
+	}
+
 	if timeout != nil {
 		err := conn.SetReadTimeout(*timeout)
 		if err != nil {
@@ -827,6 +1015,11 @@ func (conn *IRODSConnection) RecvWithTrackerCallBack(buffer []byte, size int, ti
 	}
 
 	if err != nil {
+		if ctxErr := conn.contextError(); ctxErr != nil {
+			conn.socketFail()
+			return readLen, errors.Wrapf(ctxErr, "failed to receive data")
+		}
+
 		if err == io.EOF {
 			conn.lastSuccessfulAccess = time.Now()
 			_ = conn.disconnectNow()
@@ -852,6 +1045,11 @@ func (conn *IRODSConnection) RecvToWriter(writer io.Writer, size int64, timeout
 		return 0, errors.Errorf("connection must be locked before use")
 	}
 
+	if ctxErr := conn.contextError(); ctxErr != nil {
+		conn.socketFail()
+		return 0, errors.Wrapf(ctxErr, "failed to receive data")
+	}
+
 	if timeout != nil {
 		err := conn.SetReadTimeout(*timeout)
 		if err != nil {
@@ -867,6 +1065,11 @@ func (conn *IRODSConnection) RecvToWriter(writer io.Writer, size int64, timeout
 	}
 
 	if err != nil {
+		if ctxErr := conn.contextError(); ctxErr != nil {
+			conn.socketFail()
+			return copyLen, errors.Wrapf(ctxErr, "failed to receive data")
+		}
+
 		if err == io.EOF {
 			conn.lastSuccessfulAccess = time.Now()
 			_ = conn.disconnectNow()
@@ -887,6 +1090,13 @@ func (conn *IRODSConnection) SendMessage(msg *message.IRODSMessage, timeout time
 	return conn.SendMessageWithTrackerCallBack(msg, timeout, nil)
 }
 
+// SendMessageContext makes the message into bytes, sending is aborted when the given context is done
+func (conn *IRODSConnection) SendMessageContext(ctx context.Context, msg *message.IRODSMessage, timeout time.Duration) error {
+	return conn.withContext(ctx, func() error {
+		return conn.SendMessageWithTrackerCallBack(msg, timeout, nil)
+	})
+}
+
 // SendMessageWithTrackerCallBack makes the message into bytes
 func (conn *IRODSConnection) SendMessageWithTrackerCallBack(msg *message.IRODSMessage, timeout time.Duration, callback common.TransferTrackerCallback) error {
 	if !conn.locked {
@@ -1029,6 +1239,19 @@ func (conn *IRODSConnection) ReadMessage(bsBuffer []byte, timeout time.Duration)
 	return conn.ReadMessageWithTrackerCallBack(bsBuffer, timeout, nil)
 }
 
+// ReadMessageContext reads data from the given socket and returns IRODSMessage
+// reading is aborted when the given context is done
+func (conn *IRODSConnection) ReadMessageContext(ctx context.Context, bsBuffer []byte, timeout time.Duration) (*message.IRODSMessage, error) {
+	var msg *message.IRODSMessage
+	err := conn.withContext(ctx, func() error {
+		var readErr error
+		msg, readErr = conn.ReadMessageWithTrackerCallBack(bsBuffer, timeout, nil)
+		return readErr
+	})
+	return msg, err
+}
+
+// ReadMessageWithTrackerCallBack reads data from the given socket and returns IRODSMessage
 func (conn *IRODSConnection) ReadMessageWithTrackerCallBack(bsBuffer []byte, timeout time.Duration, callback common.TransferTrackerCallback) (*message.IRODSMessage, error) {
 	if !conn.locked {
 		return nil, errors.Errorf("connection must be locked before use")
@@ -1172,7 +1395,7 @@ func (conn *IRODSConnection) poorMansEndTransaction(dummyCol string, commit bool
 // RawBind binds an IRODSConnection to a raw net.Conn socket - to be used for e.g. a proxy server setup
 func (conn *IRODSConnection) RawBind(socket net.Conn) {
 	conn.connected = true
-	conn.socket = socket
+	conn.setSocket(socket)
 }
 
 // GetMetrics returns metrics
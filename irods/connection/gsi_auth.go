@@ -0,0 +1,77 @@
+package connection
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/auth"
+	"github.com/cyverse/go-irodsclient/irods/message"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// gsiTokenTransport exchanges GSI tokens over the connection
+type gsiTokenTransport struct {
+	conn *IRODSConnection
+}
+
+// SendToken sends a GSI token
+func (transport *gsiTokenTransport) SendToken(token []byte) error {
+	return sendGSSToken(transport.conn, token)
+}
+
+// RecvToken receives a GSI token
+func (transport *gsiTokenTransport) RecvToken() ([]byte, error) {
+	return recvGSSToken(transport.conn)
+}
+
+// AuthenticateGSI authenticates with GSI using GSI_AUTH_REQUEST_AN
+// the server returns its DN, then GSI security context is established over the connection
+func AuthenticateGSI(conn *IRODSConnection, gsiClient *auth.GSIClient) error {
+	logger := log.WithFields(log.Fields{})
+
+	timeout := conn.GetOperationTimeout()
+
+	gsiAuthRequest := message.NewIRODSMessageGSIAuthRequest()
+	gsiAuthResponse := message.IRODSMessageGSIAuthResponse{}
+	err := conn.RequestAndCheck(gsiAuthRequest, &gsiAuthResponse, nil, timeout)
+	if err != nil {
+		newErr := errors.Join(err, types.NewAuthError(conn.account))
+		return errors.Wrapf(newErr, "failed to receive GSI server DN")
+	}
+
+	serverDN := getGSIServerDN(conn, gsiAuthResponse.ServerDN)
+	logger.Debugf("GSI server DN %q, client DN %q", serverDN, gsiClient.GetClientDN())
+
+	transport := &gsiTokenTransport{
+		conn: conn,
+	}
+
+	err = gsiClient.EstablishContext(transport, serverDN)
+	if err != nil {
+		newErr := errors.Join(err, types.NewAuthError(conn.account))
+		return errors.Wrapf(newErr, "failed to establish GSI security context")
+	}
+
+	conn.loggedIn = true
+
+	return nil
+}
+
+// getGSIServerDN returns DN of the server, the one configured overrides the one the server returns
+func getGSIServerDN(conn *IRODSConnection, serverDN string) string {
+	if conn.account.GSIConfiguration != nil && len(conn.account.GSIConfiguration.ServerDN) > 0 {
+		return conn.account.GSIConfiguration.ServerDN
+	}
+
+	return serverDN
+}
+
+// newGSIClientForConnection creates a GSI client with the GSI configuration of the account
+func newGSIClientForConnection(conn *IRODSConnection) (*auth.GSIClient, error) {
+	gsiClient, err := auth.NewGSIClient(conn.account.GSIConfiguration)
+	if err != nil {
+		newErr := errors.Join(err, types.NewAuthError(conn.account))
+		return nil, errors.Wrapf(newErr, "failed to create GSI client")
+	}
+
+	return gsiClient, nil
+}
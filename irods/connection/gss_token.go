@@ -0,0 +1,48 @@
+package connection
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/auth"
+)
+
+// gssTokenReader reads GSS-API tokens from the connection
+type gssTokenReader struct {
+	conn    *IRODSConnection
+	timeout *time.Duration
+}
+
+// Read reads data from the connection
+func (reader *gssTokenReader) Read(buffer []byte) (int, error) {
+	return reader.conn.Recv(buffer, len(buffer), reader.timeout)
+}
+
+// sendGSSToken sends a GSS-API token over the connection, the token is prefixed with its length in 4 bytes big endian
+func sendGSSToken(conn *IRODSConnection, token []byte) error {
+	timeout := conn.GetOperationTimeout()
+
+	buffer := auth.MarshalGSSToken(token)
+
+	err := conn.Send(buffer, len(buffer), &timeout.RequestTimeout)
+	if err != nil {
+		return errors.Wrapf(err, "failed to send GSS-API token")
+	}
+
+	return nil
+}
+
+// recvGSSToken receives a GSS-API token over the connection
+func recvGSSToken(conn *IRODSConnection) ([]byte, error) {
+	timeout := conn.GetOperationTimeout()
+
+	token, err := auth.ReadGSSToken(&gssTokenReader{
+		conn:    conn,
+		timeout: &timeout.ResponseTimeout,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to receive GSS-API token")
+	}
+
+	return token, nil
+}
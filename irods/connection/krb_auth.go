@@ -0,0 +1,77 @@
+package connection
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/auth"
+	"github.com/cyverse/go-irodsclient/irods/message"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// AuthenticateKRB authenticates with kerberos using KRB_AUTH_REQUEST_AN
+// the server returns its service principal, then GSS-API tokens are exchanged over the connection
+func AuthenticateKRB(conn *IRODSConnection, kerberosClient *auth.KerberosClient) error {
+	logger := log.WithFields(log.Fields{})
+
+	timeout := conn.GetOperationTimeout()
+
+	krbAuthRequest := message.NewIRODSMessageKRBAuthRequest()
+	krbAuthResponse := message.IRODSMessageKRBAuthResponse{}
+	err := conn.RequestAndCheck(krbAuthRequest, &krbAuthResponse, nil, timeout)
+	if err != nil {
+		newErr := errors.Join(err, types.NewAuthError(conn.account))
+		return errors.Wrapf(newErr, "failed to receive kerberos service principal")
+	}
+
+	servicePrincipal := getKRBServicePrincipal(conn, krbAuthResponse.ServerName)
+	logger.Debugf("kerberos service principal %q, client principal %q", servicePrincipal, kerberosClient.GetClientPrincipal())
+
+	// the server accepts the context right after replying
+	clientToken, err := kerberosClient.InitSecContext(servicePrincipal, true)
+	if err != nil {
+		newErr := errors.Join(err, types.NewAuthError(conn.account))
+		return errors.Wrapf(newErr, "failed to init kerberos security context")
+	}
+
+	err = sendGSSToken(conn, clientToken)
+	if err != nil {
+		newErr := errors.Join(err, types.NewAuthError(conn.account))
+		return errors.Wrapf(newErr, "failed to send kerberos token")
+	}
+
+	serverToken, err := recvGSSToken(conn)
+	if err != nil {
+		newErr := errors.Join(err, types.NewAuthError(conn.account))
+		return errors.Wrapf(newErr, "failed to receive kerberos token")
+	}
+
+	err = kerberosClient.VerifyServerToken(serverToken)
+	if err != nil {
+		newErr := errors.Join(err, types.NewAuthError(conn.account))
+		return errors.Wrapf(newErr, "failed to verify kerberos token from server")
+	}
+
+	conn.loggedIn = true
+
+	return nil
+}
+
+// getKRBServicePrincipal returns service principal of the server, the one configured overrides the one the server returns
+func getKRBServicePrincipal(conn *IRODSConnection, serverName string) string {
+	if conn.account.KerberosConfiguration != nil && len(conn.account.KerberosConfiguration.ServicePrincipal) > 0 {
+		return conn.account.KerberosConfiguration.ServicePrincipal
+	}
+
+	return serverName
+}
+
+// newKerberosClientForConnection creates a kerberos client with the kerberos configuration of the account
+func newKerberosClientForConnection(conn *IRODSConnection) (*auth.KerberosClient, error) {
+	kerberosClient, err := auth.NewKerberosClient(conn.account.KerberosConfiguration, conn.account.ProxyUser)
+	if err != nil {
+		newErr := errors.Join(err, types.NewAuthError(conn.account))
+		return nil, errors.Wrapf(newErr, "failed to create kerberos client")
+	}
+
+	return kerberosClient, nil
+}
@@ -0,0 +1,69 @@
+package connection
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+const (
+	OPENID_AUTH_ACCESS_TOKEN_KEY string = "access_token"
+)
+
+type OpenIDAuthPlugin struct {
+	BaseIRODSAuthPlugin
+}
+
+func NewOpenIDAuthPlugin() *OpenIDAuthPlugin {
+	plugin := &OpenIDAuthPlugin{}
+
+	plugin.initialize()
+	return plugin
+}
+
+func (plugin *OpenIDAuthPlugin) initialize() {
+	plugin.AddOperation(AUTH_CLIENT_START, plugin.AuthClientStart)
+	plugin.AddOperation(AUTH_CLIENT_AUTH_REQUEST, plugin.clientRequest)
+}
+
+func (plugin *OpenIDAuthPlugin) GetName() string {
+	return "openid"
+}
+
+func (plugin *OpenIDAuthPlugin) AuthClientStart(conn *IRODSConnection, requestContext *IRODSAuthContext) (*IRODSAuthContext, error) {
+	responseContext := requestContext.GetCopy()
+
+	responseContext.Set(AUTH_NEXT_OPERATION, AUTH_CLIENT_AUTH_REQUEST)
+
+	responseContext.Set("user_name", conn.account.ProxyUser)
+	responseContext.Set("zone_name", conn.account.ProxyZone)
+
+	return responseContext, nil
+}
+
+func (plugin *OpenIDAuthPlugin) clientRequest(conn *IRODSConnection, requestContext *IRODSAuthContext) (*IRODSAuthContext, error) {
+	// bearer token must not be sent in plain text
+	if !conn.isSSLSocket {
+		return nil, errors.Wrapf(types.NewAuthError(conn.account), "OpenID authentication requires secure connection")
+	}
+
+	if !requestContext.Has(OPENID_AUTH_ACCESS_TOKEN_KEY) {
+		return nil, errors.Wrapf(types.NewAuthError(conn.account), "missing access token in OpenID auth request")
+	}
+
+	reqContext := requestContext.GetCopy()
+
+	reqContext.Set(AUTH_NEXT_OPERATION, AUTH_AGENT_AUTH_REQUEST)
+
+	responseContext, err := plugin.Request(conn, reqContext)
+	if err != nil {
+		return nil, err
+	}
+
+	// don't keep the token
+	responseContext.Remove(OPENID_AUTH_ACCESS_TOKEN_KEY)
+	responseContext.Set(AUTH_NEXT_OPERATION, AUTH_FLOW_COMPLETE)
+
+	conn.loggedIn = true
+
+	return responseContext, nil
+}
@@ -31,9 +31,10 @@ type PAMInteractiveInputHandler func() (string, error)
 
 type PAMInteractiveAuthPlugin struct {
 	BaseIRODSAuthPlugin
-	requireSecureConnection  bool
-	getInputHandler          PAMInteractiveInputHandler
-	getSensitiveInputHandler PAMInteractiveInputHandler
+	requireSecureConnection bool
+	promptHandler           types.PAMInteractivePromptHandler
+	messageHandler          types.PAMInteractiveMessageHandler
+	pamInteractiveHandler   *types.PAMInteractiveHandler // can be nil, keeps PAM token issued
 }
 
 func NewPAMInteractiveAuthPlugin(requireSecureConnection bool) *PAMInteractiveAuthPlugin {
@@ -41,8 +42,8 @@ func NewPAMInteractiveAuthPlugin(requireSecureConnection bool) *PAMInteractiveAu
 		requireSecureConnection: requireSecureConnection,
 	}
 
-	plugin.getInputHandler = plugin.getInputFromClientStdin
-	plugin.getSensitiveInputHandler = plugin.getPasswordFromClientStdin
+	plugin.promptHandler = plugin.getInputFromClientStdin
+	plugin.messageHandler = plugin.printMessageToClientStdout
 
 	plugin.initialize()
 	return plugin
@@ -50,15 +51,40 @@ func NewPAMInteractiveAuthPlugin(requireSecureConnection bool) *PAMInteractiveAu
 
 func NewPAMInteractiveAuthPluginWithHandlers(requireSecureConnection bool, getInputHandler PAMInteractiveInputHandler, getSensitiveInputHandler PAMInteractiveInputHandler) *PAMInteractiveAuthPlugin {
 	plugin := &PAMInteractiveAuthPlugin{
-		requireSecureConnection:  requireSecureConnection,
-		getInputHandler:          getInputHandler,
-		getSensitiveInputHandler: getSensitiveInputHandler,
+		requireSecureConnection: requireSecureConnection,
+	}
+
+	plugin.promptHandler = func(prompt string, sensitive bool) (string, error) {
+		if sensitive {
+			return getSensitiveInputHandler()
+		}
+		return getInputHandler()
 	}
+	plugin.messageHandler = plugin.printMessageToClientStdout
 
 	plugin.initialize()
 	return plugin
 }
 
+// NewPAMInteractiveAuthPluginWithPAMInteractiveHandler creates a PAMInteractiveAuthPlugin with prompt handlers of the handler given
+// stdin and stdout are used for handlers not set, and the PAM token issued is stored in the handler
+func NewPAMInteractiveAuthPluginWithPAMInteractiveHandler(requireSecureConnection bool, handler *types.PAMInteractiveHandler) *PAMInteractiveAuthPlugin {
+	plugin := NewPAMInteractiveAuthPlugin(requireSecureConnection)
+	plugin.pamInteractiveHandler = handler
+
+	if handler != nil {
+		if handler.Prompt != nil {
+			plugin.promptHandler = handler.Prompt
+		}
+
+		if handler.Message != nil {
+			plugin.messageHandler = handler.Message
+		}
+	}
+
+	return plugin
+}
+
 func (plugin *PAMInteractiveAuthPlugin) initialize() {
 	plugin.AddOperation(AUTH_CLIENT_START, plugin.AuthClientStart)
 	plugin.AddOperation(AUTH_CLIENT_AUTH_REQUEST, plugin.clientRequest)
@@ -81,8 +107,30 @@ func (plugin *PAMInteractiveAuthPlugin) GetName() string {
 }
 
 func (plugin *PAMInteractiveAuthPlugin) AuthClientStart(conn *IRODSConnection, requestContext *IRODSAuthContext) (*IRODSAuthContext, error) {
+	logger := log.WithFields(log.Fields{})
+
 	responseContext := requestContext.GetCopy()
 
+	// reuse PAM token issued by other connections
+	pamToken := conn.account.GetPAMToken()
+	if len(pamToken) > 0 {
+		err := plugin.authenticateWithPAMToken(conn, pamToken)
+		if err == nil {
+			responseContext.Set(AUTH_NEXT_OPERATION, AUTH_FLOW_COMPLETE)
+			return responseContext, nil
+		}
+
+		if !types.IsAuthError(err) {
+			return nil, err
+		}
+
+		// expired or revoked, prompt users again
+		logger.WithError(err).Debug("PAM token is rejected, authenticating interactively")
+		if conn.account.PAMInteractiveHandler != nil {
+			conn.account.PAMInteractiveHandler.RejectPAMToken(pamToken)
+		}
+	}
+
 	responseContext.Set(AUTH_NEXT_OPERATION, AUTH_CLIENT_AUTH_REQUEST)
 
 	responseContext.Set("pdirty", false)
@@ -140,12 +188,9 @@ func (plugin *PAMInteractiveAuthPlugin) clientResponse(conn *IRODSConnection, re
 
 func (plugin *PAMInteractiveAuthPlugin) stepClientNext(conn *IRODSConnection, requestContext *IRODSAuthContext) (*IRODSAuthContext, error) {
 	reqContext := requestContext.GetCopy()
-	if msgMap, ok := reqContext.GetMap("msg"); ok && msgMap != nil {
-		if promptVal, ok2 := msgMap["prompt"]; ok2 {
-			if prompt, ok3 := promptVal.(string); ok3 {
-				fmt.Printf("%s", prompt)
-			}
-		}
+	prompt := plugin.getPrompt(reqContext)
+	if len(prompt) > 0 {
+		plugin.messageHandler(prompt)
 	}
 
 	err := plugin.patchState(reqContext)
@@ -181,7 +226,7 @@ func (plugin *PAMInteractiveAuthPlugin) stepWaiting(conn *IRODSConnection, reque
 		return nil, err
 	}
 
-	input, err := plugin.getInputHandler()
+	input, err := plugin.promptHandler(plugin.getPrompt(reqContext), false)
 	if err != nil {
 		return nil, err
 	}
@@ -224,7 +269,7 @@ func (plugin *PAMInteractiveAuthPlugin) stepWaitingPw(conn *IRODSConnection, req
 		return nil, err
 	}
 
-	input, err := plugin.getSensitiveInputHandler()
+	input, err := plugin.promptHandler(plugin.getPrompt(reqContext), true)
 	if err != nil {
 		return nil, err
 	}
@@ -246,7 +291,7 @@ func (plugin *PAMInteractiveAuthPlugin) stepWaitingPw(conn *IRODSConnection, req
 }
 
 func (plugin *PAMInteractiveAuthPlugin) stepError(conn *IRODSConnection, requestContext *IRODSAuthContext) (*IRODSAuthContext, error) {
-	fmt.Printf("error\n")
+	plugin.messageHandler("error")
 	responseContext := requestContext.GetCopy()
 	responseContext.Set(AUTH_NEXT_OPERATION, AUTH_FLOW_COMPLETE)
 	conn.loggedIn = false
@@ -254,7 +299,7 @@ func (plugin *PAMInteractiveAuthPlugin) stepError(conn *IRODSConnection, request
 }
 
 func (plugin *PAMInteractiveAuthPlugin) stepTimeout(conn *IRODSConnection, requestContext *IRODSAuthContext) (*IRODSAuthContext, error) {
-	fmt.Printf("timeout\n")
+	plugin.messageHandler("timeout")
 	responseContext := requestContext.GetCopy()
 	responseContext.Set(AUTH_NEXT_OPERATION, AUTH_FLOW_COMPLETE)
 	conn.loggedIn = false
@@ -302,6 +347,11 @@ func (plugin *PAMInteractiveAuthPlugin) performNativeAuth(conn *IRODSConnection,
 		return nil, errors.Wrapf(err, "failed to perform native auth as part of PAM password auth")
 	}
 
+	// store PAM token in the handler for future use, the handler is shared by connections and guarded by its lock
+	if plugin.pamInteractiveHandler != nil {
+		plugin.pamInteractiveHandler.SetPAMToken(requestResult)
+	}
+
 	responseContext.Set(AUTH_NEXT_OPERATION, AUTH_FLOW_COMPLETE)
 
 	// The native auth plugin sets this on success, so this isn't necessary.
@@ -312,7 +362,28 @@ func (plugin *PAMInteractiveAuthPlugin) performNativeAuth(conn *IRODSConnection,
 	return responseContext, nil
 }
 
-func (plugin *PAMInteractiveAuthPlugin) getInputFromClientStdin() (string, error) {
+// authenticateWithPAMToken authenticates with the PAM token using native auth
+func (plugin *PAMInteractiveAuthPlugin) authenticateWithPAMToken(conn *IRODSConnection, pamToken string) error {
+	input := NewIRODSAuthContext()
+	input.Set("password", pamToken)
+	input.Set(AUTH_TTL_KEY, "0")
+
+	nativeAuthPlugin := NewNativeAuthPlugin()
+	err := AuthenticateClient(conn, nativeAuthPlugin, input)
+	if err != nil {
+		return errors.Wrapf(err, "failed to perform native auth with PAM token")
+	}
+
+	return nil
+}
+
+func (plugin *PAMInteractiveAuthPlugin) getInputFromClientStdin(prompt string, sensitive bool) (string, error) {
+	fmt.Printf("%s", prompt)
+
+	if sensitive {
+		return plugin.getPasswordFromClientStdin()
+	}
+
 	userInput := ""
 	_, err := fmt.Scanln(&userInput)
 	if err != nil {
@@ -325,6 +396,8 @@ func (plugin *PAMInteractiveAuthPlugin) getInputFromClientStdin() (string, error
 
 func (plugin *PAMInteractiveAuthPlugin) getPasswordFromClientStdin() (string, error) {
 	bytePassword, err := term.ReadPassword(int(syscall.Stdin))
+	// password input is not echoed, move to the next line
+	fmt.Println()
 	if err != nil {
 		newErr := errors.Join(err, types.NewAuthError(nil))
 		return "", errors.Wrapf(newErr, "failed to get user password input")
@@ -333,6 +406,22 @@ func (plugin *PAMInteractiveAuthPlugin) getPasswordFromClientStdin() (string, er
 	return string(bytePassword), nil
 }
 
+func (plugin *PAMInteractiveAuthPlugin) printMessageToClientStdout(message string) {
+	fmt.Println(message)
+}
+
+func (plugin *PAMInteractiveAuthPlugin) getPrompt(requestContext *IRODSAuthContext) string {
+	if msg, ok := requestContext.GetMap("msg"); ok && msg != nil {
+		if promptObj, ok2 := msg["prompt"]; ok2 {
+			if prompt, ok3 := promptObj.(string); ok3 {
+				return prompt
+			}
+		}
+	}
+
+	return ""
+}
+
 func (plugin *PAMInteractiveAuthPlugin) patchState(requestContext *IRODSAuthContext) error {
 	if !requestContext.Has("patch") {
 		return nil
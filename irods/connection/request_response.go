@@ -1,6 +1,7 @@
 package connection
 
 import (
+	"context"
 	"io"
 	"time"
 
@@ -58,6 +59,15 @@ func (conn *IRODSConnection) Request(request Request, response Response, bsBuffe
 	return conn.RequestWithTrackerCallBack(request, response, bsBuffer, timeout, nil, nil)
 }
 
+// RequestContext sends a request and expects a response.
+// the request is aborted when the given context is done.
+// bsBuffer is optional
+func (conn *IRODSConnection) RequestContext(ctx context.Context, request Request, response Response, bsBuffer []byte, timeout *RequestResponseTimeout) error {
+	return conn.withContext(ctx, func() error {
+		return conn.RequestWithTrackerCallBack(request, response, bsBuffer, timeout, nil, nil)
+	})
+}
+
 // RequestWithTrackerCallBack sends a request and expects a response.
 // bsBuffer is optional
 func (conn *IRODSConnection) RequestWithTrackerCallBack(request Request, response Response, bsBuffer []byte, timeout *RequestResponseTimeout, reqCallback common.TransferTrackerCallback, resCallback common.TransferTrackerCallback) error {
@@ -269,6 +279,14 @@ func (conn *IRODSConnection) RequestAndCheck(request Request, response CheckErro
 	return conn.RequestAndCheckWithTrackerCallBack(request, response, bsBuffer, timeout, nil, nil)
 }
 
+// RequestAndCheckContext sends a request and expects a CheckErrorResponse, on which the error is already checked.
+// the request is aborted when the given context is done.
+func (conn *IRODSConnection) RequestAndCheckContext(ctx context.Context, request Request, response CheckErrorResponse, bsBuffer []byte, timeout *RequestResponseTimeout) error {
+	return conn.withContext(ctx, func() error {
+		return conn.RequestAndCheckWithTrackerCallBack(request, response, bsBuffer, timeout, nil, nil)
+	})
+}
+
 // RequestAndCheckWithCallBack sends a request and expects a CheckErrorResponse, on which the error is already checked.
 func (conn *IRODSConnection) RequestAndCheckWithTrackerCallBack(request Request, response CheckErrorResponse, bsBuffer []byte, timeout *RequestResponseTimeout, reqCallback common.TransferTrackerCallback, resCallback common.TransferTrackerCallback) error {
 	if err := conn.RequestWithTrackerCallBack(request, response, bsBuffer, timeout, reqCallback, resCallback); err != nil {
@@ -30,8 +30,98 @@ func GetDataObjectChecksum(conn *connection.IRODSConnection, path string, resour
 	}
 
 	request := message.NewIRODSMessageChecksumRequest(path, resource)
+	response, err := requestDataObjectChecksum(conn, request, path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.Checksum) == 0 {
+		return nil, errors.Errorf("checksum not present in response message")
+	}
+
+	checksum, err := types.CreateIRODSChecksum(response.Checksum)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create iRODS checksum")
+	}
+
+	return checksum, nil
+}
+
+// ComputeDataObjectChecksum computes a data object checksum for the path and registers it to the catalog, like ichksum
+// if force is set, checksum is recomputed even if it already exists
+// if allReplicas is set, checksums of all replicas are computed and resource is ignored
+// if adminFlag is set, data objects owned by others can be processed
+func ComputeDataObjectChecksum(conn *connection.IRODSConnection, path string, resource string, force bool, allReplicas bool, adminFlag bool) (*types.IRODSChecksum, error) {
+	if conn == nil || !conn.IsConnected() {
+		return nil, errors.Errorf("connection is nil or disconnected")
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForStat(1)
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	if allReplicas {
+		// server rejects resource with all replicas option
+		resource = ""
+	}
+
+	request := message.NewIRODSMessageChecksumRequest(path, resource)
+	request.SetForce(force)
+	request.SetAllReplicas(allReplicas)
+	request.SetAdminFlag(adminFlag)
+
+	response, err := requestDataObjectChecksum(conn, request, path)
+	if err != nil {
+		return nil, err
+	}
+
+	checksum, err := types.CreateIRODSChecksum(response.Checksum)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create iRODS checksum")
+	}
+
+	return checksum, nil
+}
+
+// VerifyDataObjectChecksum verifies checksums of the data object replicas against the catalog without registering new checksums, like ichksum -K
+// returns an iRODS error with USER_CHKSUM_MISMATCH code if checksum does not match
+// if allReplicas is set, checksums of all replicas are verified and resource is ignored
+func VerifyDataObjectChecksum(conn *connection.IRODSConnection, path string, resource string, allReplicas bool, adminFlag bool) error {
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForStat(1)
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	if allReplicas {
+		// server rejects resource with all replicas option
+		resource = ""
+	}
+
+	request := message.NewIRODSMessageChecksumRequest(path, resource)
+	request.SetVerify(true)
+	request.SetAllReplicas(allReplicas)
+	request.SetAdminFlag(adminFlag)
+
+	_, err := requestDataObjectChecksum(conn, request, path)
+	return err
+}
+
+func requestDataObjectChecksum(conn *connection.IRODSConnection, request *message.IRODSMessageChecksumRequest, path string) (*message.IRODSMessageChecksumResponse, error) {
 	response := message.IRODSMessageChecksumResponse{}
-	err := conn.RequestAndCheck(request, &response, nil, conn.GetOperationTimeout())
+	err := conn.RequestAndCheck(request, &response, nil, conn.GetLongResponseOperationTimeout())
 	if err != nil {
 		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_FILE {
 			newErr := errors.Join(err, types.NewFileNotFoundError(path))
@@ -44,10 +134,5 @@ func GetDataObjectChecksum(conn *connection.IRODSConnection, path string, resour
 		return nil, errors.Wrapf(err, "failed to get data object checksum")
 	}
 
-	checksum, err := types.CreateIRODSChecksum(response.Checksum)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to create iRODS checksum")
-	}
-
-	return checksum, nil
+	return &response, nil
 }
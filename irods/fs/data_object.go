@@ -1749,6 +1749,50 @@ func ReplicateDataObject(conn *connection.IRODSConnection, path string, resource
 	return nil
 }
 
+// PhysicalMoveDataObject moves a replica of a data object from srcResource to destResource without changing its logical path, like iphymv
+// if srcResource is empty, the server chooses a replica to move
+func PhysicalMoveDataObject(conn *connection.IRODSConnection, path string, srcResource string, destResource string, adminFlag bool) error {
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForDataObjectUpdate(1)
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	// use default resource when resource param is empty
+	if len(destResource) == 0 {
+		account := conn.GetAccount()
+		destResource = account.DefaultResource
+	}
+
+	request := message.NewIRODSMessagePhysicalMoveDataObjectRequest(path, srcResource, destResource)
+
+	if adminFlag {
+		request.AddKeyVal(common.ADMIN_KW, "")
+	}
+
+	response := message.IRODSMessagePhysicalMoveDataObjectResponse{}
+	err := conn.RequestAndCheck(request, &response, nil, conn.GetLongResponseOperationTimeout())
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_FILE {
+			newErr := errors.Join(err, types.NewFileNotFoundError(path))
+			return errors.Wrapf(newErr, "failed to find the data object for path %q", path)
+		} else if types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_COLLECTION {
+			newErr := errors.Join(err, types.NewFileNotFoundError(path))
+			return errors.Wrapf(newErr, "failed to find the collection for path %q", path)
+		}
+
+		return errors.Wrapf(err, "failed to move data object %q from resource %q to %q", path, srcResource, destResource)
+	}
+	return nil
+}
+
 // TrimDataObject trims replicas for a data object
 func TrimDataObject(conn *connection.IRODSConnection, path string, resource string, minCopies int, minAgeMinutes int, adminFlag bool) error {
 	if conn == nil || !conn.IsConnected() {
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"path"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
 
@@ -165,6 +167,127 @@ func UploadDataObjectFromBufferWithConnection(conn *connection.IRODSConnection,
 	return nil
 }
 
+// UploadDataObjectFromReader put a data object to the iRODS path from reader, streaming data with a buffer of bufferSize
+// size is only used for transfer callback, pass -1 if unknown
+// the data object is truncated when opened, it is removed if reading or writing data fails to not leave partial data
+// returns the number of bytes uploaded
+func UploadDataObjectFromReader(sess *session.IRODSSession, reader io.Reader, irodsPath string, resource string, size int64, bufferSize int, replicate bool, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback) (int64, error) {
+	logger := log.WithFields(log.Fields{
+		"irods_path": irodsPath,
+		"resource":   resource,
+		"replicate":  replicate,
+	})
+
+	logger.Debug("upload data object from reader")
+
+	// use default resource when resource param is empty
+	if len(resource) == 0 {
+		account := sess.GetAccount()
+		resource = account.DefaultResource
+	}
+
+	if bufferSize <= 0 {
+		bufferSize = common.ReadWriteBufferSize
+	}
+
+	conn, err := sess.AcquireConnection(false)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get connection")
+	}
+
+	defer func() {
+		_ = sess.ReturnConnection(conn)
+	}()
+
+	if conn == nil || !conn.IsConnected() {
+		return 0, errors.Errorf("connection is nil or disconnected")
+	}
+
+	// open a new file
+	handle, err := CreateDataObject(conn, irodsPath, resource, "w+", true, keywords)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to open data object %q", irodsPath)
+	}
+
+	getTotal := func(processed int64) int64 {
+		if size < 0 || processed > size {
+			return processed
+		}
+		return size
+	}
+
+	totalBytesUploaded := int64(0)
+	if transferCallback != nil {
+		transferCallback("upload", totalBytesUploaded, getTotal(totalBytesUploaded))
+	}
+
+	// block write call-back
+	var blockWriteCallback common.TransferTrackerCallback
+	if transferCallback != nil {
+		blockWriteCallback = func(taskName string, processed int64, total int64) {
+			current := totalBytesUploaded + processed
+			transferCallback("upload", current, getTotal(current))
+		}
+	}
+
+	buffer := make([]byte, bufferSize)
+	var writeErr error
+	var readErr error
+	// copy
+	for {
+		bytesRead, err := io.ReadFull(reader, buffer)
+		if bytesRead > 0 {
+			writeErr = WriteDataObjectWithTrackerCallBack(conn, handle, buffer[:bytesRead], blockWriteCallback)
+			if writeErr != nil {
+				break
+			}
+
+			totalBytesUploaded += int64(bytesRead)
+		}
+
+		if err != nil {
+			if err != io.EOF && err != io.ErrUnexpectedEOF {
+				readErr = errors.Wrapf(err, "failed to read data to upload to %q", irodsPath)
+			}
+			break
+		}
+	}
+
+	closeErr := CloseDataObject(conn, handle)
+
+	if readErr != nil || writeErr != nil {
+		// remove partial data
+		logger.Debugf("remove partially uploaded data object %q", irodsPath)
+		deleteErr := DeleteDataObject(conn, irodsPath, true)
+		if deleteErr != nil {
+			logger.WithError(deleteErr).Warnf("failed to remove partially uploaded data object %q", irodsPath)
+		}
+
+		if readErr != nil {
+			return totalBytesUploaded, readErr
+		}
+		return totalBytesUploaded, writeErr
+	}
+
+	if closeErr != nil {
+		return totalBytesUploaded, closeErr
+	}
+
+	if transferCallback != nil {
+		transferCallback("upload", totalBytesUploaded, totalBytesUploaded)
+	}
+
+	// replicate
+	if replicate {
+		replErr := ReplicateDataObject(conn, irodsPath, "", true, false)
+		if replErr != nil {
+			return totalBytesUploaded, replErr
+		}
+	}
+
+	return totalBytesUploaded, nil
+}
+
 // UploadDataObject put a data object at the local path to the iRODS path
 func UploadDataObject(sess *session.IRODSSession, localPath string, irodsPath string, resource string, replicate bool, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback) error {
 	logger := log.WithFields(log.Fields{
@@ -817,83 +940,753 @@ func UploadDataObjectParallelWithConnections(conns []*connection.IRODSConnection
 	return nil
 }
 
-// DownloadDataObjectToBuffer downloads a data object at the iRODS path to buffer
-func DownloadDataObjectToBuffer(sess *session.IRODSSession, dataObject *types.IRODSDataObject, resource string, buffer *bytes.Buffer, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback) error {
+// UploadDataObjectResumable puts a data object at the local path to the iRODS path with support of transfer resume
+func UploadDataObjectResumable(sess *session.IRODSSession, localPath string, irodsPath string, resource string, replicate bool, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback) error {
+	return UploadDataObjectParallelResumable(sess, localPath, irodsPath, resource, 1, replicate, keywords, transferCallback)
+}
+
+// UploadDataObjectParallelResumable puts a data object at the local path to the iRODS path in parallel with support of transfer resume
+// Partitions a file into n (taskNum) tasks and uploads in parallel, progress of tasks is recorded in a status file next to the local file
+// An interrupted upload reopens the same replica and continues from recorded offsets after verifying written ranges
+// The data object is not closed on failure, not to finalize the replica and verify checksum of partial data
+// The upload starts over if the local file, the iRODS path or the number of tasks changes
+// Servers not supporting replica tokens upload in a single task
+func UploadDataObjectParallelResumable(sess *session.IRODSSession, localPath string, irodsPath string, resource string, taskNum int, replicate bool, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback) error {
 	logger := log.WithFields(log.Fields{
-		"irods_path": dataObject.Path,
+		"local_path": localPath,
+		"irods_path": irodsPath,
 		"resource":   resource,
+		"task_num":   taskNum,
+		"replicate":  replicate,
 	})
 
-	logger.Debug("download data object")
-
 	// use default resource when resource param is empty
 	if len(resource) == 0 {
 		account := sess.GetAccount()
 		resource = account.DefaultResource
 	}
 
-	conn, err := sess.AcquireConnection(true)
+	stat, err := os.Stat(localPath)
 	if err != nil {
-		return errors.Wrapf(err, "failed to get connection")
+		return errors.Wrapf(err, "failed to stat file %q", localPath)
 	}
 
-	defer func() {
-		_ = sess.ReturnConnection(conn)
-	}()
+	fileLength := stat.Size()
+	modTime := stat.ModTime().UnixNano()
 
-	if conn == nil || !conn.IsConnected() {
-		return errors.Errorf("connection is nil or disconnected")
+	if fileLength == 0 {
+		// empty file
+		return UploadDataObject(sess, localPath, irodsPath, resource, replicate, keywords, transferCallback)
 	}
 
-	handle, _, err := OpenDataObject(conn, dataObject.Path, resource, "r", keywords)
+	useReplicaToken := sess.SupportParallelUpload()
+
+	numTasks := taskNum
+	if numTasks <= 0 {
+		numTasks = util.GetNumTasksForParallelTransfer(fileLength)
+	}
+
+	// 1 control connection + numTasks transfer connections
+	// without replica token, the control connection transfers data in a single task
+	numConns := 1 + numTasks
+	if !useReplicaToken {
+		numConns = 1
+	}
+
+	connections, err := sess.AcquireConnectionsMulti(numConns, false)
 	if err != nil {
-		return errors.Wrapf(err, "failed to open data object %q", dataObject.Path)
+		if len(connections) == 0 {
+			return errors.Wrapf(err, "failed to get %d connections, got %d", numConns, len(connections))
+		}
+
+		logger.WithError(err).Debugf("failed to get %d connections, got %d", numConns, len(connections))
+	}
+
+	for _, conn := range connections {
+		if conn == nil || !conn.IsConnected() {
+			sess.ReturnConnectionsMulti(connections) //nolint
+			return errors.Errorf("connection is nil or disconnected")
+		}
 	}
+
+	controlConn := connections[0]
+	transferConns := connections[1:]
+
+	// the data object opened is left open on failure, the connection is discarded to release it
+	discardControlConn := false
 	defer func() {
-		_ = CloseDataObject(conn, handle)
+		if discardControlConn {
+			sess.DiscardConnection(controlConn)
+			return
+		}
+
+		_ = sess.ReturnConnection(controlConn)
 	}()
 
-	totalBytesDownloaded := int64(0)
-	if transferCallback != nil {
-		transferCallback("download", atomic.LoadInt64(&totalBytesDownloaded), dataObject.Size)
+	if len(transferConns) == 0 {
+		// only one is available
+		useReplicaToken = false
 	}
 
-	// block read call-back
-	var blockReadCallback common.TransferTrackerCallback
-	if transferCallback != nil {
-		blockReadCallback = func(taskName string, processed int64, total int64) {
-			transferCallback("download", atomic.LoadInt64(&totalBytesDownloaded)+processed, dataObject.Size)
+	// adjust number of tasks
+	if !useReplicaToken {
+		numTasks = 1
+	} else if numTasks != len(transferConns) {
+		logger.Debugf("adjust number of tasks from %d to %d", numTasks, len(transferConns))
+		numTasks = len(transferConns)
+	}
+
+	returnTransferConns := func() {
+		for _, transferConn := range transferConns {
+			_ = sess.ReturnConnection(transferConn)
 		}
 	}
 
-	buffer2 := make([]byte, common.ReadWriteBufferSize)
-	var writeErr error
-	// copy
-	for {
-		bytesRead, readErr := ReadDataObjectWithTrackerCallBack(conn, handle, buffer2, blockReadCallback)
-		if bytesRead > 0 {
-			_, writeErr = buffer.Write(buffer2[:bytesRead])
-			if writeErr != nil {
-				break
-			}
+	// create transfer status
+	transferStatusLocal, err := GetOrNewDataObjectTransferStatusLocalForUpload(localPath, irodsPath, fileLength, modTime, numTasks)
+	if err != nil {
+		returnTransferConns()
+		return errors.Wrapf(err, "failed to read transfer status file for %q", localPath)
+	}
 
-			atomic.AddInt64(&totalBytesDownloaded, int64(bytesRead))
+	transferStatus := transferStatusLocal.GetStatus()
+
+	// open the data object
+	openDataObject := func(resuming bool) (*types.IRODSFileHandle, error) {
+		openKeywords := map[common.KeyWord]string{}
+		for k, v := range keywords {
+			openKeywords[k] = v
 		}
 
-		if readErr != nil {
-			if readErr == io.EOF {
-				break
-			} else {
-				return errors.Wrapf(readErr, "failed to read data object %q", dataObject.Path)
+		if resuming {
+			// open the same replica without truncating
+			if len(transferStatus.ResourceHierarchy) > 0 {
+				openKeywords[common.RESC_HIER_STR_KW] = transferStatus.ResourceHierarchy
 			}
+
+			if useReplicaToken {
+				return OpenDataObjectForPutParallel(controlConn, irodsPath, resource, string(types.FileOpenModeReadWrite), common.OPER_TYPE_NONE, numTasks, fileLength, openKeywords)
+			}
+
+			handle, _, openErr := OpenDataObject(controlConn, irodsPath, resource, string(types.FileOpenModeReadWrite), openKeywords)
+			return handle, openErr
+		}
+
+		if useReplicaToken {
+			return OpenDataObjectForPutParallel(controlConn, irodsPath, resource, string(types.FileOpenModeWriteTruncate), common.OPER_TYPE_NONE, numTasks, fileLength, openKeywords)
 		}
+
+		return CreateDataObject(controlConn, irodsPath, resource, string(types.FileOpenModeWriteTruncate), true, openKeywords)
 	}
 
-	if writeErr != nil {
-		return writeErr
+	resuming := len(transferStatus.StatusMap) > 0
+
+	handle, err := openDataObject(resuming)
+	if err != nil && resuming && types.IsFileNotFoundError(err) {
+		// the data object is removed, start over
+		logger.Debugf("failed to find the data object to resume uploading, start over")
+
+		transferStatusLocal = NewDataObjectTransferStatusLocalForUpload(localPath, irodsPath, fileLength, modTime, numTasks)
+		transferStatus = transferStatusLocal.GetStatus()
+		resuming = false
+
+		handle, err = openDataObject(resuming)
 	}
 
-	return nil
+	if err != nil {
+		returnTransferConns()
+		return err
+	}
+
+	replicaToken := ""
+	if useReplicaToken {
+		replicaToken, transferStatus.ResourceHierarchy, err = GetReplicaAccessInfo(controlConn, handle)
+		if err != nil {
+			returnTransferConns()
+			discardControlConn = true
+			return err
+		}
+
+		logger.Debugf("replicaToken %s, resourceHierarchy %s", replicaToken, transferStatus.ResourceHierarchy)
+	}
+
+	logger.Debugf("uploading data object in parallel with resume, size(%d), threads(%d), resuming(%t)", fileLength, numTasks, resuming)
+
+	err = transferStatusLocal.CreateStatusFile()
+	if err != nil {
+		returnTransferConns()
+		discardControlConn = true
+		return errors.Wrapf(err, "failed to create transfer status file for %q", localPath)
+	}
+
+	err = transferStatusLocal.WriteHeader()
+	if err == nil {
+		// keep progress of previous attempts
+		err = transferStatusLocal.WriteStatusMap()
+	}
+
+	if err != nil {
+		returnTransferConns()
+		transferStatusLocal.CloseStatusFile() //nolint
+		discardControlConn = true
+		return errors.Wrapf(err, "failed to write transfer status file for %q", localPath)
+	}
+
+	errChan := make(chan error, numTasks)
+	taskWaitGroup := sync.WaitGroup{}
+
+	totalBytesUploaded := int64(0)
+
+	uploadTask := func(taskID int, transferConn *connection.IRODSConnection, taskOffset int64, taskLength int64) {
+		taskLogger := log.WithFields(log.Fields{
+			"local_path":  localPath,
+			"irods_path":  irodsPath,
+			"task_id":     taskID,
+			"task_offset": taskOffset,
+			"task_length": taskLength,
+		})
+
+		taskLogger.Debug("uploading data object partition")
+
+		defer taskWaitGroup.Done()
+
+		taskHandle := handle
+		if useReplicaToken {
+			// close transfer connection after use
+			defer sess.DiscardConnection(transferConn)
+
+			// open the file with read-write mode to verify written data and not to seek to end
+			taskMode := string(types.FileOpenModeWriteOnly)
+			if resuming {
+				taskMode = string(types.FileOpenModeReadWrite)
+			}
+
+			var taskErr error
+			taskHandle, _, taskErr = OpenDataObjectWithReplicaToken(transferConn, irodsPath, resource, taskMode, replicaToken, transferStatus.ResourceHierarchy, numTasks, fileLength, keywords)
+			if taskErr != nil {
+				errChan <- taskErr
+				return
+			}
+			defer func() {
+				errClose := CloseDataObjectReplica(transferConn, taskHandle)
+				if errClose != nil {
+					errChan <- errClose
+				}
+			}()
+		}
+
+		f, taskErr := os.OpenFile(localPath, os.O_RDONLY, 0)
+		if taskErr != nil {
+			errChan <- errors.Wrapf(taskErr, "failed to open file %q", localPath)
+			return
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+
+		// find last failure point
+		lastOffset := taskOffset
+		if transferStatusEntry, ok := transferStatus.StatusMap[taskOffset]; ok {
+			lastOffset = transferStatusEntry.StartOffset + transferStatusEntry.CompletedLength
+		}
+
+		if lastOffset > taskOffset {
+			verified, verifyErr := verifyUploadedDataObjectRange(transferConn, taskHandle, f, taskOffset, lastOffset)
+			if verifyErr != nil {
+				errChan <- verifyErr
+				return
+			}
+
+			if verified {
+				taskLogger.Debugf("resuming uploading data object partition, last offset %d", lastOffset)
+			} else {
+				taskLogger.Debugf("failed to verify uploaded data object partition, start over")
+				lastOffset = taskOffset
+			}
+		}
+
+		atomic.AddInt64(&totalBytesUploaded, lastOffset-taskOffset)
+		if transferCallback != nil {
+			transferCallback("upload", atomic.LoadInt64(&totalBytesUploaded), fileLength)
+		}
+
+		taskNewOffset, taskErr := SeekDataObject(transferConn, taskHandle, lastOffset, types.SeekSet)
+		if taskErr != nil {
+			errChan <- taskErr
+			return
+		}
+
+		if taskNewOffset != lastOffset {
+			errChan <- errors.Errorf("failed to seek to target offset %d", lastOffset)
+			return
+		}
+
+		taskRemain := taskLength - (lastOffset - taskOffset)
+
+		// copy
+		buffer := make([]byte, common.ReadWriteBufferSize)
+		var taskWriteErr error
+		for taskRemain > 0 {
+			bufferLen := common.ReadWriteBufferSize
+			if taskRemain < int64(bufferLen) {
+				bufferLen = int(taskRemain)
+			}
+
+			bytesRead, taskReadErr := f.ReadAt(buffer[:bufferLen], taskOffset+(taskLength-taskRemain))
+			if bytesRead > 0 {
+				taskWriteErr = WriteDataObjectWithTrackerCallBack(transferConn, taskHandle, buffer[:bytesRead], nil)
+				if taskWriteErr != nil {
+					break
+				}
+
+				atomic.AddInt64(&totalBytesUploaded, int64(bytesRead))
+				if transferCallback != nil {
+					transferCallback("upload", atomic.LoadInt64(&totalBytesUploaded), fileLength)
+				}
+
+				// write status
+				transferStatusEntry := &DataObjectTransferStatusEntry{
+					StartOffset:     taskOffset,
+					Length:          taskLength,
+					CompletedLength: (taskLength - taskRemain) + int64(bytesRead),
+				}
+				transferStatusLocal.WriteStatus(transferStatusEntry) //nolint
+
+				taskRemain -= int64(bytesRead)
+			}
+
+			if taskReadErr != nil {
+				if taskReadErr == io.EOF {
+					break
+				} else {
+					taskWriteErr = errors.Wrapf(taskReadErr, "failed to read file %q", localPath)
+					break
+				}
+			}
+
+			if len(errChan) > 0 {
+				// other tasks failed
+				taskWriteErr = errors.Errorf("stop running as other tasks failed")
+				break
+			}
+		}
+
+		if taskWriteErr != nil {
+			errChan <- taskWriteErr
+		}
+	}
+
+	lengthPerThread := fileLength / int64(numTasks)
+	if fileLength%int64(numTasks) > 0 {
+		lengthPerThread++
+	}
+
+	offset := int64(0)
+
+	for i := 0; i < numTasks; i++ {
+		taskWaitGroup.Add(1)
+
+		transferConn := controlConn
+		if useReplicaToken {
+			transferConn = transferConns[i]
+		}
+
+		go uploadTask(i, transferConn, offset, lengthPerThread)
+		offset += lengthPerThread
+	}
+
+	taskWaitGroup.Wait()
+
+	if len(errChan) > 0 {
+		// keep the status file to resume
+		_ = transferStatusLocal.CloseStatusFile()
+		discardControlConn = true
+		return <-errChan
+	}
+
+	err = transferStatusLocal.CloseStatusFile()
+	if err != nil {
+		_ = CloseDataObject(controlConn, handle)
+		return errors.Wrapf(err, "failed to close status file")
+	}
+
+	// checksum is verified by the server when closing
+	closeErr := CloseDataObject(controlConn, handle)
+
+	// start over next time if verification fails
+	err = transferStatusLocal.DeleteStatusFile()
+	if closeErr != nil {
+		return closeErr
+	}
+
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete status file")
+	}
+
+	// replicate
+	if replicate {
+		err = ReplicateDataObject(controlConn, irodsPath, "", true, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// verifyUploadedDataObjectRange reads the uploaded range of the data object back and compares it with the local file
+func verifyUploadedDataObjectRange(conn *connection.IRODSConnection, handle *types.IRODSFileHandle, f *os.File, startOffset int64, endOffset int64) (bool, error) {
+	newOffset, err := SeekDataObject(conn, handle, startOffset, types.SeekSet)
+	if err != nil {
+		return false, err
+	}
+
+	if newOffset != startOffset {
+		return false, nil
+	}
+
+	irodsData := make([]byte, common.ReadWriteBufferSize)
+	localData := make([]byte, common.ReadWriteBufferSize)
+
+	offset := startOffset
+	for offset < endOffset {
+		bufferLen := common.ReadWriteBufferSize
+		if endOffset-offset < int64(bufferLen) {
+			bufferLen = int(endOffset - offset)
+		}
+
+		irodsDataLength := 0
+		for irodsDataLength < bufferLen {
+			readLen, readErr := ReadDataObject(conn, handle, irodsData[irodsDataLength:bufferLen])
+			irodsDataLength += readLen
+
+			if readErr != nil {
+				if readErr == io.EOF {
+					break
+				}
+				return false, readErr
+			}
+
+			if readLen == 0 {
+				break
+			}
+		}
+
+		if irodsDataLength != bufferLen {
+			// not written
+			return false, nil
+		}
+
+		_, err = f.ReadAt(localData[:bufferLen], offset)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to read file %q", f.Name())
+		}
+
+		if !bytes.Equal(irodsData[:bufferLen], localData[:bufferLen]) {
+			return false, nil
+		}
+
+		offset += int64(bufferLen)
+	}
+
+	return true, nil
+}
+
+// UploadDataObjectsBulk puts small local files to the iRODS collection at once, like iput -b
+// file data are written back to back in a buffer in memory, the server splits the buffer at the end offsets of files
+// the number of files and the total size of files must not exceed common.MaxBulkOperationFiles and common.BulkOperationBufSize
+// checksums maps local paths to iRODS checksum strings, if given, the checksums are registered, or verified by the server if verifyChecksum is set
+func UploadDataObjectsBulk(conn *connection.IRODSConnection, localPaths []string, irodsCollectionPath string, resource string, force bool, checksums map[string]string, verifyChecksum bool, transferCallback common.TransferTrackerCallback) error {
+	logger := log.WithFields(log.Fields{
+		"irods_path": irodsCollectionPath,
+		"resource":   resource,
+		"files":      len(localPaths),
+	})
+
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	if len(localPaths) == 0 {
+		return nil
+	}
+
+	if len(localPaths) > common.MaxBulkOperationFiles {
+		return errors.Errorf("too many files for bulk upload, %d > %d", len(localPaths), common.MaxBulkOperationFiles)
+	}
+
+	if verifyChecksum && len(checksums) == 0 {
+		return errors.Errorf("checksums of files must be given to verify checksum")
+	}
+
+	// use default resource when resource param is empty
+	if len(resource) == 0 {
+		account := conn.GetAccount()
+		resource = account.DefaultResource
+	}
+
+	withChecksum := len(checksums) > 0
+	request := message.NewIRODSMessageBulkDataObjectPutRequest(irodsCollectionPath, resource, withChecksum)
+
+	if force {
+		request.AddKeyVal(common.FORCE_FLAG_KW, "")
+	}
+
+	if withChecksum {
+		if verifyChecksum {
+			request.AddKeyVal(common.VERIFY_CHKSUM_KW, "")
+		} else {
+			request.AddKeyVal(common.REG_CHKSUM_KW, "")
+		}
+	}
+
+	logger.Debug("fill buffer with files for bulk upload")
+
+	// fill buffer, like fillBBufWithFile in iput
+	buffer := &bytes.Buffer{}
+
+	for _, localPath := range localPaths {
+		stat, err := os.Stat(localPath)
+		if err != nil {
+			return errors.Wrapf(err, "failed to stat file %q", localPath)
+		}
+
+		if !stat.Mode().IsRegular() {
+			return errors.Errorf("failed to add %q to buffer, not a regular file", localPath)
+		}
+
+		if int64(buffer.Len())+stat.Size() > int64(common.BulkOperationBufSize) {
+			return errors.Errorf("files for bulk upload are too large, exceeding %d bytes", common.BulkOperationBufSize)
+		}
+
+		checksum := ""
+		if withChecksum {
+			checksum = checksums[localPath]
+			if len(checksum) == 0 {
+				return errors.Errorf("checksum of file %q is not given", localPath)
+			}
+		}
+
+		err = copyLocalFileTo(buffer, localPath)
+		if err != nil {
+			return err
+		}
+
+		// the server reads data of the file up to the offset
+		request.AddFile(path.Join(irodsCollectionPath, filepath.Base(localPath)), int(stat.Mode().Perm()), int64(buffer.Len()), checksum)
+	}
+
+	request.SetData(buffer.Bytes())
+
+	totalSize := int64(buffer.Len())
+	if transferCallback != nil {
+		transferCallback("upload", 0, totalSize)
+	}
+
+	logger.Debug("bulk upload data objects")
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForDataObjectCreate(uint64(len(localPaths)))
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	response := message.IRODSMessageBulkDataObjectPutResponse{}
+	err := conn.RequestAndCheckWithTrackerCallBack(request, &response, nil, conn.GetLongResponseOperationTimeout(), transferCallback, nil)
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_COLLECTION {
+			newErr := errors.Join(err, types.NewFileNotFoundError(irodsCollectionPath))
+			return errors.Wrapf(newErr, "failed to find the collection for path %q", irodsCollectionPath)
+		} else if types.GetIRODSErrorCode(err) == common.CAT_NAME_EXISTS_AS_DATAOBJ || types.GetIRODSErrorCode(err) == common.OVERWRITE_WITHOUT_FORCE_FLAG {
+			newErr := errors.Join(err, types.NewFileAlreadyExistError(irodsCollectionPath))
+			return errors.Wrapf(newErr, "failed to bulk upload data objects to %q, some data objects already exist", irodsCollectionPath)
+		}
+
+		return errors.Wrapf(err, "failed to bulk upload data objects to %q", irodsCollectionPath)
+	}
+
+	if transferCallback != nil {
+		transferCallback("upload", totalSize, totalSize)
+	}
+
+	return nil
+}
+
+func copyLocalFileTo(w io.Writer, localPath string) error {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open file %q", localPath)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	_, err = io.Copy(w, f)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read file %q", localPath)
+	}
+
+	return nil
+}
+
+// DownloadDataObjectToBuffer downloads a data object at the iRODS path to buffer
+func DownloadDataObjectToBuffer(sess *session.IRODSSession, dataObject *types.IRODSDataObject, resource string, buffer *bytes.Buffer, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback) error {
+	logger := log.WithFields(log.Fields{
+		"irods_path": dataObject.Path,
+		"resource":   resource,
+	})
+
+	logger.Debug("download data object")
+
+	// use default resource when resource param is empty
+	if len(resource) == 0 {
+		account := sess.GetAccount()
+		resource = account.DefaultResource
+	}
+
+	conn, err := sess.AcquireConnection(true)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get connection")
+	}
+
+	defer func() {
+		_ = sess.ReturnConnection(conn)
+	}()
+
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	handle, _, err := OpenDataObject(conn, dataObject.Path, resource, "r", keywords)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open data object %q", dataObject.Path)
+	}
+	defer func() {
+		_ = CloseDataObject(conn, handle)
+	}()
+
+	totalBytesDownloaded := int64(0)
+	if transferCallback != nil {
+		transferCallback("download", atomic.LoadInt64(&totalBytesDownloaded), dataObject.Size)
+	}
+
+	// block read call-back
+	var blockReadCallback common.TransferTrackerCallback
+	if transferCallback != nil {
+		blockReadCallback = func(taskName string, processed int64, total int64) {
+			transferCallback("download", atomic.LoadInt64(&totalBytesDownloaded)+processed, dataObject.Size)
+		}
+	}
+
+	buffer2 := make([]byte, common.ReadWriteBufferSize)
+	var writeErr error
+	// copy
+	for {
+		bytesRead, readErr := ReadDataObjectWithTrackerCallBack(conn, handle, buffer2, blockReadCallback)
+		if bytesRead > 0 {
+			_, writeErr = buffer.Write(buffer2[:bytesRead])
+			if writeErr != nil {
+				break
+			}
+
+			atomic.AddInt64(&totalBytesDownloaded, int64(bytesRead))
+		}
+
+		if readErr != nil {
+			if readErr == io.EOF {
+				break
+			} else {
+				return errors.Wrapf(readErr, "failed to read data object %q", dataObject.Path)
+			}
+		}
+	}
+
+	if writeErr != nil {
+		return writeErr
+	}
+
+	return nil
+}
+
+// DownloadDataObjectToWriter downloads a data object at the iRODS path to writer, streaming data with a buffer of bufferSize
+// returns the number of bytes downloaded
+func DownloadDataObjectToWriter(sess *session.IRODSSession, dataObject *types.IRODSDataObject, resource string, writer io.Writer, bufferSize int, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback) (int64, error) {
+	logger := log.WithFields(log.Fields{
+		"irods_path": dataObject.Path,
+		"resource":   resource,
+	})
+
+	logger.Debug("download data object to writer")
+
+	// use default resource when resource param is empty
+	if len(resource) == 0 {
+		account := sess.GetAccount()
+		resource = account.DefaultResource
+	}
+
+	if bufferSize <= 0 {
+		bufferSize = common.ReadWriteBufferSize
+	}
+
+	conn, err := sess.AcquireConnection(true)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get connection")
+	}
+
+	defer func() {
+		_ = sess.ReturnConnection(conn)
+	}()
+
+	if conn == nil || !conn.IsConnected() {
+		return 0, errors.Errorf("connection is nil or disconnected")
+	}
+
+	handle, _, err := OpenDataObject(conn, dataObject.Path, resource, "r", keywords)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to open data object %q", dataObject.Path)
+	}
+	defer func() {
+		_ = CloseDataObject(conn, handle)
+	}()
+
+	totalBytesDownloaded := int64(0)
+	if transferCallback != nil {
+		transferCallback("download", totalBytesDownloaded, dataObject.Size)
+	}
+
+	// block read call-back
+	var blockReadCallback common.TransferTrackerCallback
+	if transferCallback != nil {
+		blockReadCallback = func(taskName string, processed int64, total int64) {
+			transferCallback("download", totalBytesDownloaded+processed, dataObject.Size)
+		}
+	}
+
+	buffer := make([]byte, bufferSize)
+	// copy
+	for {
+		bytesRead, readErr := ReadDataObjectWithTrackerCallBack(conn, handle, buffer, blockReadCallback)
+		if bytesRead > 0 {
+			_, writeErr := writer.Write(buffer[:bytesRead])
+			if writeErr != nil {
+				return totalBytesDownloaded, errors.Wrapf(writeErr, "failed to write data of data object %q", dataObject.Path)
+			}
+
+			totalBytesDownloaded += int64(bytesRead)
+		}
+
+		if readErr != nil {
+			if readErr == io.EOF {
+				break
+			}
+			return totalBytesDownloaded, errors.Wrapf(readErr, "failed to read data object %q", dataObject.Path)
+		}
+	}
+
+	return totalBytesDownloaded, nil
 }
 
 // DownloadDataObjectToBufferWithConnection downloads a data object at the iRODS path to buffer
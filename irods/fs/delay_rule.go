@@ -0,0 +1,274 @@
+package fs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	"github.com/cyverse/go-irodsclient/irods/message"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+)
+
+// GetDelayRule returns the delay rule for the given rule id
+func GetDelayRule(conn *connection.IRODSConnection, ruleID int64) (*types.IRODSDelayRule, error) {
+	if conn == nil || !conn.IsConnected() {
+		return nil, errors.Errorf("connection is nil or disconnected")
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	rules, err := listDelayRules(conn, func(query *message.IRODSMessageQueryRequest) {
+		query.AddEqualIDCondition(common.ICAT_COLUMN_RULE_EXEC_ID, ruleID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rules) != 1 {
+		newErr := types.NewDelayRuleNotFoundError(fmt.Sprintf("%d", ruleID))
+		return nil, errors.Wrapf(newErr, "failed to find the delay rule for id %d", ruleID)
+	}
+
+	return rules[0], nil
+}
+
+// ListDelayRules returns all delay rules visible to the user
+func ListDelayRules(conn *connection.IRODSConnection) ([]*types.IRODSDelayRule, error) {
+	if conn == nil || !conn.IsConnected() {
+		return nil, errors.Errorf("connection is nil or disconnected")
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	return listDelayRules(conn, nil)
+}
+
+// ListDelayRulesForUser returns delay rules owned by the given user
+func ListDelayRulesForUser(conn *connection.IRODSConnection, userName string) ([]*types.IRODSDelayRule, error) {
+	if conn == nil || !conn.IsConnected() {
+		return nil, errors.Errorf("connection is nil or disconnected")
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	return listDelayRules(conn, func(query *message.IRODSMessageQueryRequest) {
+		query.AddEqualStringCondition(common.ICAT_COLUMN_RULE_EXEC_USER_NAME, userName)
+	})
+}
+
+func listDelayRules(conn *connection.IRODSConnection, addConditions func(query *message.IRODSMessageQueryRequest)) ([]*types.IRODSDelayRule, error) {
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForList(1)
+	}
+
+	rules := []*types.IRODSDelayRule{}
+
+	continueQuery := true
+	continueIndex := 0
+	for continueQuery {
+		query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, continueIndex, 0, 0)
+		query.AddSelect(common.ICAT_COLUMN_RULE_EXEC_ID, 1)
+		query.AddSelect(common.ICAT_COLUMN_RULE_EXEC_NAME, 1)
+		query.AddSelect(common.ICAT_COLUMN_RULE_EXEC_REI_FILE_PATH, 1)
+		query.AddSelect(common.ICAT_COLUMN_RULE_EXEC_USER_NAME, 1)
+		query.AddSelect(common.ICAT_COLUMN_RULE_EXEC_ADDRESS, 1)
+		query.AddSelect(common.ICAT_COLUMN_RULE_EXEC_TIME, 1)
+		query.AddSelect(common.ICAT_COLUMN_RULE_EXEC_FREQUENCY, 1)
+		query.AddSelect(common.ICAT_COLUMN_RULE_EXEC_PRIORITY, 1)
+		query.AddSelect(common.ICAT_COLUMN_RULE_EXEC_ESTIMATED_EXE_TIME, 1)
+		query.AddSelect(common.ICAT_COLUMN_RULE_EXEC_NOTIFICATION_ADDR, 1)
+		query.AddSelect(common.ICAT_COLUMN_RULE_EXEC_LAST_EXE_TIME, 1)
+		query.AddSelect(common.ICAT_COLUMN_RULE_EXEC_STATUS, 1)
+
+		if addConditions != nil {
+			addConditions(query)
+		}
+
+		queryResult := message.IRODSMessageQueryResponse{}
+		err := conn.Request(query, &queryResult, nil, conn.GetLongResponseOperationTimeout())
+		if err != nil {
+			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
+				// empty
+				break
+			}
+
+			return nil, errors.Wrapf(err, "failed to receive a delay rule query result message")
+		}
+
+		err = queryResult.CheckError()
+		if err != nil {
+			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
+				// empty
+				break
+			}
+
+			return nil, errors.Wrapf(err, "received a delay rule query error")
+		}
+
+		if queryResult.RowCount == 0 {
+			break
+		}
+
+		if queryResult.AttributeCount > len(queryResult.SQLResult) {
+			return nil, errors.Errorf("failed to receive delay rule attributes - requires %d, but received %d attributes", queryResult.AttributeCount, len(queryResult.SQLResult))
+		}
+
+		pagenatedRules := make([]*types.IRODSDelayRule, queryResult.RowCount)
+
+		for attr := 0; attr < queryResult.AttributeCount; attr++ {
+			sqlResult := queryResult.SQLResult[attr]
+			if len(sqlResult.Values) != queryResult.RowCount {
+				return nil, errors.Errorf("failed to receive delay rule rows - requires %d, but received %d attributes", queryResult.RowCount, len(sqlResult.Values))
+			}
+
+			for row := 0; row < queryResult.RowCount; row++ {
+				value := sqlResult.Values[row]
+
+				if pagenatedRules[row] == nil {
+					// create a new
+					pagenatedRules[row] = &types.IRODSDelayRule{
+						ID:            -1,
+						ExecutionTime: time.Time{},
+					}
+				}
+
+				switch sqlResult.AttributeIndex {
+				case int(common.ICAT_COLUMN_RULE_EXEC_ID):
+					rID, err := strconv.ParseInt(value, 10, 64)
+					if err != nil {
+						return nil, errors.Wrapf(err, "failed to parse delay rule id %q", value)
+					}
+					pagenatedRules[row].ID = rID
+				case int(common.ICAT_COLUMN_RULE_EXEC_NAME):
+					pagenatedRules[row].Name = value
+				case int(common.ICAT_COLUMN_RULE_EXEC_REI_FILE_PATH):
+					pagenatedRules[row].ReiFilePath = value
+				case int(common.ICAT_COLUMN_RULE_EXEC_USER_NAME):
+					pagenatedRules[row].UserName = value
+				case int(common.ICAT_COLUMN_RULE_EXEC_ADDRESS):
+					pagenatedRules[row].Address = value
+				case int(common.ICAT_COLUMN_RULE_EXEC_TIME):
+					if len(strings.TrimSpace(value)) > 0 {
+						eT, err := util.GetIRODSDateTime(strings.TrimSpace(value))
+						if err != nil {
+							return nil, errors.Wrapf(err, "failed to parse execution time %q", value)
+						}
+						pagenatedRules[row].ExecutionTime = eT
+					}
+				case int(common.ICAT_COLUMN_RULE_EXEC_FREQUENCY):
+					pagenatedRules[row].Frequency = value
+				case int(common.ICAT_COLUMN_RULE_EXEC_PRIORITY):
+					pagenatedRules[row].Priority = value
+				case int(common.ICAT_COLUMN_RULE_EXEC_ESTIMATED_EXE_TIME):
+					pagenatedRules[row].EstimatedExecutionTime = value
+				case int(common.ICAT_COLUMN_RULE_EXEC_NOTIFICATION_ADDR):
+					pagenatedRules[row].NotificationAddress = value
+				case int(common.ICAT_COLUMN_RULE_EXEC_LAST_EXE_TIME):
+					pagenatedRules[row].LastExecutionTime = value
+				case int(common.ICAT_COLUMN_RULE_EXEC_STATUS):
+					pagenatedRules[row].Status = value
+				default:
+					// ignore
+				}
+			}
+		}
+
+		rules = append(rules, pagenatedRules...)
+
+		continueIndex = queryResult.ContinueIndex
+		if continueIndex == 0 {
+			continueQuery = false
+		}
+	}
+
+	return rules, nil
+}
+
+// DeleteDelayRule deletes the delay rule
+func DeleteDelayRule(conn *connection.IRODSConnection, ruleID int64) error {
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	req := message.NewIRODSMessageRuleExecDeleteRequest(ruleID)
+
+	err := conn.RequestAndCheck(req, &message.IRODSMessageRuleExecDeleteResponse{}, nil, conn.GetOperationTimeout())
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND || types.GetIRODSErrorCode(err) == common.CAT_SUCCESS_BUT_WITH_NO_INFO {
+			newErr := errors.Join(err, types.NewDelayRuleNotFoundError(fmt.Sprintf("%d", ruleID)))
+			return errors.Wrapf(newErr, "failed to find the delay rule for id %d", ruleID)
+		}
+
+		return errors.Wrapf(err, "received delete delay rule error")
+	}
+	return nil
+}
+
+// ModifyDelayRule modifies the given attributes of the delay rule
+// keys are one of RULE_*_KW keywords, e.g., common.RULE_EXE_TIME_KW
+func ModifyDelayRule(conn *connection.IRODSConnection, ruleID int64, attributes map[common.KeyWord]string) error {
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	req := message.NewIRODSMessageRuleExecModifyRequest(ruleID)
+	for key, val := range attributes {
+		req.AddKeyVal(key, val)
+	}
+
+	err := conn.RequestAndCheck(req, &message.IRODSMessageRuleExecModifyResponse{}, nil, conn.GetOperationTimeout())
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND || types.GetIRODSErrorCode(err) == common.CAT_SUCCESS_BUT_WITH_NO_INFO {
+			newErr := errors.Join(err, types.NewDelayRuleNotFoundError(fmt.Sprintf("%d", ruleID)))
+			return errors.Wrapf(newErr, "failed to find the delay rule for id %d", ruleID)
+		}
+
+		return errors.Wrapf(err, "received modify delay rule error")
+	}
+	return nil
+}
+
+// ModifyDelayRuleExecutionTime modifies the execution time of the delay rule
+func ModifyDelayRuleExecutionTime(conn *connection.IRODSConnection, ruleID int64, executionTime time.Time) error {
+	return ModifyDelayRule(conn, ruleID, map[common.KeyWord]string{
+		common.RULE_EXE_TIME_KW: fmt.Sprintf("%011d", executionTime.Unix()),
+	})
+}
+
+// ModifyDelayRuleFrequency modifies the frequency of the delay rule, e.g., "1h REPEAT FOR EVER"
+func ModifyDelayRuleFrequency(conn *connection.IRODSConnection, ruleID int64, frequency string) error {
+	return ModifyDelayRule(conn, ruleID, map[common.KeyWord]string{
+		common.RULE_EXE_FREQUENCY_KW: frequency,
+	})
+}
+
+// ModifyDelayRulePriority modifies the priority of the delay rule
+func ModifyDelayRulePriority(conn *connection.IRODSConnection, ruleID int64, priority int) error {
+	return ModifyDelayRule(conn, ruleID, map[common.KeyWord]string{
+		common.RULE_PRIORITY_KW: fmt.Sprintf("%d", priority),
+	})
+}
@@ -0,0 +1,481 @@
+package fs
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	"github.com/cyverse/go-irodsclient/irods/message"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+)
+
+// GenQueryOperator is a comparison operator for GenQuery conditions
+type GenQueryOperator string
+
+const (
+	// GenQueryOperatorEqual is for =
+	GenQueryOperatorEqual GenQueryOperator = "="
+	// GenQueryOperatorNotEqual is for <>
+	GenQueryOperatorNotEqual GenQueryOperator = "<>"
+	// GenQueryOperatorLessThan is for <
+	GenQueryOperatorLessThan GenQueryOperator = "<"
+	// GenQueryOperatorLessThanOrEqual is for <=
+	GenQueryOperatorLessThanOrEqual GenQueryOperator = "<="
+	// GenQueryOperatorGreaterThan is for >
+	GenQueryOperatorGreaterThan GenQueryOperator = ">"
+	// GenQueryOperatorGreaterThanOrEqual is for >=
+	GenQueryOperatorGreaterThanOrEqual GenQueryOperator = ">="
+	// GenQueryOperatorLike is for like
+	GenQueryOperatorLike GenQueryOperator = "like"
+	// GenQueryOperatorNotLike is for not like
+	GenQueryOperatorNotLike GenQueryOperator = "not like"
+)
+
+// ErrStopGenQuery can be returned by GenQueryRowHandler to stop the query without an error
+var ErrStopGenQuery = errors.New("stop genquery")
+
+// GenQueryRow is a row of GenQuery result, maps column numbers to values
+type GenQueryRow map[common.ICATColumnNumber]string
+
+// GenQueryRowHandler is a handler called for each row of GenQuery result
+type GenQueryRowHandler func(row GenQueryRow) error
+
+type genQuerySelect struct {
+	column common.ICATColumnNumber
+	option int
+}
+
+type genQueryCondition struct {
+	column    common.ICATColumnNumber
+	condition string
+}
+
+// GenQuery builds a GenQuery (GenQuery1) query
+// e.g., NewGenQuery().Select(common.ICAT_COLUMN_COLL_NAME, common.ICAT_COLUMN_DATA_NAME).Where(common.ICAT_COLUMN_DATA_SIZE, GenQueryOperatorGreaterThan, "1024")
+type GenQuery struct {
+	selects    []genQuerySelect
+	conditions []genQueryCondition
+	keyVals    map[common.KeyWord]string
+	options    int
+	maxRows    int
+	err        error // first invalid condition, returned when the query is executed
+}
+
+// NewGenQuery creates a new GenQuery builder
+func NewGenQuery() *GenQuery {
+	return &GenQuery{
+		selects:    []genQuerySelect{},
+		conditions: []genQueryCondition{},
+		keyVals:    map[common.KeyWord]string{},
+		options:    0,
+		maxRows:    common.MaxQueryRows,
+	}
+}
+
+func (query *GenQuery) findSelect(column common.ICATColumnNumber) int {
+	for idx, sel := range query.selects {
+		if sel.column == column {
+			return idx
+		}
+	}
+	return -1
+}
+
+// Select adds columns to select
+func (query *GenQuery) Select(columns ...common.ICATColumnNumber) *GenQuery {
+	for _, column := range columns {
+		if query.findSelect(column) < 0 {
+			query.selects = append(query.selects, genQuerySelect{
+				column: column,
+				option: int(common.GENQUERY_SELECT_DEFAULT),
+			})
+		}
+	}
+	return query
+}
+
+// SelectAggregate adds a column to select with an aggregate function, e.g., common.GENQUERY_SELECT_COUNT
+func (query *GenQuery) SelectAggregate(column common.ICATColumnNumber, aggregate common.GenQuerySelectOption) *GenQuery {
+	idx := query.findSelect(column)
+	if idx < 0 {
+		query.selects = append(query.selects, genQuerySelect{
+			column: column,
+			option: int(aggregate),
+		})
+		return query
+	}
+
+	// keep ordering flags
+	orderFlags := query.selects[idx].option & int(common.GENQUERY_ORDER_BY|common.GENQUERY_ORDER_BY_DESC)
+	query.selects[idx].option = int(aggregate) | orderFlags
+	return query
+}
+
+// OrderBy orders results by the column, the column is selected if not selected yet
+func (query *GenQuery) OrderBy(column common.ICATColumnNumber, descending bool) *GenQuery {
+	query.Select(column)
+	idx := query.findSelect(column)
+
+	option := query.selects[idx].option &^ int(common.GENQUERY_ORDER_BY|common.GENQUERY_ORDER_BY_DESC)
+	if descending {
+		option |= int(common.GENQUERY_ORDER_BY_DESC)
+	} else {
+		option |= int(common.GENQUERY_ORDER_BY)
+	}
+
+	query.selects[idx].option = option
+	return query
+}
+
+// addCondition adds a raw condition, conditions on the same column are combined with &&
+func (query *GenQuery) addCondition(column common.ICATColumnNumber, condition string) *GenQuery {
+	for idx, cond := range query.conditions {
+		if cond.column == column {
+			query.conditions[idx].condition = fmt.Sprintf("%s && %s", cond.condition, condition)
+			return query
+		}
+	}
+
+	query.conditions = append(query.conditions, genQueryCondition{
+		column:    column,
+		condition: condition,
+	})
+	return query
+}
+
+// quoteValue quotes a condition value
+// GenQuery has no escape for single quotes, values having them are rejected rather than changing the condition
+func (query *GenQuery) quoteValue(column common.ICATColumnNumber, value string) (string, bool) {
+	if strings.Contains(value, "'") {
+		if query.err == nil {
+			query.err = errors.Errorf("condition value %q for column %d must not contain single quotes", value, column)
+		}
+		return "", false
+	}
+
+	return "'" + value + "'", true
+}
+
+// Where adds a condition, all conditions are combined with and
+// values having single quotes are not supported, the query fails when executed
+func (query *GenQuery) Where(column common.ICATColumnNumber, operator GenQueryOperator, value string) *GenQuery {
+	quoted, ok := query.quoteValue(column, value)
+	if !ok {
+		return query
+	}
+
+	return query.addCondition(column, fmt.Sprintf("%s %s", operator, quoted))
+}
+
+// WhereIn adds a condition that the column value is one of the given values
+func (query *GenQuery) WhereIn(column common.ICATColumnNumber, values ...string) *GenQuery {
+	quotedValues := []string{}
+	for _, value := range values {
+		quoted, ok := query.quoteValue(column, value)
+		if !ok {
+			return query
+		}
+
+		quotedValues = append(quotedValues, quoted)
+	}
+
+	return query.addCondition(column, fmt.Sprintf("in (%s)", strings.Join(quotedValues, ", ")))
+}
+
+// WhereBetween adds a condition that the column value is between low and high
+func (query *GenQuery) WhereBetween(column common.ICATColumnNumber, low string, high string) *GenQuery {
+	quotedLow, ok := query.quoteValue(column, low)
+	if !ok {
+		return query
+	}
+
+	quotedHigh, ok := query.quoteValue(column, high)
+	if !ok {
+		return query
+	}
+
+	return query.addCondition(column, fmt.Sprintf("between %s %s", quotedLow, quotedHigh))
+}
+
+// WhereTime adds a condition comparing time column (e.g., modify time) with the given time
+func (query *GenQuery) WhereTime(column common.ICATColumnNumber, operator GenQueryOperator, t time.Time) *GenQuery {
+	return query.Where(column, operator, fmt.Sprintf("%011d", t.Unix()))
+}
+
+// NoDistinct makes the query return duplicated rows
+func (query *GenQuery) NoDistinct() *GenQuery {
+	query.options |= int(common.GENQUERY_NO_DISTINCT)
+	return query
+}
+
+// UpperCaseWhere makes conditions case-insensitive, condition values must be in upper case
+func (query *GenQuery) UpperCaseWhere() *GenQuery {
+	query.options |= int(common.GENQUERY_UPPER_CASE_WHERE)
+	return query
+}
+
+// AddOption adds a query option
+func (query *GenQuery) AddOption(option common.GenQueryOption) *GenQuery {
+	query.options |= int(option)
+	return query
+}
+
+// AddKeyVal adds a key-value pair, e.g., common.ZONE_KW for querying other zones
+func (query *GenQuery) AddKeyVal(key common.KeyWord, val string) *GenQuery {
+	query.keyVals[key] = val
+	return query
+}
+
+// Err returns the error of an invalid condition added, nil if the query is valid
+func (query *GenQuery) Err() error {
+	return query.err
+}
+
+// MaxRows sets the max number of rows to receive per request (page size)
+func (query *GenQuery) MaxRows(maxRows int) *GenQuery {
+	if maxRows > 0 {
+		query.maxRows = maxRows
+	}
+	return query
+}
+
+// GetRequest returns a query request message for the continue index
+func (query *GenQuery) GetRequest(continueIndex int) *message.IRODSMessageQueryRequest {
+	request := message.NewIRODSMessageQueryRequest(query.maxRows, continueIndex, 0, query.options)
+	for _, sel := range query.selects {
+		request.AddSelect(sel.column, sel.option)
+	}
+
+	for _, cond := range query.conditions {
+		request.AddCondition(cond.column, cond.condition)
+	}
+
+	for key, val := range query.keyVals {
+		request.AddKeyVal(key, val)
+	}
+
+	return request
+}
+
+// ExecuteGenQuery executes the query and calls rowHandler for each row, pages are requested as needed
+// return ErrStopGenQuery from rowHandler to stop early
+func ExecuteGenQuery(conn *connection.IRODSConnection, query *GenQuery, rowHandler GenQueryRowHandler) error {
+	if query.err != nil {
+		return errors.Wrapf(query.err, "invalid query")
+	}
+
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	if len(query.selects) == 0 {
+		return errors.Errorf("no columns to select")
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForSearch(1)
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	continueIndex := 0
+	for {
+		request := query.GetRequest(continueIndex)
+
+		queryResult := message.IRODSMessageQueryResponse{}
+		err := conn.Request(request, &queryResult, nil, conn.GetLongResponseOperationTimeout())
+		if err != nil {
+			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
+				// empty
+				return nil
+			}
+
+			return errors.Wrapf(err, "failed to receive a query result message")
+		}
+
+		err = queryResult.CheckError()
+		if err != nil {
+			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
+				// empty
+				return nil
+			}
+
+			return errors.Wrapf(err, "received a query error")
+		}
+
+		if queryResult.RowCount == 0 {
+			return nil
+		}
+
+		if queryResult.AttributeCount > len(queryResult.SQLResult) {
+			return errors.Errorf("failed to receive attributes - requires %d, but received %d attributes", queryResult.AttributeCount, len(queryResult.SQLResult))
+		}
+
+		for attr := 0; attr < queryResult.AttributeCount; attr++ {
+			sqlResult := queryResult.SQLResult[attr]
+			if len(sqlResult.Values) != queryResult.RowCount {
+				return errors.Errorf("failed to receive rows - requires %d, but received %d attributes", queryResult.RowCount, len(sqlResult.Values))
+			}
+		}
+
+		for row := 0; row < queryResult.RowCount; row++ {
+			genQueryRow := GenQueryRow{}
+			for attr := 0; attr < queryResult.AttributeCount; attr++ {
+				sqlResult := queryResult.SQLResult[attr]
+				genQueryRow[common.ICATColumnNumber(sqlResult.AttributeIndex)] = sqlResult.Values[row]
+			}
+
+			err = rowHandler(genQueryRow)
+			if err != nil {
+				if queryResult.ContinueIndex != 0 {
+					closeGenQuery(conn, queryResult.ContinueIndex)
+				}
+
+				if errors.Is(err, ErrStopGenQuery) {
+					return nil
+				}
+
+				return err
+			}
+		}
+
+		continueIndex = queryResult.ContinueIndex
+		if continueIndex == 0 {
+			return nil
+		}
+	}
+}
+
+// closeGenQuery releases the server-side statement of the unfinished query
+func closeGenQuery(conn *connection.IRODSConnection, continueIndex int) {
+	request := message.NewIRODSMessageQueryRequest(0, continueIndex, 0, 0)
+	queryResult := message.IRODSMessageQueryResponse{}
+	// ignore errors
+	_ = conn.Request(request, &queryResult, nil, conn.GetOperationTimeout())
+}
+
+// ListGenQuery executes the query and returns all rows
+func ListGenQuery(conn *connection.IRODSConnection, query *GenQuery) ([]GenQueryRow, error) {
+	rows := []GenQueryRow{}
+	err := ExecuteGenQuery(conn, query, func(row GenQueryRow) error {
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// Scan copies values of the row to fields of the struct dest points to
+// fields are mapped with `irods` tag having column name (e.g., `irods:"DATA_NAME"`) or column number
+// supported field types are string, integers, floats, bool and time.Time
+func (row GenQueryRow) Scan(dest interface{}) error {
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Pointer || destValue.IsNil() || destValue.Elem().Kind() != reflect.Struct {
+		return errors.Errorf("destination must be a non-nil pointer to a struct, but %T", dest)
+	}
+
+	structValue := destValue.Elem()
+	structType := structValue.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag, ok := field.Tag.Lookup("irods")
+		if !ok || tag == "-" || !field.IsExported() {
+			continue
+		}
+
+		column, ok := common.GetICATColumnNumber(tag)
+		if !ok {
+			return errors.Errorf("unknown column %q for field %q", tag, field.Name)
+		}
+
+		value, ok := row[column]
+		if !ok {
+			continue
+		}
+
+		err := setGenQueryField(structValue.Field(i), value)
+		if err != nil {
+			return errors.Wrapf(err, "failed to set field %q with value %q", field.Name, value)
+		}
+	}
+
+	return nil
+}
+
+func setGenQueryField(field reflect.Value, value string) error {
+	if field.Type() == reflect.TypeOf(time.Time{}) {
+		trimmed := strings.TrimSpace(value)
+		if len(trimmed) == 0 {
+			field.Set(reflect.ValueOf(time.Time{}))
+			return nil
+		}
+
+		t, err := util.GetIRODSDateTime(trimmed)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if len(value) == 0 {
+			field.SetInt(0)
+			return nil
+		}
+
+		i, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if len(value) == 0 {
+			field.SetUint(0)
+			return nil
+		}
+
+		u, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		if len(value) == 0 {
+			field.SetFloat(0)
+			return nil
+		}
+
+		f, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	default:
+		return errors.Errorf("unsupported field type %s", field.Type())
+	}
+
+	return nil
+}
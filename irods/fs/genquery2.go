@@ -0,0 +1,252 @@
+package fs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	"github.com/cyverse/go-irodsclient/irods/message"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// GenQuery2Operator is a comparison operator for GenQuery2 conditions
+type GenQuery2Operator string
+
+const (
+	// GenQuery2OperatorEqual is for =
+	GenQuery2OperatorEqual GenQuery2Operator = "="
+	// GenQuery2OperatorNotEqual is for !=
+	GenQuery2OperatorNotEqual GenQuery2Operator = "!="
+	// GenQuery2OperatorLessThan is for <
+	GenQuery2OperatorLessThan GenQuery2Operator = "<"
+	// GenQuery2OperatorLessThanOrEqual is for <=
+	GenQuery2OperatorLessThanOrEqual GenQuery2Operator = "<="
+	// GenQuery2OperatorGreaterThan is for >
+	GenQuery2OperatorGreaterThan GenQuery2Operator = ">"
+	// GenQuery2OperatorGreaterThanOrEqual is for >=
+	GenQuery2OperatorGreaterThanOrEqual GenQuery2Operator = ">="
+	// GenQuery2OperatorLike is for like
+	GenQuery2OperatorLike GenQuery2Operator = "like"
+	// GenQuery2OperatorNotLike is for not like
+	GenQuery2OperatorNotLike GenQuery2Operator = "not like"
+)
+
+// GenQuery2 builds a GenQuery2 query string
+// e.g., NewGenQuery2("COLL_NAME", "DATA_NAME").Where("DATA_SIZE", GenQuery2OperatorGreaterThan, "1024").OrderBy("DATA_NAME", false).Limit(10)
+type GenQuery2 struct {
+	selects    []string
+	conditions []string
+	groupBy    []string
+	orderBy    []string
+	offset     int
+	limit      int
+	noDistinct bool
+}
+
+// NewGenQuery2 creates a new GenQuery2 builder selecting the given columns
+// aggregates can be given as columns, e.g., "count(DATA_ID)"
+func NewGenQuery2(columns ...string) *GenQuery2 {
+	return &GenQuery2{
+		selects:    columns,
+		conditions: []string{},
+		groupBy:    []string{},
+		orderBy:    []string{},
+		offset:     -1,
+		limit:      -1,
+		noDistinct: false,
+	}
+}
+
+// Select adds columns to select
+func (query *GenQuery2) Select(columns ...string) *GenQuery2 {
+	query.selects = append(query.selects, columns...)
+	return query
+}
+
+// NoDistinct makes the query return duplicated rows
+func (query *GenQuery2) NoDistinct() *GenQuery2 {
+	query.noDistinct = true
+	return query
+}
+
+// Where adds a condition, all conditions are combined with and
+func (query *GenQuery2) Where(column string, operator GenQuery2Operator, value string) *GenQuery2 {
+	query.conditions = append(query.conditions, fmt.Sprintf("%s %s %s", column, operator, quoteGenQuery2Literal(value)))
+	return query
+}
+
+// WhereIn adds a condition that the column value is one of the given values
+func (query *GenQuery2) WhereIn(column string, values ...string) *GenQuery2 {
+	quoted := []string{}
+	for _, value := range values {
+		quoted = append(quoted, quoteGenQuery2Literal(value))
+	}
+
+	query.conditions = append(query.conditions, fmt.Sprintf("%s in (%s)", column, strings.Join(quoted, ", ")))
+	return query
+}
+
+// WhereBetween adds a condition that the column value is between low and high
+func (query *GenQuery2) WhereBetween(column string, low string, high string) *GenQuery2 {
+	query.conditions = append(query.conditions, fmt.Sprintf("%s between %s %s", column, quoteGenQuery2Literal(low), quoteGenQuery2Literal(high)))
+	return query
+}
+
+// WhereNull adds a condition that the column value is null (or not null)
+func (query *GenQuery2) WhereNull(column string, isNull bool) *GenQuery2 {
+	if isNull {
+		query.conditions = append(query.conditions, fmt.Sprintf("%s is null", column))
+	} else {
+		query.conditions = append(query.conditions, fmt.Sprintf("%s is not null", column))
+	}
+	return query
+}
+
+// GroupBy adds columns to group by
+func (query *GenQuery2) GroupBy(columns ...string) *GenQuery2 {
+	query.groupBy = append(query.groupBy, columns...)
+	return query
+}
+
+// OrderBy adds a column to order by
+func (query *GenQuery2) OrderBy(column string, descending bool) *GenQuery2 {
+	if descending {
+		query.orderBy = append(query.orderBy, fmt.Sprintf("%s desc", column))
+	} else {
+		query.orderBy = append(query.orderBy, column)
+	}
+	return query
+}
+
+// Offset sets the number of rows to skip
+func (query *GenQuery2) Offset(offset int) *GenQuery2 {
+	query.offset = offset
+	return query
+}
+
+// Limit sets the max number of rows to return
+func (query *GenQuery2) Limit(limit int) *GenQuery2 {
+	query.limit = limit
+	return query
+}
+
+// Build returns the query string
+func (query *GenQuery2) Build() string {
+	sb := strings.Builder{}
+	sb.WriteString("select ")
+	if query.noDistinct {
+		sb.WriteString("no distinct ")
+	}
+	sb.WriteString(strings.Join(query.selects, ", "))
+
+	if len(query.conditions) > 0 {
+		sb.WriteString(" where ")
+		sb.WriteString(strings.Join(query.conditions, " and "))
+	}
+
+	if len(query.groupBy) > 0 {
+		sb.WriteString(" group by ")
+		sb.WriteString(strings.Join(query.groupBy, ", "))
+	}
+
+	if len(query.orderBy) > 0 {
+		sb.WriteString(" order by ")
+		sb.WriteString(strings.Join(query.orderBy, ", "))
+	}
+
+	if query.offset >= 0 {
+		sb.WriteString(fmt.Sprintf(" offset %d", query.offset))
+	}
+
+	if query.limit >= 0 {
+		sb.WriteString(fmt.Sprintf(" limit %d", query.limit))
+	}
+
+	return sb.String()
+}
+
+// String returns the query string
+func (query *GenQuery2) String() string {
+	return query.Build()
+}
+
+func quoteGenQuery2Literal(value string) string {
+	escaped := strings.ReplaceAll(value, "\\", "\\\\")
+	escaped = strings.ReplaceAll(escaped, "'", "\\'")
+	return "'" + escaped + "'"
+}
+
+// ExecuteGenQuery2 executes GenQuery2 query and returns rows
+// requires iRODS 4.3.2 or higher, use conn.SupportGenQuery2() to fall back to GenQuery1
+func ExecuteGenQuery2(conn *connection.IRODSConnection, query string) ([][]string, error) {
+	if conn == nil || !conn.IsConnected() {
+		return nil, errors.Errorf("connection is nil or disconnected")
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForSearch(1)
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	response, err := requestGenQuery2(conn, query, false)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := response.GetRows()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse GenQuery2 result")
+	}
+
+	return rows, nil
+}
+
+// GetGenQuery2SQL returns SQL generated for the GenQuery2 query without executing it
+func GetGenQuery2SQL(conn *connection.IRODSConnection, query string) (string, error) {
+	if conn == nil || !conn.IsConnected() {
+		return "", errors.Errorf("connection is nil or disconnected")
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	response, err := requestGenQuery2(conn, query, true)
+	if err != nil {
+		return "", err
+	}
+
+	return response.Output, nil
+}
+
+func requestGenQuery2(conn *connection.IRODSConnection, query string, sqlOnly bool) (*message.IRODSMessageGenQuery2Response, error) {
+	if !conn.SupportGenQuery2() {
+		newErr := types.NewAPINotSupportedError(common.GENQUERY2_AN)
+		return nil, errors.Wrapf(newErr, "GenQuery2 is not supported by the server version %q", conn.GetVersion().ReleaseVersion)
+	}
+
+	account := conn.GetAccount()
+
+	request := message.NewIRODSMessageGenQuery2Request(query, account.ClientZone)
+	request.SetSQLOnly(sqlOnly)
+
+	response := message.IRODSMessageGenQuery2Response{}
+	err := conn.RequestAndCheck(request, &response, nil, conn.GetLongResponseOperationTimeout())
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.SYS_UNMATCHED_API_NUM {
+			// not supported
+			newErr := errors.Join(err, types.NewAPINotSupportedError(common.GENQUERY2_AN))
+			return nil, errors.Wrapf(newErr, "GenQuery2 is not supported by the server")
+		}
+
+		return nil, errors.Wrapf(err, "failed to execute GenQuery2 %q", query)
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,146 @@
+package fs
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	"github.com/cyverse/go-irodsclient/irods/message"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// RegisterDataObject registers a physical file on the resource as a data object, like ireg
+// if asReplica is set, the file is registered as a new replica of the existing data object
+// if registerChecksum is set, checksum is computed and registered, if verifyChecksum is set, checksum is verified against the catalog
+func RegisterDataObject(conn *connection.IRODSConnection, path string, physicalPath string, resource string, asReplica bool, force bool, registerChecksum bool, verifyChecksum bool) error {
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForDataObjectCreate(1)
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	// use default resource when resource param is empty
+	if len(resource) == 0 {
+		account := conn.GetAccount()
+		resource = account.DefaultResource
+	}
+
+	request := message.NewIRODSMessageRegisterPhysicalPathRequest(path, physicalPath, resource)
+
+	if asReplica {
+		request.AddKeyVal(common.REG_REPL_KW, "")
+	}
+
+	if force {
+		request.AddKeyVal(common.FORCE_FLAG_KW, "")
+	}
+
+	if registerChecksum {
+		request.AddKeyVal(common.REG_CHKSUM_KW, "")
+	}
+
+	if verifyChecksum {
+		request.AddKeyVal(common.VERIFY_CHKSUM_KW, "")
+	}
+
+	response := message.IRODSMessageRegisterPhysicalPathResponse{}
+	err := conn.RequestAndCheck(request, &response, nil, conn.GetLongResponseOperationTimeout())
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_COLLECTION {
+			newErr := errors.Join(err, types.NewFileNotFoundError(path))
+			return errors.Wrapf(newErr, "failed to find the collection for path %q", path)
+		} else if types.GetIRODSErrorCode(err) == common.CAT_NAME_EXISTS_AS_DATAOBJ || types.GetIRODSErrorCode(err) == common.OVERWRITE_WITHOUT_FORCE_FLAG {
+			newErr := errors.Join(err, types.NewFileAlreadyExistError(path))
+			return errors.Wrapf(newErr, "failed to register data object for path %q", path)
+		}
+
+		return errors.Wrapf(err, "failed to register physical path %q to data object %q", physicalPath, path)
+	}
+	return nil
+}
+
+// RegisterCollection registers a physical directory on the resource recursively as a collection, like ireg -C
+func RegisterCollection(conn *connection.IRODSConnection, path string, physicalPath string, resource string, force bool, registerChecksum bool) error {
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForCollectionCreate(1)
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	// use default resource when resource param is empty
+	if len(resource) == 0 {
+		account := conn.GetAccount()
+		resource = account.DefaultResource
+	}
+
+	request := message.NewIRODSMessageRegisterPhysicalPathRequest(path, physicalPath, resource)
+	request.AddKeyVal(common.COLLECTION_KW, "")
+
+	if force {
+		request.AddKeyVal(common.FORCE_FLAG_KW, "")
+	}
+
+	if registerChecksum {
+		request.AddKeyVal(common.REG_CHKSUM_KW, "")
+	}
+
+	response := message.IRODSMessageRegisterPhysicalPathResponse{}
+	err := conn.RequestAndCheck(request, &response, nil, conn.GetLongResponseOperationTimeout())
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_COLLECTION {
+			newErr := errors.Join(err, types.NewFileNotFoundError(path))
+			return errors.Wrapf(newErr, "failed to find the collection for path %q", path)
+		} else if types.GetIRODSErrorCode(err) == common.CAT_NAME_EXISTS_AS_COLLECTION {
+			newErr := errors.Join(err, types.NewFileAlreadyExistError(path))
+			return errors.Wrapf(newErr, "failed to register collection for path %q", path)
+		}
+
+		return errors.Wrapf(err, "failed to register physical path %q to collection %q", physicalPath, path)
+	}
+	return nil
+}
+
+// UnregisterDataObject removes a data object from the catalog without deleting its physical file, like irm -U
+func UnregisterDataObject(conn *connection.IRODSConnection, path string) error {
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForDataObjectDelete(1)
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	request := message.NewIRODSMessageUnregisterDataObjectRequest(path)
+	response := message.IRODSMessageUnregisterDataObjectResponse{}
+	err := conn.RequestAndCheck(request, &response, nil, conn.GetOperationTimeout())
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_FILE {
+			newErr := errors.Join(err, types.NewFileNotFoundError(path))
+			return errors.Wrapf(newErr, "failed to find the data object for path %q", path)
+		} else if types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_COLLECTION {
+			newErr := errors.Join(err, types.NewFileNotFoundError(path))
+			return errors.Wrapf(newErr, "failed to find the collection for path %q", path)
+		}
+
+		return errors.Wrapf(err, "failed to unregister data object")
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package fs
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	"github.com/cyverse/go-irodsclient/irods/message"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// ExecuteRule executes a rule and returns output parameters
+// rule is a rule text in the language of the rule engine instance (e.g., native rule language or python)
+// outputLabels lists the labels of output parameters to return, ruleExecOut (stdout/stderr) is returned if empty
+// instanceName selects the rule engine plugin instance, the server picks one if empty
+func ExecuteRule(conn *connection.IRODSConnection, rule string, inputParams []*types.IRODSRuleParameter, outputLabels []string, instanceName string) (*types.IRODSRuleResult, error) {
+	if conn == nil || !conn.IsConnected() {
+		return nil, errors.Errorf("connection is nil or disconnected")
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	outParamDesc := types.RuleExecOutLabel
+	if len(outputLabels) > 0 {
+		labels := []string{}
+		for _, label := range outputLabels {
+			param := types.IRODSRuleParameter{Label: label}
+			labels = append(labels, param.GetLabel())
+		}
+
+		outParamDesc = strings.Join(labels, "%")
+	}
+
+	request, err := message.NewIRODSMessageExecMyRuleRequest(rule, inputParams, outParamDesc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to make a rule execution request")
+	}
+
+	request.SetRuleEngineInstance(instanceName)
+
+	response := message.IRODSMessageExecMyRuleResponse{}
+	err = conn.RequestAndCheck(request, &response, nil, conn.GetLongResponseOperationTimeout())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to execute rule")
+	}
+
+	outputParams, err := response.GetRuleParameters()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get output parameters")
+	}
+
+	return &types.IRODSRuleResult{
+		Parameters: outputParams,
+	}, nil
+}
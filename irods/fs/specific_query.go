@@ -0,0 +1,203 @@
+package fs
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	"github.com/cyverse/go-irodsclient/irods/message"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+const (
+	// maxSpecificQueryArgs is the max number of arguments for a specific query
+	maxSpecificQueryArgs int = 10
+)
+
+// SpecificQueryRowHandler is a handler called for each row of specific query result
+type SpecificQueryRowHandler func(row []string) error
+
+// ExecuteSpecificQuery executes a specific query registered with the alias and returns all rows
+// zoneName can be empty to use the client zone
+func ExecuteSpecificQuery(conn *connection.IRODSConnection, alias string, args []string, zoneName string) ([][]string, error) {
+	rows := [][]string{}
+	err := ExecuteSpecificQueryWithHandler(conn, alias, args, zoneName, func(row []string) error {
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// ExecuteSpecificQueryWithHandler executes a specific query registered with the alias and calls rowHandler for each row
+// pages are requested as needed, return ErrStopGenQuery from rowHandler to stop early
+func ExecuteSpecificQueryWithHandler(conn *connection.IRODSConnection, alias string, args []string, zoneName string, rowHandler SpecificQueryRowHandler) error {
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	if len(args) > maxSpecificQueryArgs {
+		return errors.Errorf("too many arguments for specific query %q - max %d, but given %d", alias, maxSpecificQueryArgs, len(args))
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForSearch(1)
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	if len(zoneName) == 0 {
+		zoneName = conn.GetAccount().ClientZone
+	}
+
+	continueIndex := 0
+	for {
+		query := message.NewIRODSMessageQuerySpecificRequest(alias, args, common.MaxQueryRows, continueIndex, 0, 0)
+		query.AddKeyVal(common.ZONE_KW, zoneName)
+
+		queryResult := message.IRODSMessageQueryResponse{}
+		err := conn.Request(query, &queryResult, nil, conn.GetLongResponseOperationTimeout())
+		if err != nil {
+			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
+				// empty
+				return nil
+			}
+
+			return errors.Wrapf(err, "failed to receive a specific query result message")
+		}
+
+		err = queryResult.CheckError()
+		if err != nil {
+			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
+				// empty
+				return nil
+			}
+
+			return errors.Wrapf(err, "received specific query %q error", alias)
+		}
+
+		if queryResult.RowCount == 0 {
+			return nil
+		}
+
+		if queryResult.AttributeCount > len(queryResult.SQLResult) {
+			return errors.Errorf("failed to receive specific query attributes - requires %d, but received %d attributes", queryResult.AttributeCount, len(queryResult.SQLResult))
+		}
+
+		for attr := 0; attr < queryResult.AttributeCount; attr++ {
+			sqlResult := queryResult.SQLResult[attr]
+			if len(sqlResult.Values) != queryResult.RowCount {
+				return errors.Errorf("failed to receive specific query rows - requires %d, but received %d attributes", queryResult.RowCount, len(sqlResult.Values))
+			}
+		}
+
+		for row := 0; row < queryResult.RowCount; row++ {
+			values := make([]string, queryResult.AttributeCount)
+			for attr := 0; attr < queryResult.AttributeCount; attr++ {
+				values[attr] = queryResult.SQLResult[attr].Values[row]
+			}
+
+			err = rowHandler(values)
+			if err != nil {
+				if queryResult.ContinueIndex != 0 {
+					closeSpecificQuery(conn, alias, queryResult.ContinueIndex)
+				}
+
+				if errors.Is(err, ErrStopGenQuery) {
+					return nil
+				}
+
+				return err
+			}
+		}
+
+		continueIndex = queryResult.ContinueIndex
+		if continueIndex == 0 {
+			return nil
+		}
+	}
+}
+
+// closeSpecificQuery releases the server-side statement of the unfinished specific query
+func closeSpecificQuery(conn *connection.IRODSConnection, alias string, continueIndex int) {
+	query := message.NewIRODSMessageQuerySpecificRequest(alias, nil, 0, continueIndex, 0, 0)
+	queryResult := message.IRODSMessageQueryResponse{}
+	// ignore errors
+	_ = conn.Request(query, &queryResult, nil, conn.GetOperationTimeout())
+}
+
+// ListSpecificQueries returns all registered specific queries
+func ListSpecificQueries(conn *connection.IRODSConnection) ([]*types.IRODSSpecificQuery, error) {
+	// "ls" is a built-in specific query listing alias and sql
+	return listSpecificQueries(conn, "ls", nil)
+}
+
+// ListSpecificQueriesByAlias returns registered specific queries matching the alias pattern (sql like)
+func ListSpecificQueriesByAlias(conn *connection.IRODSConnection, aliasPattern string) ([]*types.IRODSSpecificQuery, error) {
+	// "lsl" is a built-in specific query listing alias and sql with alias like the argument
+	return listSpecificQueries(conn, "lsl", []string{aliasPattern})
+}
+
+func listSpecificQueries(conn *connection.IRODSConnection, builtinAlias string, args []string) ([]*types.IRODSSpecificQuery, error) {
+	rows, err := ExecuteSpecificQuery(conn, builtinAlias, args, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list specific queries")
+	}
+
+	queries := []*types.IRODSSpecificQuery{}
+	for _, row := range rows {
+		if len(row) < 2 {
+			return nil, errors.Errorf("failed to receive specific query attributes - requires 2, but received %d attributes", len(row))
+		}
+
+		queries = append(queries, &types.IRODSSpecificQuery{
+			Alias: row[0],
+			SQL:   row[1],
+		})
+	}
+
+	return queries, nil
+}
+
+// AddSpecificQuery registers a specific query with the alias, requires admin privilege
+func AddSpecificQuery(conn *connection.IRODSConnection, alias string, sql string) error {
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	req := message.NewIRODSMessageAdminRequest("add", "specificQuery", sql, alias)
+
+	err := conn.RequestAndCheck(req, &message.IRODSMessageAdminResponse{}, nil, conn.GetOperationTimeout())
+	if err != nil {
+		return errors.Wrapf(err, "received add specific query error")
+	}
+	return nil
+}
+
+// RemoveSpecificQuery removes the specific query having the alias (or sql), requires admin privilege
+func RemoveSpecificQuery(conn *connection.IRODSConnection, aliasOrSQL string) error {
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	req := message.NewIRODSMessageAdminRequest("rm", "specificQuery", aliasOrSQL)
+
+	err := conn.RequestAndCheck(req, &message.IRODSMessageAdminResponse{}, nil, conn.GetOperationTimeout())
+	if err != nil {
+		return errors.Wrapf(err, "received remove specific query error")
+	}
+	return nil
+}
@@ -16,6 +16,8 @@ import (
 const (
 	DataObjectTransferStatusFilePrefix string = ".grc."
 	DataObjectTransferStatusFileSuffix string = ".trx_status"
+	// DataObjectUploadStatusFileInfix is added to the names of status files for uploads
+	DataObjectUploadStatusFileInfix string = ".upload"
 )
 
 // DataObjectTransferStatusEntry
@@ -32,6 +34,11 @@ type DataObjectTransferStatus struct {
 	Size           int64                                    `json:"size"`
 	Threads        int                                      `json:"threads"`
 	StatusMap      map[int64]*DataObjectTransferStatusEntry `json:"-"`
+
+	// for upload
+	IRODSPath         string `json:"irods_path,omitempty"`
+	ResourceHierarchy string `json:"resource_hierarchy,omitempty"`
+	ModTime           int64  `json:"mod_time,omitempty"`
 }
 
 func (status *DataObjectTransferStatus) Validate(path string, size int64, threads int) bool {
@@ -50,6 +57,24 @@ func (status *DataObjectTransferStatus) Validate(path string, size int64, thread
 	return true
 }
 
+// ValidateUpload checks if the status is for the upload of the local file to the irods path
+func (status *DataObjectTransferStatus) ValidateUpload(localPath string, irodsPath string, size int64, modTime int64, threads int) bool {
+	if !status.Validate(localPath, size, threads) {
+		return false
+	}
+
+	if status.IRODSPath != irodsPath {
+		return false
+	}
+
+	if status.ModTime != modTime {
+		// local file is modified
+		return false
+	}
+
+	return true
+}
+
 // IsDataObjectTransferStatusFile checks if the file is transfer status file
 func IsDataObjectTransferStatusFile(p string) bool {
 	filename := filepath.Base(p)
@@ -68,6 +93,13 @@ func GetDataObjectTransferStatusFilePath(p string) string {
 	return filepath.Join(dir, statusFilename)
 }
 
+// GetDataObjectUploadStatusFilePath returns transfer status file path for upload of the local file
+func GetDataObjectUploadStatusFilePath(localPath string) string {
+	dir, filename := filepath.Split(localPath)
+	statusFilename := fmt.Sprintf("%s%s%s%s", DataObjectTransferStatusFilePrefix, filename, DataObjectUploadStatusFileInfix, DataObjectTransferStatusFileSuffix)
+	return filepath.Join(dir, statusFilename)
+}
+
 // NewDataObjectTransferStatus creates new DataObjectTransferStatus
 func NewDataObjectTransferStatus(path string, size int64, threads int) *DataObjectTransferStatus {
 	return &DataObjectTransferStatus{
@@ -203,6 +235,18 @@ func (status *DataObjectTransferStatusLocal) WriteStatus(entry *DataObjectTransf
 	return err
 }
 
+// WriteStatusMap writes all entries in the status map, used to keep progress of previous attempts after creating the status file
+func (status *DataObjectTransferStatusLocal) WriteStatusMap() error {
+	for _, entry := range status.status.StatusMap {
+		err := status.WriteStatus(entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // GetDataObjectTransferStatusLocal returns DataObjectTransferStatusLocal in local disk
 func GetDataObjectTransferStatusLocal(localPath string) (*DataObjectTransferStatusLocal, error) {
 	statusFilePath := GetDataObjectTransferStatusFilePath(localPath)
@@ -249,3 +293,44 @@ func GetOrNewDataObjectTransferStatusLocal(localPath string, size int64, threads
 
 	return status, nil
 }
+
+// NewDataObjectTransferStatusLocalForUpload creates new DataObjectTransferStatusLocal for upload, the status file is created next to the local file
+func NewDataObjectTransferStatusLocalForUpload(localPath string, irodsPath string, size int64, modTime int64, threads int) *DataObjectTransferStatusLocal {
+	status := NewDataObjectTransferStatus(localPath, size, threads)
+	status.StatusFilePath = GetDataObjectUploadStatusFilePath(localPath)
+	status.IRODSPath = irodsPath
+	status.ModTime = modTime
+
+	return &DataObjectTransferStatusLocal{
+		status:     status,
+		fileHandle: nil,
+	}
+}
+
+// GetOrNewDataObjectTransferStatusLocalForUpload returns DataObjectTransferStatusLocal for upload in local disk
+func GetOrNewDataObjectTransferStatusLocalForUpload(localPath string, irodsPath string, size int64, modTime int64, threads int) (*DataObjectTransferStatusLocal, error) {
+	statusFilePath := GetDataObjectUploadStatusFilePath(localPath)
+
+	data, err := os.ReadFile(statusFilePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// status file not found
+			return NewDataObjectTransferStatusLocalForUpload(localPath, irodsPath, size, modTime, threads), nil
+		}
+
+		return nil, errors.Wrapf(err, "failed to read file %q", statusFilePath)
+	}
+
+	status, err := newDataObjectTransferFromBytes(data)
+	if err != nil || !status.ValidateUpload(localPath, irodsPath, size, modTime, threads) {
+		// cannot reuse, create a new
+		return NewDataObjectTransferStatusLocalForUpload(localPath, irodsPath, size, modTime, threads), nil
+	}
+
+	status.StatusFilePath = statusFilePath
+
+	return &DataObjectTransferStatusLocal{
+		status:     status,
+		fileHandle: nil,
+	}, nil
+}
@@ -0,0 +1,40 @@
+package message
+
+import (
+	"github.com/cyverse/go-irodsclient/irods/common"
+)
+
+// IRODSMessageGSIAuthRequest stores GSI authentication request
+type IRODSMessageGSIAuthRequest struct {
+	// empty structure
+}
+
+// NewIRODSMessageGSIAuthRequest creates a IRODSMessageGSIAuthRequest message
+func NewIRODSMessageGSIAuthRequest() *IRODSMessageGSIAuthRequest {
+	return &IRODSMessageGSIAuthRequest{}
+}
+
+// GetMessage builds a message
+func (msg *IRODSMessageGSIAuthRequest) GetMessage() (*IRODSMessage, error) {
+	msgHeader := IRODSMessageHeader{
+		Type:       RODS_MESSAGE_API_REQ_TYPE,
+		MessageLen: 0,
+		ErrorLen:   0,
+		BsLen:      0,
+		IntInfo:    int32(common.GSI_AUTH_REQUEST_AN),
+	}
+
+	return &IRODSMessage{
+		Header: &msgHeader,
+		Body:   nil,
+	}, nil
+}
+
+// FromMessage returns struct from IRODSMessage
+func (msg *IRODSMessageGSIAuthRequest) FromMessage(msgIn *IRODSMessage) error {
+	return nil
+}
+
+func (msg *IRODSMessageGSIAuthRequest) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForRequest()
+}
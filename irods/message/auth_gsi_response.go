@@ -0,0 +1,66 @@
+package message
+
+import (
+	"encoding/xml"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// IRODSMessageGSIAuthResponse stores GSI authentication response, having the DN of the server
+type IRODSMessageGSIAuthResponse struct {
+	XMLName  xml.Name `xml:"gsiAuthRequestOut_PI"`
+	ServerDN string   `xml:"serverDN"`
+	// stores error return
+	Result int `xml:"-"`
+}
+
+// CheckError returns error if server returned an error
+func (msg *IRODSMessageGSIAuthResponse) CheckError() error {
+	if msg.Result < 0 {
+		return types.NewIRODSError(common.ErrorCode(msg.Result))
+	}
+	return nil
+}
+
+// GetBytes returns byte array
+func (msg *IRODSMessageGSIAuthResponse) GetBytes() ([]byte, error) {
+	xmlBytes, err := xml.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal irods message to xml")
+	}
+	return xmlBytes, nil
+}
+
+// FromBytes returns struct from bytes
+func (msg *IRODSMessageGSIAuthResponse) FromBytes(bytes []byte) error {
+	err := xml.Unmarshal(bytes, msg)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal xml to irods message")
+	}
+	return nil
+}
+
+// FromMessage returns struct from IRODSMessage
+func (msg *IRODSMessageGSIAuthResponse) FromMessage(msgIn *IRODSMessage) error {
+	if msgIn.Body == nil {
+		return errors.Errorf("empty message body")
+	}
+
+	msg.Result = int(msgIn.Body.IntInfo)
+
+	if msgIn.Body.Message != nil {
+		err := msg.FromBytes(msgIn.Body.Message)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get irods message from message body")
+		}
+	}
+
+	return nil
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessageGSIAuthResponse) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForResponse()
+}
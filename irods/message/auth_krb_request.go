@@ -0,0 +1,40 @@
+package message
+
+import (
+	"github.com/cyverse/go-irodsclient/irods/common"
+)
+
+// IRODSMessageKRBAuthRequest stores kerberos authentication request
+type IRODSMessageKRBAuthRequest struct {
+	// empty structure
+}
+
+// NewIRODSMessageKRBAuthRequest creates a IRODSMessageKRBAuthRequest message
+func NewIRODSMessageKRBAuthRequest() *IRODSMessageKRBAuthRequest {
+	return &IRODSMessageKRBAuthRequest{}
+}
+
+// GetMessage builds a message
+func (msg *IRODSMessageKRBAuthRequest) GetMessage() (*IRODSMessage, error) {
+	msgHeader := IRODSMessageHeader{
+		Type:       RODS_MESSAGE_API_REQ_TYPE,
+		MessageLen: 0,
+		ErrorLen:   0,
+		BsLen:      0,
+		IntInfo:    int32(common.KRB_AUTH_REQUEST_AN),
+	}
+
+	return &IRODSMessage{
+		Header: &msgHeader,
+		Body:   nil,
+	}, nil
+}
+
+// FromMessage returns struct from IRODSMessage
+func (msg *IRODSMessageKRBAuthRequest) FromMessage(msgIn *IRODSMessage) error {
+	return nil
+}
+
+func (msg *IRODSMessageKRBAuthRequest) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForRequest()
+}
@@ -0,0 +1,149 @@
+package message
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+)
+
+const (
+	bulkOprMaxNameLen int = 1024 + 64
+	bulkOprNameLen    int = 64
+)
+
+// IRODSMessageBulkDataObjectPutRequest stores bulk data object put request
+// file data are concatenated and sent via bs buffer, split by the server at the offsets of files
+type IRODSMessageBulkDataObjectPutRequest struct {
+	XMLName    xml.Name                  `xml:"BulkOprInp_PI"`
+	Path       string                    `xml:"objPath"`
+	Flags      int                       `xml:"flags"`
+	Attributes IRODSMessageQueryResponse `xml:"GenQueryOut_PI"`
+	KeyVals    IRODSMessageSSKeyVal      `xml:"KeyValPair_PI"`
+	Data       []byte                    `xml:"-"`
+}
+
+// NewIRODSMessageBulkDataObjectPutRequest creates a IRODSMessageBulkDataObjectPutRequest message
+// collectionPath is a path to the collection where data objects are created
+// if withChecksum is set, checksums of files must be given via AddFile
+func NewIRODSMessageBulkDataObjectPutRequest(collectionPath string, resource string, withChecksum bool) *IRODSMessageBulkDataObjectPutRequest {
+	sqlResults := []IRODSMessageSQLResult{
+		{
+			AttributeIndex: int(common.ICAT_COLUMN_DATA_NAME),
+			ResultLen:      bulkOprMaxNameLen,
+			Values:         []string{},
+		},
+		{
+			AttributeIndex: int(common.ICAT_COLUMN_DATA_MODE),
+			ResultLen:      bulkOprNameLen,
+			Values:         []string{},
+		},
+		{
+			AttributeIndex: int(common.ICAT_COLUMN_BULK_OPR_OFFSET),
+			ResultLen:      bulkOprNameLen,
+			Values:         []string{},
+		},
+	}
+
+	if withChecksum {
+		sqlResults = append(sqlResults, IRODSMessageSQLResult{
+			AttributeIndex: int(common.ICAT_COLUMN_D_DATA_CHECKSUM),
+			ResultLen:      bulkOprNameLen,
+			Values:         []string{},
+		})
+	}
+
+	request := &IRODSMessageBulkDataObjectPutRequest{
+		Path:  collectionPath,
+		Flags: 0,
+		Attributes: IRODSMessageQueryResponse{
+			RowCount:       0,
+			AttributeCount: len(sqlResults),
+			ContinueIndex:  0,
+			TotalRowCount:  0,
+			SQLResult:      sqlResults,
+		},
+		KeyVals: IRODSMessageSSKeyVal{
+			Length: 0,
+		},
+	}
+
+	if len(resource) > 0 {
+		request.KeyVals.Add(string(common.DEST_RESC_NAME_KW), resource)
+	}
+
+	return request
+}
+
+// AddFile adds a file in the buffer, offset is the end offset of the file data in the buffer
+func (msg *IRODSMessageBulkDataObjectPutRequest) AddFile(path string, mode int, offset int64, checksum string) {
+	msg.Attributes.SQLResult[0].Values = append(msg.Attributes.SQLResult[0].Values, path)
+	msg.Attributes.SQLResult[1].Values = append(msg.Attributes.SQLResult[1].Values, fmt.Sprintf("%d", mode))
+	msg.Attributes.SQLResult[2].Values = append(msg.Attributes.SQLResult[2].Values, fmt.Sprintf("%d", offset))
+
+	if len(msg.Attributes.SQLResult) > 3 {
+		msg.Attributes.SQLResult[3].Values = append(msg.Attributes.SQLResult[3].Values, checksum)
+	}
+
+	msg.Attributes.RowCount++
+}
+
+// SetData sets the buffer containing file data
+func (msg *IRODSMessageBulkDataObjectPutRequest) SetData(data []byte) {
+	msg.Data = data
+}
+
+// AddKeyVal adds a key-value pair
+func (msg *IRODSMessageBulkDataObjectPutRequest) AddKeyVal(key common.KeyWord, val string) {
+	msg.KeyVals.Add(string(key), val)
+}
+
+// GetBytes returns byte array
+func (msg *IRODSMessageBulkDataObjectPutRequest) GetBytes() ([]byte, error) {
+	xmlBytes, err := xml.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal irods message to xml")
+	}
+	return xmlBytes, nil
+}
+
+// FromBytes returns struct from bytes
+func (msg *IRODSMessageBulkDataObjectPutRequest) FromBytes(bytes []byte) error {
+	err := xml.Unmarshal(bytes, msg)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal xml to irods message")
+	}
+	return nil
+}
+
+// GetMessage builds a message
+func (msg *IRODSMessageBulkDataObjectPutRequest) GetMessage() (*IRODSMessage, error) {
+	bytes, err := msg.GetBytes()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get bytes from irods message")
+	}
+
+	msgBody := IRODSMessageBody{
+		Type:    RODS_MESSAGE_API_REQ_TYPE,
+		Message: bytes,
+		Error:   nil,
+		Bs:      msg.Data,
+		IntInfo: int32(common.BULK_DATA_OBJ_PUT_AN),
+	}
+
+	msgHeader, err := msgBody.BuildHeader()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build header from irods message")
+	}
+
+	return &IRODSMessage{
+		Header: msgHeader,
+		Body:   &msgBody,
+	}, nil
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessageBulkDataObjectPutRequest) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForRequest()
+}
@@ -2,6 +2,7 @@ package message
 
 import (
 	"encoding/xml"
+	"fmt"
 
 	"github.com/cockroachdb/errors"
 	"github.com/cyverse/go-irodsclient/irods/common"
@@ -36,6 +37,41 @@ func NewIRODSMessageChecksumRequest(path string, resource string) *IRODSMessageC
 	return request
 }
 
+// SetForce sets force flag to recompute checksum even if it already exists
+func (msg *IRODSMessageChecksumRequest) SetForce(force bool) {
+	if force {
+		msg.KeyVals.Add(string(common.FORCE_CHKSUM_KW), "")
+	}
+}
+
+// SetAllReplicas sets to compute (or verify) checksums of all replicas
+func (msg *IRODSMessageChecksumRequest) SetAllReplicas(allReplicas bool) {
+	if allReplicas {
+		msg.KeyVals.Add(string(common.CHKSUM_ALL_KW), "")
+	}
+}
+
+// SetVerify sets to verify checksum against the catalog, like ichksum -K
+func (msg *IRODSMessageChecksumRequest) SetVerify(verify bool) {
+	if verify {
+		msg.KeyVals.Add(string(common.VERIFY_CHKSUM_KW), "")
+	}
+}
+
+// SetReplicaNumber sets the replica to compute (or verify) checksum
+func (msg *IRODSMessageChecksumRequest) SetReplicaNumber(replicaNumber int) {
+	if replicaNumber >= 0 {
+		msg.KeyVals.Add(string(common.REPL_NUM_KW), fmt.Sprintf("%d", replicaNumber))
+	}
+}
+
+// SetAdminFlag sets admin flag to access data objects owned by others
+func (msg *IRODSMessageChecksumRequest) SetAdminFlag(adminFlag bool) {
+	if adminFlag {
+		msg.KeyVals.Add(string(common.ADMIN_KW), "")
+	}
+}
+
 // AddKeyVal adds a key-value pair
 func (msg *IRODSMessageChecksumRequest) AddKeyVal(key common.KeyWord, val string) {
 	msg.KeyVals.Add(string(key), val)
@@ -29,10 +29,6 @@ func (msg *IRODSMessageChecksumResponse) GetBytes() ([]byte, error) {
 
 // CheckError returns error if server returned an error
 func (msg *IRODSMessageChecksumResponse) CheckError() error {
-	if len(msg.Checksum) == 0 {
-		return errors.Errorf("checksum not present in response message")
-	}
-
 	if msg.Result < 0 {
 		return types.NewIRODSError(common.ErrorCode(msg.Result))
 	}
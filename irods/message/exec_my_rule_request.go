@@ -0,0 +1,101 @@
+package message
+
+import (
+	"encoding/xml"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// IRODSMessageExecMyRuleRequest stores rule execution request
+type IRODSMessageExecMyRuleRequest struct {
+	// str myRule[META_STR_LEN]; struct RHostAddr_PI; struct KeyValPair_PI; str outParamDesc[LONG_NAME_LEN]; struct *MsParamArray_PI;
+	XMLName      xml.Name                  `xml:"ExecMyRuleInp_PI"`
+	MyRule       string                    `xml:"myRule"`
+	Host         IRODSMessageHost          `xml:"RHostAddr_PI"`
+	KeyVals      IRODSMessageSSKeyVal      `xml:"KeyValPair_PI"`
+	OutParamDesc string                    `xml:"outParamDesc"`
+	InputParams  *IRODSMessageMsParamArray `xml:"MsParamArray_PI"`
+}
+
+// NewIRODSMessageExecMyRuleRequest creates a IRODSMessageExecMyRuleRequest message
+func NewIRODSMessageExecMyRuleRequest(rule string, inputParams []*types.IRODSRuleParameter, outParamDesc string) (*IRODSMessageExecMyRuleRequest, error) {
+	msParamArray, err := NewIRODSMessageMsParamArray(inputParams)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create input parameters")
+	}
+
+	request := &IRODSMessageExecMyRuleRequest{
+		MyRule:       rule,
+		Host:         IRODSMessageHost{},
+		OutParamDesc: outParamDesc,
+		InputParams:  msParamArray,
+		KeyVals: IRODSMessageSSKeyVal{
+			Length: 0,
+		},
+	}
+
+	return request, nil
+}
+
+// AddKeyVal adds a key-value pair
+func (msg *IRODSMessageExecMyRuleRequest) AddKeyVal(key common.KeyWord, val string) {
+	msg.KeyVals.Add(string(key), val)
+}
+
+// SetRuleEngineInstance sets rule engine plugin instance to run the rule
+func (msg *IRODSMessageExecMyRuleRequest) SetRuleEngineInstance(instanceName string) {
+	if len(instanceName) > 0 {
+		msg.AddKeyVal(common.INSTANCE_NAME_KW, instanceName)
+	}
+}
+
+// GetBytes returns byte array
+func (msg *IRODSMessageExecMyRuleRequest) GetBytes() ([]byte, error) {
+	xmlBytes, err := xml.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal irods message to xml")
+	}
+	return xmlBytes, nil
+}
+
+// FromBytes returns struct from bytes
+func (msg *IRODSMessageExecMyRuleRequest) FromBytes(bytes []byte) error {
+	err := xml.Unmarshal(bytes, msg)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal xml to irods message")
+	}
+	return nil
+}
+
+// GetMessage builds a message
+func (msg *IRODSMessageExecMyRuleRequest) GetMessage() (*IRODSMessage, error) {
+	bytes, err := msg.GetBytes()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get bytes from irods message")
+	}
+
+	msgBody := IRODSMessageBody{
+		Type:    RODS_MESSAGE_API_REQ_TYPE,
+		Message: bytes,
+		Error:   nil,
+		Bs:      nil,
+		IntInfo: int32(common.EXEC_MY_RULE_AN),
+	}
+
+	msgHeader, err := msgBody.BuildHeader()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build header from irods message")
+	}
+
+	return &IRODSMessage{
+		Header: msgHeader,
+		Body:   &msgBody,
+	}, nil
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessageExecMyRuleRequest) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForRequest()
+}
@@ -0,0 +1,86 @@
+package message
+
+import (
+	"encoding/xml"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+)
+
+// IRODSMessageGenQuery2Request stores GenQuery2 request
+type IRODSMessageGenQuery2Request struct {
+	// str *query_string; str *zone; int sql_only; int column_mappings;
+	XMLName        xml.Name `xml:"Genquery2Input_PI"`
+	QueryString    string   `xml:"query_string"`
+	Zone           string   `xml:"zone"`
+	SQLOnly        int      `xml:"sql_only"`        // 1 to return generated SQL without executing it
+	ColumnMappings int      `xml:"column_mappings"` // 1 to return column mappings
+}
+
+// NewIRODSMessageGenQuery2Request creates a IRODSMessageGenQuery2Request message
+func NewIRODSMessageGenQuery2Request(query string, zone string) *IRODSMessageGenQuery2Request {
+	return &IRODSMessageGenQuery2Request{
+		QueryString:    query,
+		Zone:           zone,
+		SQLOnly:        0,
+		ColumnMappings: 0,
+	}
+}
+
+// SetSQLOnly sets the request to return generated SQL only
+func (msg *IRODSMessageGenQuery2Request) SetSQLOnly(sqlOnly bool) {
+	if sqlOnly {
+		msg.SQLOnly = 1
+	} else {
+		msg.SQLOnly = 0
+	}
+}
+
+// GetBytes returns byte array
+func (msg *IRODSMessageGenQuery2Request) GetBytes() ([]byte, error) {
+	xmlBytes, err := xml.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal irods message to xml")
+	}
+	return xmlBytes, nil
+}
+
+// FromBytes returns struct from bytes
+func (msg *IRODSMessageGenQuery2Request) FromBytes(bytes []byte) error {
+	err := xml.Unmarshal(bytes, msg)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal xml to irods message")
+	}
+	return nil
+}
+
+// GetMessage builds a message
+func (msg *IRODSMessageGenQuery2Request) GetMessage() (*IRODSMessage, error) {
+	bytes, err := msg.GetBytes()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get bytes from irods message")
+	}
+
+	msgBody := IRODSMessageBody{
+		Type:    RODS_MESSAGE_API_REQ_TYPE,
+		Message: bytes,
+		Error:   nil,
+		Bs:      nil,
+		IntInfo: int32(common.GENQUERY2_AN),
+	}
+
+	msgHeader, err := msgBody.BuildHeader()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build header from irods message")
+	}
+
+	return &IRODSMessage{
+		Header: msgHeader,
+		Body:   &msgBody,
+	}, nil
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessageGenQuery2Request) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForRequest()
+}
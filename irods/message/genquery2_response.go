@@ -0,0 +1,81 @@
+package message
+
+import (
+	"encoding/json"
+	"encoding/xml"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// IRODSMessageGenQuery2Response stores GenQuery2 response
+type IRODSMessageGenQuery2Response struct {
+	Output string `xml:"myStr"` // JSON string
+	// stores error return
+	Result int `xml:"-"`
+}
+
+// GetBytes returns byte array
+func (msg *IRODSMessageGenQuery2Response) GetBytes() ([]byte, error) {
+	xmlBytes, err := xml.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal irods message to xml")
+	}
+	return xmlBytes, nil
+}
+
+// CheckError returns error if server returned an error
+func (msg *IRODSMessageGenQuery2Response) CheckError() error {
+	if msg.Result < 0 {
+		return types.NewIRODSError(common.ErrorCode(msg.Result))
+	}
+
+	return nil
+}
+
+// GetRows returns rows parsed from the JSON output
+func (msg *IRODSMessageGenQuery2Response) GetRows() ([][]string, error) {
+	rows := [][]string{}
+	if len(msg.Output) == 0 {
+		return rows, nil
+	}
+
+	err := json.Unmarshal([]byte(msg.Output), &rows)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal json to rows")
+	}
+	return rows, nil
+}
+
+// FromBytes returns struct from bytes
+func (msg *IRODSMessageGenQuery2Response) FromBytes(bytes []byte) error {
+	err := xml.Unmarshal(bytes, msg)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal xml to irods message")
+	}
+	return nil
+}
+
+// FromMessage returns struct from IRODSMessage
+func (msg *IRODSMessageGenQuery2Response) FromMessage(msgIn *IRODSMessage) error {
+	if msgIn.Body == nil {
+		return errors.Errorf("empty message body")
+	}
+
+	msg.Result = int(msgIn.Body.IntInfo)
+
+	if msgIn.Body.Message != nil {
+		err := msg.FromBytes(msgIn.Body.Message)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get irods message from message body")
+		}
+	}
+
+	return nil
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessageGenQuery2Response) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForResponse()
+}
@@ -0,0 +1,338 @@
+package message
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// IRODSMessageMsParamString stores STR_PI
+type IRODSMessageMsParamString struct {
+	XMLName xml.Name `xml:"STR_PI"`
+	MyStr   string   `xml:"myStr"`
+}
+
+// IRODSMessageMsParamInt stores INT_PI
+type IRODSMessageMsParamInt struct {
+	XMLName xml.Name `xml:"INT_PI"`
+	MyInt   int      `xml:"myInt"`
+}
+
+// IRODSMessageMsParamDouble stores DOUBLE_PI, iRODS stores rodsLong in it
+type IRODSMessageMsParamDouble struct {
+	XMLName  xml.Name `xml:"DOUBLE_PI"`
+	MyDouble int64    `xml:"myDouble"`
+}
+
+// IRODSMessageMsParamBufLen stores BUF_LEN_PI
+type IRODSMessageMsParamBufLen struct {
+	XMLName xml.Name `xml:"BUF_LEN_PI"`
+	MyInt   int      `xml:"myInt"`
+}
+
+// IRODSMessageMsParamExecCmdOut stores ExecCmdOut_PI
+type IRODSMessageMsParamExecCmdOut struct {
+	XMLName xml.Name                  `xml:"ExecCmdOut_PI"`
+	Buffers []IRODSMessageBinBytesBuf `xml:"BinBytesBuf_PI"` // stdout, stderr
+	Status  int                       `xml:"status"`
+}
+
+// IRODSMessageMsParamRaw stores msParam of unknown type
+type IRODSMessageMsParamRaw struct {
+	XMLName xml.Name
+	Value   string `xml:",innerxml"`
+}
+
+// IRODSMessageMsParam stores a rule parameter
+type IRODSMessageMsParam struct {
+	// str *label; piStr *type; ?type *inOutStruct; struct *BinBytesBuf_PI;
+	Label       string
+	Type        string
+	InOutStruct interface{}
+	BinBytesBuf *IRODSMessageBinBytesBuf
+}
+
+// IRODSMessageMsParamArray stores rule parameters
+type IRODSMessageMsParamArray struct {
+	// int paramLen; int oprType; struct *MsParam_PI(paramLen);
+	XMLName xml.Name              `xml:"MsParamArray_PI"`
+	Length  int                   `xml:"paramLen"`
+	OprType int                   `xml:"oprType"`
+	Params  []IRODSMessageMsParam `xml:"MsParam_PI"`
+}
+
+// NewIRODSMessageMsParam creates a IRODSMessageMsParam from rule parameter
+func NewIRODSMessageMsParam(param *types.IRODSRuleParameter) (*IRODSMessageMsParam, error) {
+	msParam := &IRODSMessageMsParam{
+		Label: param.GetLabel(),
+		Type:  string(param.Type),
+	}
+
+	switch param.Type {
+	case types.RuleParameterTypeString:
+		val, ok := param.Value.(string)
+		if !ok {
+			return nil, errors.Errorf("rule parameter %q must have string value, but %T", param.Label, param.Value)
+		}
+		msParam.InOutStruct = &IRODSMessageMsParamString{MyStr: val}
+	case types.RuleParameterTypeInt:
+		val, ok := param.Value.(int)
+		if !ok {
+			return nil, errors.Errorf("rule parameter %q must have int value, but %T", param.Label, param.Value)
+		}
+		msParam.InOutStruct = &IRODSMessageMsParamInt{MyInt: val}
+	case types.RuleParameterTypeDouble:
+		val, ok := param.Value.(int64)
+		if !ok {
+			return nil, errors.Errorf("rule parameter %q must have int64 value, but %T", param.Label, param.Value)
+		}
+		msParam.InOutStruct = &IRODSMessageMsParamDouble{MyDouble: val}
+	case types.RuleParameterTypeBuffer:
+		val, ok := param.Value.([]byte)
+		if !ok {
+			return nil, errors.Errorf("rule parameter %q must have []byte value, but %T", param.Label, param.Value)
+		}
+		msParam.InOutStruct = &IRODSMessageMsParamBufLen{MyInt: len(val)}
+		msParam.BinBytesBuf = &IRODSMessageBinBytesBuf{
+			Length: len(val),
+			Data:   base64.StdEncoding.EncodeToString(val),
+		}
+	case types.RuleParameterTypeKeyValPair:
+		val, ok := param.Value.(map[string]string)
+		if !ok {
+			return nil, errors.Errorf("rule parameter %q must have map[string]string value, but %T", param.Label, param.Value)
+		}
+		keyVals := NewIRODSMessageSSKeyVal()
+		for k, v := range val {
+			keyVals.Add(k, v)
+		}
+		msParam.InOutStruct = keyVals
+	default:
+		return nil, errors.Errorf("unsupported rule parameter type %q for %q", param.Type, param.Label)
+	}
+
+	return msParam, nil
+}
+
+// GetRuleParameter returns rule parameter
+func (msg *IRODSMessageMsParam) GetRuleParameter() (*types.IRODSRuleParameter, error) {
+	param := &types.IRODSRuleParameter{
+		Label: msg.Label,
+		Type:  types.RuleParameterType(msg.Type),
+	}
+
+	switch v := msg.InOutStruct.(type) {
+	case *IRODSMessageMsParamString:
+		param.Value = v.MyStr
+	case *IRODSMessageMsParamInt:
+		param.Value = v.MyInt
+	case *IRODSMessageMsParamDouble:
+		param.Value = v.MyDouble
+	case *IRODSMessageMsParamBufLen:
+		data := []byte{}
+		if msg.BinBytesBuf != nil {
+			decoded, err := decodeBinBytesBuf(msg.BinBytesBuf)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to decode buffer of rule parameter %q", msg.Label)
+			}
+			data = decoded
+		}
+		param.Value = data
+	case *IRODSMessageSSKeyVal:
+		kv := map[string]string{}
+		for idx, key := range v.Keys {
+			if idx < len(v.Values) {
+				kv[key] = v.Values[idx].Value
+			}
+		}
+		param.Value = kv
+	case *IRODSMessageMsParamExecCmdOut:
+		execOut := &types.IRODSRuleExecOut{
+			Status: v.Status,
+		}
+
+		for idx, buf := range v.Buffers {
+			decoded, err := decodeBinBytesBuf(&buf)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to decode stdout/stderr of rule parameter %q", msg.Label)
+			}
+
+			// remove trailing null chars
+			decoded = bytes.TrimRight(decoded, "\x00")
+
+			switch idx {
+			case 0:
+				execOut.Stdout = string(decoded)
+			case 1:
+				execOut.Stderr = string(decoded)
+			}
+		}
+		param.Value = execOut
+	case *IRODSMessageMsParamRaw:
+		param.Value = v.Value
+	case nil:
+		param.Value = nil
+	default:
+		return nil, errors.Errorf("unknown rule parameter struct %T", msg.InOutStruct)
+	}
+
+	return param, nil
+}
+
+func decodeBinBytesBuf(buf *IRODSMessageBinBytesBuf) ([]byte, error) {
+	if buf.Length <= 0 || len(buf.Data) == 0 {
+		return []byte{}, nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(buf.Data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode base64 data")
+	}
+
+	if len(decoded) > buf.Length {
+		decoded = decoded[:buf.Length]
+	}
+
+	return decoded, nil
+}
+
+// MarshalXML marshals the msParam, the element name of inOutStruct is determined by its type
+func (msg IRODSMessageMsParam) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: "MsParam_PI"}
+	start.Attr = nil
+
+	err := e.EncodeToken(start)
+	if err != nil {
+		return err
+	}
+
+	err = e.EncodeElement(msg.Label, xml.StartElement{Name: xml.Name{Local: "label"}})
+	if err != nil {
+		return err
+	}
+
+	err = e.EncodeElement(msg.Type, xml.StartElement{Name: xml.Name{Local: "type"}})
+	if err != nil {
+		return err
+	}
+
+	if msg.InOutStruct != nil && len(msg.Type) > 0 {
+		err = e.EncodeElement(msg.InOutStruct, xml.StartElement{Name: xml.Name{Local: msg.Type}})
+		if err != nil {
+			return err
+		}
+	}
+
+	if msg.BinBytesBuf != nil {
+		err = e.EncodeElement(msg.BinBytesBuf, xml.StartElement{Name: xml.Name{Local: "BinBytesBuf_PI"}})
+		if err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// UnmarshalXML unmarshals the msParam, inOutStruct is decoded according to its element name
+func (msg *IRODSMessageMsParam) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for {
+		token, err := d.Token()
+		if err != nil {
+			if err == io.EOF {
+				return errors.Errorf("unexpected end of MsParam_PI")
+			}
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "label":
+				err = d.DecodeElement(&msg.Label, &t)
+			case "type":
+				err = d.DecodeElement(&msg.Type, &t)
+			case "BinBytesBuf_PI":
+				buf := &IRODSMessageBinBytesBuf{}
+				err = d.DecodeElement(buf, &t)
+				msg.BinBytesBuf = buf
+			default:
+				err = msg.decodeInOutStruct(d, &t)
+			}
+
+			if err != nil {
+				return err
+			}
+		case xml.EndElement:
+			if t.Name.Local == start.Name.Local {
+				return nil
+			}
+		}
+	}
+}
+
+func (msg *IRODSMessageMsParam) decodeInOutStruct(d *xml.Decoder, start *xml.StartElement) error {
+	var inOutStruct interface{}
+
+	switch types.RuleParameterType(start.Name.Local) {
+	case types.RuleParameterTypeString:
+		inOutStruct = &IRODSMessageMsParamString{}
+	case types.RuleParameterTypeInt:
+		inOutStruct = &IRODSMessageMsParamInt{}
+	case types.RuleParameterTypeDouble:
+		inOutStruct = &IRODSMessageMsParamDouble{}
+	case types.RuleParameterTypeBuffer:
+		inOutStruct = &IRODSMessageMsParamBufLen{}
+	case types.RuleParameterTypeKeyValPair:
+		inOutStruct = &IRODSMessageSSKeyVal{}
+	case types.RuleParameterTypeExecCmdOut:
+		inOutStruct = &IRODSMessageMsParamExecCmdOut{}
+	default:
+		inOutStruct = &IRODSMessageMsParamRaw{}
+	}
+
+	err := d.DecodeElement(inOutStruct, start)
+	if err != nil {
+		return err
+	}
+
+	msg.InOutStruct = inOutStruct
+	return nil
+}
+
+// NewIRODSMessageMsParamArray creates a IRODSMessageMsParamArray from rule parameters
+func NewIRODSMessageMsParamArray(params []*types.IRODSRuleParameter) (*IRODSMessageMsParamArray, error) {
+	msParams := []IRODSMessageMsParam{}
+	for _, param := range params {
+		msParam, err := NewIRODSMessageMsParam(param)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create msParam for %q", param.Label)
+		}
+
+		msParams = append(msParams, *msParam)
+	}
+
+	return &IRODSMessageMsParamArray{
+		Length:  len(msParams),
+		OprType: 0,
+		Params:  msParams,
+	}, nil
+}
+
+// GetRuleParameters returns rule parameters
+func (msg *IRODSMessageMsParamArray) GetRuleParameters() ([]*types.IRODSRuleParameter, error) {
+	params := []*types.IRODSRuleParameter{}
+	for _, msParam := range msg.Params {
+		param, err := msParam.GetRuleParameter()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get rule parameter %q", msParam.Label)
+		}
+
+		params = append(params, param)
+	}
+
+	return params, nil
+}
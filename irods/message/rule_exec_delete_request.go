@@ -0,0 +1,72 @@
+package message
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+)
+
+// IRODSMessageRuleExecDeleteRequest stores delay rule delete request
+type IRODSMessageRuleExecDeleteRequest struct {
+	// str ruleExecId[NAME_LEN];
+	XMLName xml.Name `xml:"RULE_EXEC_DEL_INP_PI"`
+	RuleID  string   `xml:"ruleExecId"`
+}
+
+// NewIRODSMessageRuleExecDeleteRequest creates a IRODSMessageRuleExecDeleteRequest message
+func NewIRODSMessageRuleExecDeleteRequest(ruleID int64) *IRODSMessageRuleExecDeleteRequest {
+	return &IRODSMessageRuleExecDeleteRequest{
+		RuleID: fmt.Sprintf("%d", ruleID),
+	}
+}
+
+// GetBytes returns byte array
+func (msg *IRODSMessageRuleExecDeleteRequest) GetBytes() ([]byte, error) {
+	xmlBytes, err := xml.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal irods message to xml")
+	}
+	return xmlBytes, nil
+}
+
+// FromBytes returns struct from bytes
+func (msg *IRODSMessageRuleExecDeleteRequest) FromBytes(bytes []byte) error {
+	err := xml.Unmarshal(bytes, msg)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal xml to irods message")
+	}
+	return nil
+}
+
+// GetMessage builds a message
+func (msg *IRODSMessageRuleExecDeleteRequest) GetMessage() (*IRODSMessage, error) {
+	bytes, err := msg.GetBytes()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get bytes from irods message")
+	}
+
+	msgBody := IRODSMessageBody{
+		Type:    RODS_MESSAGE_API_REQ_TYPE,
+		Message: bytes,
+		Error:   nil,
+		Bs:      nil,
+		IntInfo: int32(common.RULE_EXEC_DEL_AN),
+	}
+
+	msgHeader, err := msgBody.BuildHeader()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build header from irods message")
+	}
+
+	return &IRODSMessage{
+		Header: msgHeader,
+		Body:   &msgBody,
+	}, nil
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessageRuleExecDeleteRequest) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForRequest()
+}
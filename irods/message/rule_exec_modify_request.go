@@ -0,0 +1,81 @@
+package message
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+)
+
+// IRODSMessageRuleExecModifyRequest stores delay rule modify request
+type IRODSMessageRuleExecModifyRequest struct {
+	// str ruleId[NAME_LEN]; struct KeyValPair_PI;
+	XMLName xml.Name             `xml:"RULE_EXEC_MOD_INP_PI"`
+	RuleID  string               `xml:"ruleId"`
+	KeyVals IRODSMessageSSKeyVal `xml:"KeyValPair_PI"`
+}
+
+// NewIRODSMessageRuleExecModifyRequest creates a IRODSMessageRuleExecModifyRequest message
+func NewIRODSMessageRuleExecModifyRequest(ruleID int64) *IRODSMessageRuleExecModifyRequest {
+	return &IRODSMessageRuleExecModifyRequest{
+		RuleID: fmt.Sprintf("%d", ruleID),
+		KeyVals: IRODSMessageSSKeyVal{
+			Length: 0,
+		},
+	}
+}
+
+// AddKeyVal adds a key-value pair
+func (msg *IRODSMessageRuleExecModifyRequest) AddKeyVal(key common.KeyWord, val string) {
+	msg.KeyVals.Add(string(key), val)
+}
+
+// GetBytes returns byte array
+func (msg *IRODSMessageRuleExecModifyRequest) GetBytes() ([]byte, error) {
+	xmlBytes, err := xml.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal irods message to xml")
+	}
+	return xmlBytes, nil
+}
+
+// FromBytes returns struct from bytes
+func (msg *IRODSMessageRuleExecModifyRequest) FromBytes(bytes []byte) error {
+	err := xml.Unmarshal(bytes, msg)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal xml to irods message")
+	}
+	return nil
+}
+
+// GetMessage builds a message
+func (msg *IRODSMessageRuleExecModifyRequest) GetMessage() (*IRODSMessage, error) {
+	bytes, err := msg.GetBytes()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get bytes from irods message")
+	}
+
+	msgBody := IRODSMessageBody{
+		Type:    RODS_MESSAGE_API_REQ_TYPE,
+		Message: bytes,
+		Error:   nil,
+		Bs:      nil,
+		IntInfo: int32(common.RULE_EXEC_MOD_AN),
+	}
+
+	msgHeader, err := msgBody.BuildHeader()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build header from irods message")
+	}
+
+	return &IRODSMessage{
+		Header: msgHeader,
+		Body:   &msgBody,
+	}, nil
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessageRuleExecModifyRequest) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForRequest()
+}
@@ -2,6 +2,7 @@ package session
 
 import (
 	"container/list"
+	"context"
 	"sync"
 	"time"
 
@@ -241,7 +242,7 @@ func (pool *ConnectionPool) init() error {
 			return errors.Wrapf(err, "failed to connect to irods server")
 		}
 
-		err = newConn.Connect()
+		newConn, err = pool.connect(context.Background(), newConn)
 		if err != nil {
 			if pool.config.Metrics != nil {
 				pool.config.Metrics.IncreaseCounterForConnectionPoolFailures(1)
@@ -264,7 +265,7 @@ func (pool *ConnectionPool) init() error {
 	return nil
 }
 
-func (pool *ConnectionPool) get(new bool, noConnect bool) (*connection.IRODSConnection, bool, error) {
+func (pool *ConnectionPool) get(ctx context.Context, new bool, noConnect bool) (*connection.IRODSConnection, bool, error) {
 	logger := log.WithFields(log.Fields{
 		"new": new,
 	})
@@ -337,7 +338,7 @@ func (pool *ConnectionPool) get(new bool, noConnect bool) (*connection.IRODSConn
 	}
 
 	if !noConnect {
-		err = newConn.Connect()
+		newConn, err = pool.connect(ctx, newConn)
 		if err != nil {
 			if pool.config.Metrics != nil {
 				pool.config.Metrics.IncreaseCounterForConnectionPoolFailures(1)
@@ -370,6 +371,38 @@ func (pool *ConnectionPool) get(new bool, noConnect bool) (*connection.IRODSConn
 	return newConn, true, nil
 }
 
+// connect connects the connection, returns the connection connected
+// for openid with a token source, retries once on a new connection with a fresh token if the token is rejected
+func (pool *ConnectionPool) connect(ctx context.Context, conn *connection.IRODSConnection) (*connection.IRODSConnection, error) {
+	logger := log.WithFields(log.Fields{})
+
+	err := conn.ConnectContext(ctx)
+	if err == nil {
+		return conn, nil
+	}
+
+	if pool.account.AuthenticationScheme != types.AuthSchemeOpenID || pool.account.TokenSource == nil || !types.IsAuthError(err) {
+		return nil, err
+	}
+
+	logger.WithError(err).Debug("failed to authenticate with the cached token, retrying with a fresh token")
+
+	pool.account.InvalidateAuthToken()
+
+	// the failed connection is closed, retry with a new connection
+	newConn, err := connection.NewIRODSConnection(pool.account, pool.config.ToConnectionConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	err = newConn.ConnectContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newConn, nil
+}
+
 // Get gets a new or an idle connection out of the pool
 // the boolean return value indicates if the returned connection is new (True) or existing idle (False)
 func (pool *ConnectionPool) Get(new bool, noConnect bool, wait bool) (*connection.IRODSConnection, bool, error) {
@@ -377,7 +410,7 @@ func (pool *ConnectionPool) Get(new bool, noConnect bool, wait bool) (*connectio
 	defer pool.mutex.Unlock()
 
 	for {
-		conn, newConn, err := pool.get(new, noConnect)
+		conn, newConn, err := pool.get(context.Background(), new, noConnect)
 		if err != nil && types.IsConnectionPoolFullError(err) && wait {
 			// if the pool is full and wait is true, wait for a while
 			pool.waitCond.Wait()
@@ -387,6 +420,40 @@ func (pool *ConnectionPool) Get(new bool, noConnect bool, wait bool) (*connectio
 	}
 }
 
+// GetContext gets a new or an idle connection out of the pool
+// if the pool is full, it waits until a connection is available or the given context is done
+// the boolean return value indicates if the returned connection is new (True) or existing idle (False)
+func (pool *ConnectionPool) GetContext(ctx context.Context, new bool, noConnect bool) (*connection.IRODSConnection, bool, error) {
+	// wake up waiters when the context is done
+	stopFunc := context.AfterFunc(ctx, func() {
+		pool.mutex.Lock()
+		defer pool.mutex.Unlock()
+
+		pool.waitCond.Broadcast()
+	})
+	defer stopFunc()
+
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	for {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, false, errors.Wrapf(ctxErr, "failed to get a connection from the pool")
+		}
+
+		if pool.terminated {
+			return nil, false, errors.Errorf("failed to get a connection from the pool, the pool is released")
+		}
+
+		conn, newConn, err := pool.get(ctx, new, noConnect)
+		if err != nil && types.IsConnectionPoolFullError(err) {
+			pool.waitCond.Wait()
+		} else {
+			return conn, newConn, err
+		}
+	}
+}
+
 // Return returns the connection after use
 func (pool *ConnectionPool) Return(conn *connection.IRODSConnection) error {
 	logger := log.WithFields(log.Fields{})
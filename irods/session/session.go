@@ -1,6 +1,7 @@
 package session
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -279,20 +280,7 @@ func (sess *IRODSSession) acquireConnection(new bool, allowShared bool, noConnec
 		// fall below
 	} else {
 		// put to share
-		if shares, ok := sess.sharedConnections[conn]; ok {
-			shares++
-			sess.sharedConnections[conn] = shares
-		} else {
-			sess.sharedConnections[conn] = 1
-		}
-
-		if !sess.supportParallelUploadSet {
-			if conn.IsConnected() {
-				// check parallel upload
-				sess.supportParallelUpload = conn.SupportParallelUpload()
-				sess.supportParallelUploadSet = true
-			}
-		}
+		sess.registerConnection(conn)
 
 		return conn, nil
 	}
@@ -441,6 +429,162 @@ func (sess *IRODSSession) AcquireConnectionsMulti(number int, allowShared bool)
 	return newConnections, fullErr
 }
 
+// AcquireConnectionContext acquires an idle connection that is not shared with others
+// the given context is bound to the connection until it is returned, so cancelling the context aborts in-flight operations.
+// if the pool is full, it waits until a connection becomes available or the context is done.
+func (sess *IRODSSession) AcquireConnectionContext(ctx context.Context) (*connection.IRODSConnection, error) {
+	sess.mutex.Lock()
+	pendingErr := sess.getPendingError()
+	sess.mutex.Unlock()
+
+	// return last error
+	if pendingErr != nil {
+		return nil, errors.Wrapf(pendingErr, "failed to get a connection from the pool because pending error is found")
+	}
+
+	// do not hold session mutex while waiting as returning connections requires it
+	conn, _, err := sess.connectionPool.GetContext(ctx, false, false)
+	if err != nil {
+		if ctx.Err() == nil && !types.IsConnectionPoolFullError(err) {
+			sess.mutex.Lock()
+			sess.lastConnectionError = err
+			sess.lastConnectionErrorTime = time.Now()
+			sess.mutex.Unlock()
+		}
+		return nil, err
+	}
+
+	sess.mutex.Lock()
+	defer sess.mutex.Unlock()
+
+	sess.registerConnection(conn)
+	conn.SetContext(ctx)
+
+	return conn, nil
+}
+
+// AcquireConnectionsMultiContext acquires multiple idle connections that are not shared with others
+// the given context is bound to the connections until they are returned.
+// it waits for the first connection if the pool is full, and returns fewer connections than requested if it cannot get more.
+func (sess *IRODSSession) AcquireConnectionsMultiContext(ctx context.Context, number int) ([]*connection.IRODSConnection, error) {
+	sess.mutex.Lock()
+	pendingErr := sess.getPendingError()
+	sess.mutex.Unlock()
+
+	// return last error
+	if pendingErr != nil {
+		return nil, errors.Wrapf(pendingErr, "failed to get a connection from the pool because pending error is found")
+	}
+
+	poolFull := false
+	maxConns := sess.connectionPool.GetMaxConnections()
+
+	requestedNum := number
+	if requestedNum > maxConns {
+		requestedNum = maxConns
+		poolFull = true
+	}
+
+	connections := []*connection.IRODSConnection{}
+	for i := 0; i < requestedNum; i++ {
+		var conn *connection.IRODSConnection
+		var err error
+
+		// this does not return connection fully connected
+		if i == 0 {
+			// wait for at least one connection
+			conn, _, err = sess.connectionPool.GetContext(ctx, false, true)
+		} else {
+			conn, _, err = sess.connectionPool.Get(false, true, false)
+		}
+
+		if err != nil {
+			if types.IsConnectionPoolFullError(err) {
+				poolFull = true
+				break
+			}
+
+			for _, acquiredConn := range connections {
+				sess.connectionPool.Discard(acquiredConn)
+			}
+			return nil, err
+		}
+
+		connections = append(connections, conn)
+	}
+
+	newConnections := []*connection.IRODSConnection{}
+	newConnectionsMutex := sync.Mutex{}
+	var connError error
+	wait := sync.WaitGroup{}
+	for _, conn := range connections {
+		if conn.IsConnected() {
+			newConnections = append(newConnections, conn)
+			continue
+		}
+
+		// new connection that needs to connect
+		wait.Add(1)
+
+		go func() {
+			defer wait.Done()
+
+			err := conn.ConnectContext(ctx)
+
+			newConnectionsMutex.Lock()
+			defer newConnectionsMutex.Unlock()
+
+			if err != nil {
+				connError = errors.Wrapf(err, "failed to connect to iRODS server")
+
+				// discard
+				sess.connectionPool.Discard(conn)
+				return
+			}
+
+			newConnections = append(newConnections, conn)
+		}()
+	}
+
+	wait.Wait()
+
+	sess.mutex.Lock()
+	for _, conn := range newConnections {
+		sess.registerConnection(conn)
+		conn.SetContext(ctx)
+	}
+	sess.mutex.Unlock()
+
+	var fullErr error
+	if poolFull {
+		fullErr = types.NewConnectionPoolFullError(number, maxConns)
+	}
+
+	if connError != nil {
+		return newConnections, errors.Join(connError, fullErr)
+	}
+
+	return newConnections, fullErr
+}
+
+// registerConnection registers a connection acquired from the pool to the share list
+func (sess *IRODSSession) registerConnection(conn *connection.IRODSConnection) {
+	if shares, ok := sess.sharedConnections[conn]; ok {
+		shares++
+		sess.sharedConnections[conn] = shares
+	} else {
+		sess.sharedConnections[conn] = 1
+	}
+
+	if !sess.supportParallelUploadSet {
+		if conn.IsConnected() {
+			// check parallel upload
+			sess.supportParallelUpload = conn.SupportParallelUpload()
+			sess.supportParallelUploadSet = true
+		}
+	}
+}
+
 func (sess *IRODSSession) returnConnection(conn *connection.IRODSConnection) error {
 	logger := log.WithFields(log.Fields{})
 
@@ -450,6 +594,9 @@ func (sess *IRODSSession) returnConnection(conn *connection.IRODSConnection) err
 			// no share
 			delete(sess.sharedConnections, conn)
 
+			// unbind context
+			conn.SetContext(nil)
+
 			conn.Lock()
 
 			if conn.IsSocketFailed() {
@@ -502,6 +649,9 @@ func (sess *IRODSSession) ReturnConnection(conn *connection.IRODSConnection) err
 
 // ReturnConnectionsMulti returns multiple idle connections with transaction close
 func (sess *IRODSSession) ReturnConnectionsMulti(conns []*connection.IRODSConnection) error {
+	sess.mutex.Lock()
+	defer sess.mutex.Unlock()
+
 	var firstErr error
 	for _, conn := range conns {
 		err := sess.returnConnection(conn)
@@ -526,6 +676,9 @@ func (sess *IRODSSession) DiscardConnection(conn *connection.IRODSConnection) {
 			// no share
 			delete(sess.sharedConnections, conn)
 
+			// unbind context
+			conn.SetContext(nil)
+
 			sess.connectionPool.Discard(conn)
 			return
 		} else {
@@ -30,9 +30,15 @@ type IRODSAccount struct {
 	Ticket                  string
 	DefaultResource         string
 	DefaultHashScheme       string
+	MatchHashPolicy         MatchHashPolicy
 	PamTTL                  int
 	PAMToken                string
+	AccessToken             string                 // bearer token for openid auth scheme, TokenSource is used instead if set
+	TokenSource             TokenSource            // optional source of bearer tokens for openid auth scheme, refreshes expired tokens
+	PAMInteractiveHandler   *PAMInteractiveHandler // optional prompt handler for pam_interactive auth scheme
 	SSLConfiguration        *IRODSSSLConfig
+	KerberosConfiguration   *IRODSKerberosConfig
+	GSIConfiguration        *IRODSGSIConfig
 }
 
 // CreateIRODSAccount creates IRODSAccount
@@ -52,9 +58,15 @@ func CreateIRODSAccount(host string, port int, username string, zoneName string,
 		Ticket:                  "",
 		DefaultResource:         defaultResource,
 		DefaultHashScheme:       HashSchemeDefault,
+		MatchHashPolicy:         MatchHashPolicyCompatible,
 		PamTTL:                  PamTTLDefault,
 		PAMToken:                "",
+		AccessToken:             "",
+		TokenSource:             nil,
+		PAMInteractiveHandler:   nil,
 		SSLConfiguration:        nil,
+		KerberosConfiguration:   nil,
+		GSIConfiguration:        nil,
 	}
 
 	account.FixAuthConfiguration()
@@ -79,9 +91,15 @@ func CreateIRODSAccountForTicket(host string, port int, username string, zoneNam
 		Ticket:                  ticket,
 		DefaultResource:         defaultResource,
 		DefaultHashScheme:       HashSchemeDefault,
+		MatchHashPolicy:         MatchHashPolicyCompatible,
 		PamTTL:                  PamTTLDefault,
 		PAMToken:                "",
+		AccessToken:             "",
+		TokenSource:             nil,
+		PAMInteractiveHandler:   nil,
 		SSLConfiguration:        nil,
+		KerberosConfiguration:   nil,
+		GSIConfiguration:        nil,
 	}
 
 	account.FixAuthConfiguration()
@@ -107,9 +125,15 @@ func CreateIRODSProxyAccount(host string, port int, clientUsername string, clien
 		Ticket:                  "",
 		DefaultResource:         defaultResource,
 		DefaultHashScheme:       HashSchemeDefault,
+		MatchHashPolicy:         MatchHashPolicyCompatible,
 		PamTTL:                  PamTTLDefault,
 		PAMToken:                "",
+		AccessToken:             "",
+		TokenSource:             nil,
+		PAMInteractiveHandler:   nil,
 		SSLConfiguration:        nil,
+		KerberosConfiguration:   nil,
+		GSIConfiguration:        nil,
 	}
 
 	account.FixAuthConfiguration()
@@ -122,6 +146,93 @@ func (account *IRODSAccount) SetSSLConfiguration(sslConf *IRODSSSLConfig) {
 	account.SSLConfiguration = sslConf
 }
 
+// SetKerberosConfiguration sets Kerberos Configuration
+func (account *IRODSAccount) SetKerberosConfiguration(kerberosConf *IRODSKerberosConfig) {
+	account.KerberosConfiguration = kerberosConf
+}
+
+// SetGSIConfiguration sets GSI Configuration
+func (account *IRODSAccount) SetGSIConfiguration(gsiConf *IRODSGSIConfig) {
+	account.GSIConfiguration = gsiConf
+}
+
+// SetTokenSource sets TokenSource for openid auth scheme
+// tokens returned are cached until they expire, a new token is obtained from the source for new connections afterwards
+func (account *IRODSAccount) SetTokenSource(source TokenSource) {
+	if source == nil {
+		account.TokenSource = nil
+		return
+	}
+
+	if _, ok := source.(*ReuseTokenSource); ok {
+		account.TokenSource = source
+		return
+	}
+
+	account.TokenSource = NewReuseTokenSource(source, TokenExpiryMarginDefault)
+}
+
+// GetAuthToken returns a bearer token for openid auth scheme, from TokenSource or AccessToken
+func (account *IRODSAccount) GetAuthToken() (*AuthToken, error) {
+	if account.TokenSource != nil {
+		token, err := account.TokenSource.Token()
+		if err != nil {
+			newErr := errors.Join(err, NewAuthError(account))
+			return nil, errors.Wrapf(newErr, "failed to get a token from token source")
+		}
+
+		if token == nil || len(token.AccessToken) == 0 {
+			newErr := NewAuthError(account)
+			return nil, errors.Wrapf(newErr, "token source returned an empty token")
+		}
+
+		if token.IsExpired(0) {
+			newErr := NewAuthError(account)
+			return nil, errors.Wrapf(newErr, "token source returned an expired token")
+		}
+
+		return token, nil
+	}
+
+	if len(account.AccessToken) == 0 {
+		newErr := NewAuthError(account)
+		return nil, errors.Wrapf(newErr, "empty access token")
+	}
+
+	return &AuthToken{
+		AccessToken: account.AccessToken,
+	}, nil
+}
+
+// InvalidateAuthToken drops the token cached in TokenSource, so a fresh token is used for new connections
+func (account *IRODSAccount) InvalidateAuthToken() {
+	if reuseTokenSource, ok := account.TokenSource.(*ReuseTokenSource); ok {
+		reuseTokenSource.Invalidate()
+	}
+}
+
+// SetPAMInteractiveHandler sets PAMInteractiveHandler for pam_interactive auth scheme
+func (account *IRODSAccount) SetPAMInteractiveHandler(handler *PAMInteractiveHandler) {
+	account.PAMInteractiveHandler = handler
+}
+
+// GetPAMToken returns PAM token issued by pam_interactive authentication, or the one given
+// the token given is not returned once it is rejected by the server
+func (account *IRODSAccount) GetPAMToken() string {
+	if account.PAMInteractiveHandler != nil {
+		pamToken := account.PAMInteractiveHandler.GetPAMToken()
+		if len(pamToken) > 0 {
+			return pamToken
+		}
+
+		if account.PAMInteractiveHandler.IsPAMTokenRejected(account.PAMToken) {
+			return ""
+		}
+	}
+
+	return account.PAMToken
+}
+
 // SetCSNegotiation sets CSNegotiation policy
 func (account *IRODSAccount) SetCSNegotiation(requireNegotiation bool, requirePolicy CSNegotiationPolicyRequest) {
 	account.ClientServerNegotiation = requireNegotiation
@@ -192,9 +303,9 @@ func (account *IRODSAccount) Validate() error {
 		return errors.Wrapf(newErr, "unknown authentication scheme")
 	}
 
-	if account.AuthenticationScheme != AuthSchemeNative && account.CSNegotiationPolicy != CSNegotiationPolicyRequestSSL {
+	if account.AuthenticationScheme.IsSSLRequired() && account.CSNegotiationPolicy != CSNegotiationPolicyRequestSSL {
 		newErr := NewConnectionConfigError(account)
-		return errors.Wrapf(newErr, "SSL is required for non-native authentication scheme")
+		return errors.Wrapf(newErr, "SSL is required for %q authentication scheme", account.AuthenticationScheme)
 	}
 
 	if account.CSNegotiationPolicy == CSNegotiationPolicyRequestSSL && !account.ClientServerNegotiation {
@@ -214,6 +325,25 @@ func (account *IRODSAccount) Validate() error {
 		}
 	}
 
+	if account.AuthenticationScheme == AuthSchemeKRB && account.KerberosConfiguration != nil {
+		err = account.KerberosConfiguration.Validate()
+		if err != nil {
+			return errors.Wrapf(err, "failed to validate Kerberos configuration")
+		}
+	}
+
+	if account.AuthenticationScheme == AuthSchemeOpenID && account.TokenSource == nil && len(account.AccessToken) == 0 {
+		newErr := NewConnectionConfigError(account)
+		return errors.Wrapf(newErr, "access token or token source is required for %q authentication scheme", account.AuthenticationScheme)
+	}
+
+	if account.AuthenticationScheme == AuthSchemeGSI && account.GSIConfiguration != nil {
+		err = account.GSIConfiguration.Validate()
+		if err != nil {
+			return errors.Wrapf(err, "failed to validate GSI configuration")
+		}
+	}
+
 	return nil
 }
 
@@ -246,7 +376,7 @@ func (account *IRODSAccount) FixAuthConfiguration() {
 		account.AuthenticationScheme = AuthSchemeNative
 	}
 
-	if account.AuthenticationScheme != AuthSchemeNative {
+	if account.AuthenticationScheme.IsSSLRequired() {
 		account.CSNegotiationPolicy = CSNegotiationPolicyRequestSSL
 	}
 
@@ -254,6 +384,11 @@ func (account *IRODSAccount) FixAuthConfiguration() {
 		account.ClientServerNegotiation = true
 	}
 
+	if account.AuthenticationScheme == AuthSchemePAMInteractive && account.PAMInteractiveHandler == nil {
+		// use stdin, shared by copies of the account to keep PAM token issued
+		account.PAMInteractiveHandler = NewPAMInteractiveHandler(nil, nil)
+	}
+
 	if len(account.ProxyUser) == 0 {
 		account.ProxyUser = account.ClientUser
 	}
@@ -275,6 +410,7 @@ func (account *IRODSAccount) GetRedacted() *IRODSAccount {
 	account2 := *account
 	account2.Password = "<Redacted>"
 	account2.PAMToken = "<Redacted>"
+	account2.AccessToken = "<Redacted>"
 	account2.Ticket = "<Redacted>"
 
 	return &account2
@@ -2,6 +2,7 @@ package types
 
 import (
 	"strings"
+	"sync"
 )
 
 // AuthScheme defines Authentication Scheme
@@ -16,13 +17,59 @@ const (
 	AuthSchemePAM AuthScheme = "pam"
 	// AuthSchemePAMPasswordAuthScheme uses PAM authentication scheme
 	AuthSchemePAMPassword AuthScheme = "pam_password"
+	// AuthSchemePAMInteractive uses PAM interactive authentication scheme, user is prompted for PAM conversation
+	AuthSchemePAMInteractive AuthScheme = "pam_interactive"
+	// AuthSchemeKRB uses Kerberos authentication scheme
+	AuthSchemeKRB AuthScheme = "krb"
+	// AuthSchemeOpenID uses OpenID Connect (OAuth2 bearer token) authentication scheme
+	AuthSchemeOpenID AuthScheme = "openid"
 	// AuthSchemeUnknown is unknown scheme
 	AuthSchemeUnknown AuthScheme = ""
 )
 
-// GetAuthScheme returns AuthScheme value from string
+var (
+	registeredAuthSchemes     = map[string]AuthScheme{}
+	registeredAuthSchemesLock sync.RWMutex
+)
+
+// RegisterAuthScheme registers a custom auth scheme name, GetAuthScheme returns it for the name
+func RegisterAuthScheme(authScheme string) AuthScheme {
+	name := strings.TrimSpace(strings.ToLower(authScheme))
+	if len(name) == 0 {
+		return AuthSchemeUnknown
+	}
+
+	registeredAuthSchemesLock.Lock()
+	defer registeredAuthSchemesLock.Unlock()
+
+	scheme := AuthScheme(name)
+	registeredAuthSchemes[name] = scheme
+	return scheme
+}
+
+// UnregisterAuthScheme unregisters a custom auth scheme name
+func UnregisterAuthScheme(authScheme string) {
+	name := strings.TrimSpace(strings.ToLower(authScheme))
+
+	registeredAuthSchemesLock.Lock()
+	defer registeredAuthSchemesLock.Unlock()
+
+	delete(registeredAuthSchemes, name)
+}
+
+// getRegisteredAuthScheme returns a custom auth scheme registered
+func getRegisteredAuthScheme(name string) (AuthScheme, bool) {
+	registeredAuthSchemesLock.RLock()
+	defer registeredAuthSchemesLock.RUnlock()
+
+	scheme, ok := registeredAuthSchemes[name]
+	return scheme, ok
+}
+
+// GetAuthScheme returns AuthScheme value from string, custom auth schemes registered are also accepted
 func GetAuthScheme(authScheme string) AuthScheme {
-	switch strings.TrimSpace(strings.ToLower(authScheme)) {
+	name := strings.TrimSpace(strings.ToLower(authScheme))
+	switch name {
 	case string(AuthSchemeNative):
 		return AuthSchemeNative
 	case string(AuthSchemeGSI):
@@ -31,14 +78,33 @@ func GetAuthScheme(authScheme string) AuthScheme {
 		return AuthSchemePAM
 	case string(AuthSchemePAMPassword):
 		return AuthSchemePAMPassword
+	case string(AuthSchemePAMInteractive):
+		return AuthSchemePAMInteractive
+	case string(AuthSchemeKRB), "kerberos":
+		return AuthSchemeKRB
+	case string(AuthSchemeOpenID), "oidc":
+		return AuthSchemeOpenID
 	case string(AuthSchemeUnknown):
-		fallthrough
+		return AuthSchemeUnknown
 	default:
+		if scheme, ok := getRegisteredAuthScheme(name); ok {
+			return scheme
+		}
 		return AuthSchemeUnknown
 	}
 }
 
-// IsPAM checks if the auth scheme is pam or pam_password
+// IsPAM checks if the auth scheme is pam, pam_password or pam_interactive
 func (authScheme AuthScheme) IsPAM() bool {
-	return authScheme == AuthSchemePAM || authScheme == AuthSchemePAMPassword
+	return authScheme == AuthSchemePAM || authScheme == AuthSchemePAMPassword || authScheme == AuthSchemePAMInteractive
+}
+
+// IsSSLRequired checks if the auth scheme requires SSL, native, krb and gsi do not send password in plain text
+func (authScheme AuthScheme) IsSSLRequired() bool {
+	return authScheme != AuthSchemeNative && authScheme != AuthSchemeKRB && authScheme != AuthSchemeGSI
+}
+
+// UsePassword checks if the auth scheme uses password or pam token, krb, gsi and openid use kerberos credentials, proxy certificates or bearer tokens instead
+func (authScheme AuthScheme) UsePassword() bool {
+	return authScheme != AuthSchemeKRB && authScheme != AuthSchemeGSI && authScheme != AuthSchemeOpenID
 }
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// IRODSDelayRule contains irods delay rule (delayed execution) information
+type IRODSDelayRule struct {
+	ID int64 `json:"id"`
+	// Name is rule text
+	Name string `json:"name"`
+	// ReiFilePath is a path to the rule execution info file
+	ReiFilePath string `json:"rei_file_path"`
+	// UserName is the owner's name
+	UserName string `json:"user_name"`
+	// Address is the server address the rule runs on
+	Address string `json:"address"`
+	// ExecutionTime is the time that the rule is scheduled to run
+	ExecutionTime time.Time `json:"execution_time"`
+	// Frequency is a repetition policy, e.g., "1h REPEAT FOR EVER"
+	Frequency string `json:"frequency"`
+	// Priority is a priority of the rule
+	Priority string `json:"priority"`
+	// EstimatedExecutionTime is an estimated execution time
+	EstimatedExecutionTime string `json:"estimated_execution_time"`
+	// NotificationAddress is an address to notify
+	NotificationAddress string `json:"notification_address"`
+	// LastExecutionTime is a status message of the last execution
+	LastExecutionTime string `json:"last_execution_time"`
+	// Status is the execution status, e.g., RE_RUNNING
+	Status string `json:"status"`
+}
+
+// ToString stringifies the object
+func (rule *IRODSDelayRule) ToString() string {
+	return fmt.Sprintf("<IRODSDelayRule %d %s %s %v %s>", rule.ID, rule.UserName, rule.Address, rule.ExecutionTime, rule.Frequency)
+}
@@ -154,6 +154,26 @@ func MakeIRODSChecksumString(algorithm ChecksumAlgorithm, checksum []byte) (stri
 	}
 }
 
+// MatchHashPolicy determines how to verify checksums computed with an algorithm different from the default hash scheme
+type MatchHashPolicy string
+
+const (
+	// MatchHashPolicyCompatible uses the checksum algorithm of the server for verification
+	MatchHashPolicyCompatible MatchHashPolicy = "compatible"
+	// MatchHashPolicyStrict fails verification if the checksum algorithm of the server is different from the default hash scheme
+	MatchHashPolicyStrict MatchHashPolicy = "strict"
+)
+
+// GetMatchHashPolicy returns match hash policy from string, returns compatible for unknown values
+func GetMatchHashPolicy(policy string) MatchHashPolicy {
+	switch strings.TrimSpace(strings.ToLower(policy)) {
+	case string(MatchHashPolicyStrict):
+		return MatchHashPolicyStrict
+	default:
+		return MatchHashPolicyCompatible
+	}
+}
+
 // EncryptionAlgorithm determines encryption algorithm
 type EncryptionAlgorithm string
 
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/hex"
 	"fmt"
 
 	"github.com/cockroachdb/errors"
@@ -404,6 +405,51 @@ func IsFileAlreadyExistError(err error) bool {
 	return errors.As(err, &fileAlreadyExistErr)
 }
 
+// ChecksumMismatchError contains checksum mismatch error information
+type ChecksumMismatchError struct {
+	Path              string
+	ExpectedAlgorithm ChecksumAlgorithm
+	Expected          []byte
+	ActualAlgorithm   ChecksumAlgorithm
+	Actual            []byte
+}
+
+// NewChecksumMismatchError creates an error for checksum mismatch
+func NewChecksumMismatchError(p string, expectedAlgorithm ChecksumAlgorithm, expected []byte, actualAlgorithm ChecksumAlgorithm, actual []byte) error {
+	return &ChecksumMismatchError{
+		Path:              p,
+		ExpectedAlgorithm: expectedAlgorithm,
+		Expected:          expected,
+		ActualAlgorithm:   actualAlgorithm,
+		Actual:            actual,
+	}
+}
+
+// Error returns error message
+func (err *ChecksumMismatchError) Error() string {
+	if err.ExpectedAlgorithm != err.ActualAlgorithm && len(err.ExpectedAlgorithm) > 0 && len(err.ActualAlgorithm) > 0 {
+		return fmt.Sprintf("checksum algorithm mismatch for path %q (%s vs %s)", err.Path, err.ExpectedAlgorithm, err.ActualAlgorithm)
+	}
+	return fmt.Sprintf("checksum mismatch for path %q (%s vs %s)", err.Path, hex.EncodeToString(err.Expected), hex.EncodeToString(err.Actual))
+}
+
+// Is tests type of error
+func (err *ChecksumMismatchError) Is(other error) bool {
+	_, ok := other.(*ChecksumMismatchError)
+	return ok
+}
+
+// ToString stringifies the object
+func (err *ChecksumMismatchError) ToString() string {
+	return fmt.Sprintf("<ChecksumMismatchError %q>", err.Path)
+}
+
+// IsChecksumMismatchError checks if the given error is ChecksumMismatchError
+func IsChecksumMismatchError(err error) bool {
+	var checksumMismatchErr *ChecksumMismatchError
+	return errors.As(err, &checksumMismatchErr)
+}
+
 // TicketNotFoundError contains ticket not found error information
 type TicketNotFoundError struct {
 	Ticket string
@@ -472,6 +518,40 @@ func IsUserNotFoundError(err error) bool {
 	return errors.As(err, &userNotFoundErr)
 }
 
+// DelayRuleNotFoundError contains delay rule not found error information
+type DelayRuleNotFoundError struct {
+	ID string
+}
+
+// NewDelayRuleNotFoundError creates an error for delay rule not found
+func NewDelayRuleNotFoundError(id string) error {
+	return &DelayRuleNotFoundError{
+		ID: id,
+	}
+}
+
+// Error returns error message
+func (err *DelayRuleNotFoundError) Error() string {
+	return fmt.Sprintf("delay rule %s not found", err.ID)
+}
+
+// Is tests type of error
+func (err *DelayRuleNotFoundError) Is(other error) bool {
+	_, ok := other.(*DelayRuleNotFoundError)
+	return ok
+}
+
+// ToString stringifies the object
+func (err *DelayRuleNotFoundError) ToString() string {
+	return fmt.Sprintf("<DelayRuleNotFoundError %s>", err.ID)
+}
+
+// IsDelayRuleNotFoundError checks if the given error is DelayRuleNotFoundError
+func IsDelayRuleNotFoundError(err error) bool {
+	var delayRuleNotFoundErr *DelayRuleNotFoundError
+	return errors.As(err, &delayRuleNotFoundErr)
+}
+
 // APINotSupportedError contains api not supported error information
 type APINotSupportedError struct {
 	APINumber common.APINumber
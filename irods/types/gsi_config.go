@@ -0,0 +1,152 @@
+package types
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// GSICACertificatePathDefault is a default path of trusted CA certificates for GSI
+	GSICACertificatePathDefault string = "/etc/grid-security/certificates"
+)
+
+// IRODSGSIConfig contains GSI (X.509 proxy certificate) authentication configuration
+type IRODSGSIConfig struct {
+	ServerDN          string // optional DN of iRODS server, overrides the one the server returns
+	ProxyFile         string // optional proxy certificate file, X509_USER_PROXY or /tmp/x509up_u<uid> is used if empty
+	CACertificatePath string // optional trusted CA certificate dir, X509_CERT_DIR, ~/.globus/certificates or /etc/grid-security/certificates is used if empty
+}
+
+// GetProxyFile returns proxy certificate file path
+func (config *IRODSGSIConfig) GetProxyFile() string {
+	if len(config.ProxyFile) > 0 {
+		return config.ProxyFile
+	}
+
+	envProxyFile := os.Getenv("X509_USER_PROXY")
+	if len(envProxyFile) > 0 {
+		return envProxyFile
+	}
+
+	return fmt.Sprintf("/tmp/x509up_u%d", os.Getuid())
+}
+
+// GetCACertificatePath returns trusted CA certificate dir path
+func (config *IRODSGSIConfig) GetCACertificatePath() string {
+	if len(config.CACertificatePath) > 0 {
+		return config.CACertificatePath
+	}
+
+	envCertDir := os.Getenv("X509_CERT_DIR")
+	if len(envCertDir) > 0 {
+		return envCertDir
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err == nil {
+		userCertDir := filepath.Join(homeDir, ".globus", "certificates")
+		if st, err := os.Stat(userCertDir); err == nil && st.IsDir() {
+			return userCertDir
+		}
+	}
+
+	return GSICACertificatePathDefault
+}
+
+// LoadProxyCertificate loads proxy certificate, the file has the proxy certificate, its private key and the certificate chain
+func (config *IRODSGSIConfig) LoadProxyCertificate() (*tls.Certificate, error) {
+	proxyFile := config.GetProxyFile()
+
+	proxyBytes, err := os.ReadFile(proxyFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			newErr := NewFileNotFoundError(proxyFile)
+			return nil, errors.Wrapf(newErr, "proxy certificate file %q error", proxyFile)
+		}
+		return nil, errors.Wrapf(err, "failed to read proxy certificate file %q", proxyFile)
+	}
+
+	// all certificates in the file become the chain, in order
+	certificate, err := tls.X509KeyPair(proxyBytes, proxyBytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load proxy certificate file %q", proxyFile)
+	}
+
+	leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse proxy certificate in file %q", proxyFile)
+	}
+
+	if time.Now().After(leaf.NotAfter) {
+		return nil, errors.Errorf("proxy certificate in file %q expired at %s", proxyFile, leaf.NotAfter.Format(time.RFC3339))
+	}
+
+	certificate.Leaf = leaf
+
+	return &certificate, nil
+}
+
+// LoadCACert loads trusted CA certificates in the CA certificate dir
+// files that do not contain certificates, e.g., signing policies, are ignored
+func (config *IRODSGSIConfig) LoadCACert() (*x509.CertPool, error) {
+	logger := log.WithFields(log.Fields{})
+
+	caPath := config.GetCACertificatePath()
+
+	entries, err := os.ReadDir(caPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			newErr := NewFileNotFoundError(caPath)
+			return nil, errors.Wrapf(newErr, "CA certificate path %q error", caPath)
+		}
+		return nil, errors.Wrapf(err, "failed to read CA certificate path %q", caPath)
+	}
+
+	certPool := x509.NewCertPool()
+	loaded := 0
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() && entry.Type()&os.ModeSymlink == 0 {
+			continue
+		}
+
+		certPath := filepath.Join(caPath, entry.Name())
+		certBytes, err := os.ReadFile(certPath)
+		if err != nil {
+			logger.WithError(err).Debugf("failed to read CA certificate file %q, ignoring", certPath)
+			continue
+		}
+
+		if certPool.AppendCertsFromPEM(certBytes) {
+			loaded++
+		}
+	}
+
+	if loaded == 0 {
+		return nil, errors.Errorf("no CA certificates found in CA certificate path %q", caPath)
+	}
+
+	return certPool, nil
+}
+
+// Validate validates GSI configuration
+func (config *IRODSGSIConfig) Validate() error {
+	proxyFile := config.GetProxyFile()
+
+	_, err := os.Stat(proxyFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			newErr := NewFileNotFoundError(proxyFile)
+			return errors.Wrapf(newErr, "proxy certificate file %q error", proxyFile)
+		}
+		return errors.Wrapf(err, "proxy certificate file %q error", proxyFile)
+	}
+
+	return nil
+}
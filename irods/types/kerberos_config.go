@@ -0,0 +1,89 @@
+package types
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// KerberosConfigFileDefault is a default path of kerberos configuration file
+	KerberosConfigFileDefault string = "/etc/krb5.conf"
+)
+
+// IRODSKerberosConfig contains kerberos (krb) authentication configuration
+type IRODSKerberosConfig struct {
+	ServicePrincipal    string // optional service principal of iRODS server, overrides the one the server returns
+	Principal           string // optional client principal (user@REALM) used with keytab, iRODS username is used if empty
+	KeytabFile          string // optional keytab file, credential cache is used if empty
+	CredentialCacheFile string // optional credential cache file, KRB5CCNAME or /tmp/krb5cc_<uid> is used if empty
+	ConfigFile          string // optional kerberos configuration file, KRB5_CONFIG or /etc/krb5.conf is used if empty
+}
+
+// GetConfigFile returns kerberos configuration file path
+func (config *IRODSKerberosConfig) GetConfigFile() string {
+	if len(config.ConfigFile) > 0 {
+		return config.ConfigFile
+	}
+
+	// KRB5_CONFIG may have multiple files separated by colon, use the first one
+	envConfigFile := os.Getenv("KRB5_CONFIG")
+	if len(envConfigFile) > 0 {
+		return strings.Split(envConfigFile, ":")[0]
+	}
+
+	return KerberosConfigFileDefault
+}
+
+// GetCredentialCacheFile returns credential cache file path
+func (config *IRODSKerberosConfig) GetCredentialCacheFile() (string, error) {
+	ccache := config.CredentialCacheFile
+	if len(ccache) == 0 {
+		ccache = os.Getenv("KRB5CCNAME")
+	}
+
+	if len(ccache) == 0 {
+		return fmt.Sprintf("/tmp/krb5cc_%d", os.Getuid()), nil
+	}
+
+	if strings.HasPrefix(ccache, "FILE:") {
+		return strings.TrimPrefix(ccache, "FILE:"), nil
+	}
+
+	if idx := strings.Index(ccache, ":"); idx > 0 && !strings.Contains(ccache[:idx], "/") {
+		// other types, e.g., KEYRING:, KCM:, DIR:
+		return "", errors.Errorf("unsupported credential cache type %q, only file credential cache is supported", ccache[:idx])
+	}
+
+	return ccache, nil
+}
+
+// UseKeytab returns true if keytab is used to login
+func (config *IRODSKerberosConfig) UseKeytab() bool {
+	return len(config.KeytabFile) > 0
+}
+
+// Validate validates kerberos configuration
+func (config *IRODSKerberosConfig) Validate() error {
+	if config.UseKeytab() {
+		_, err := os.Stat(config.KeytabFile)
+		if err != nil {
+			if os.IsNotExist(err) {
+				newErr := NewFileNotFoundError(config.KeytabFile)
+				return errors.Wrapf(newErr, "keytab file %q error", config.KeytabFile)
+			}
+			return errors.Wrapf(err, "keytab file %q error", config.KeytabFile)
+		}
+
+		return nil
+	}
+
+	_, err := config.GetCredentialCacheFile()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
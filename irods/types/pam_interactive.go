@@ -0,0 +1,69 @@
+package types
+
+import (
+	"sync"
+)
+
+// PAMInteractivePromptHandler returns user input for the prompt of pam_interactive authentication
+// sensitive is true for passwords that must not be echoed
+type PAMInteractivePromptHandler func(prompt string, sensitive bool) (string, error)
+
+// PAMInteractiveMessageHandler shows a message of pam_interactive authentication that does not need user input
+type PAMInteractiveMessageHandler func(message string)
+
+// PAMInteractiveHandler handles prompts of pam_interactive authentication, e.g., for GUIs and web flows
+// stdin and stdout are used if handlers are nil
+// it also keeps the PAM token issued, the token is shared by copies of the account, so users are prompted only once
+type PAMInteractiveHandler struct {
+	Prompt  PAMInteractivePromptHandler
+	Message PAMInteractiveMessageHandler
+
+	pamToken         string
+	rejectedPAMToken string
+	mutex            sync.Mutex
+}
+
+// NewPAMInteractiveHandler creates a PAMInteractiveHandler
+func NewPAMInteractiveHandler(prompt PAMInteractivePromptHandler, message PAMInteractiveMessageHandler) *PAMInteractiveHandler {
+	return &PAMInteractiveHandler{
+		Prompt:  prompt,
+		Message: message,
+	}
+}
+
+// GetPAMToken returns the PAM token issued
+func (handler *PAMInteractiveHandler) GetPAMToken() string {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+
+	return handler.pamToken
+}
+
+// SetPAMToken sets the PAM token issued
+func (handler *PAMInteractiveHandler) SetPAMToken(pamToken string) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+
+	handler.pamToken = pamToken
+}
+
+// RejectPAMToken clears the PAM token rejected by the server, so users are prompted again
+// the token is also not used if it is given by the account
+func (handler *PAMInteractiveHandler) RejectPAMToken(pamToken string) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+
+	if handler.pamToken == pamToken {
+		handler.pamToken = ""
+	}
+
+	handler.rejectedPAMToken = pamToken
+}
+
+// IsPAMTokenRejected returns true if the PAM token is rejected by the server
+func (handler *PAMInteractiveHandler) IsPAMTokenRejected(pamToken string) bool {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+
+	return len(pamToken) > 0 && handler.rejectedPAMToken == pamToken
+}
@@ -0,0 +1,184 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// RuleParameterType determines the type of rule parameter (msParam)
+type RuleParameterType string
+
+const (
+	// RuleParameterTypeString is for string
+	RuleParameterTypeString RuleParameterType = "STR_PI"
+	// RuleParameterTypeInt is for 32bit integer
+	RuleParameterTypeInt RuleParameterType = "INT_PI"
+	// RuleParameterTypeDouble is for 64bit integer (rodsLong)
+	RuleParameterTypeDouble RuleParameterType = "DOUBLE_PI"
+	// RuleParameterTypeBuffer is for binary buffer
+	RuleParameterTypeBuffer RuleParameterType = "BUF_LEN_PI"
+	// RuleParameterTypeKeyValPair is for key-value pairs
+	RuleParameterTypeKeyValPair RuleParameterType = "KeyValPair_PI"
+	// RuleParameterTypeExecCmdOut is for rule execution output (stdout/stderr)
+	RuleParameterTypeExecCmdOut RuleParameterType = "ExecCmdOut_PI"
+)
+
+const (
+	// RuleExecOutLabel is a label of the output parameter that carries stdout/stderr of rule execution
+	RuleExecOutLabel string = "ruleExecOut"
+
+	// RuleEngineInstanceNative is an instance name of the native rule engine
+	RuleEngineInstanceNative string = "irods_rule_engine_plugin-irods_rule_language-instance"
+	// RuleEngineInstancePython is an instance name of the python rule engine
+	RuleEngineInstancePython string = "irods_rule_engine_plugin-python-instance"
+)
+
+// IRODSRuleExecOut contains stdout/stderr of rule execution
+type IRODSRuleExecOut struct {
+	Stdout string `json:"stdout"`
+	Stderr string `json:"stderr"`
+	Status int    `json:"status"`
+}
+
+// ToString stringifies the object
+func (out *IRODSRuleExecOut) ToString() string {
+	return fmt.Sprintf("<IRODSRuleExecOut %d %q %q>", out.Status, out.Stdout, out.Stderr)
+}
+
+// IRODSRuleParameter contains a rule input/output parameter
+type IRODSRuleParameter struct {
+	// Label is a name of the parameter, e.g., *A
+	Label string `json:"label"`
+	// Type is a type of the parameter
+	Type RuleParameterType `json:"type"`
+	// Value holds the parameter value
+	// string for STR_PI, int for INT_PI, int64 for DOUBLE_PI, []byte for BUF_LEN_PI,
+	// map[string]string for KeyValPair_PI, *IRODSRuleExecOut for ExecCmdOut_PI,
+	// raw XML string for other types
+	Value interface{} `json:"value"`
+}
+
+// NewIRODSRuleParameter creates a new IRODSRuleParameter, the parameter type is determined from the value type
+func NewIRODSRuleParameter(label string, value interface{}) (*IRODSRuleParameter, error) {
+	param := &IRODSRuleParameter{
+		Label: label,
+	}
+
+	switch v := value.(type) {
+	case string:
+		param.Type = RuleParameterTypeString
+		param.Value = v
+	case int:
+		param.Type = RuleParameterTypeInt
+		param.Value = v
+	case int32:
+		param.Type = RuleParameterTypeInt
+		param.Value = int(v)
+	case int64:
+		param.Type = RuleParameterTypeDouble
+		param.Value = v
+	case []byte:
+		param.Type = RuleParameterTypeBuffer
+		param.Value = v
+	case map[string]string:
+		param.Type = RuleParameterTypeKeyValPair
+		param.Value = v
+	default:
+		return nil, errors.Errorf("unsupported rule parameter value type %T for %q", value, label)
+	}
+
+	return param, nil
+}
+
+// GetLabel returns normalized label, rule parameter labels start with '*' except ruleExecOut
+func (param *IRODSRuleParameter) GetLabel() string {
+	if param.Label == RuleExecOutLabel || strings.HasPrefix(param.Label, "*") {
+		return param.Label
+	}
+
+	return "*" + param.Label
+}
+
+// GetString returns the value in string
+func (param *IRODSRuleParameter) GetString() string {
+	switch v := param.Value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case *IRODSRuleExecOut:
+		return v.Stdout
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// ToString stringifies the object
+func (param *IRODSRuleParameter) ToString() string {
+	return fmt.Sprintf("<IRODSRuleParameter %s %s %v>", param.Label, param.Type, param.Value)
+}
+
+// IRODSRuleResult contains outputs of rule execution
+type IRODSRuleResult struct {
+	Parameters []*IRODSRuleParameter `json:"parameters"`
+}
+
+// GetParameter returns the output parameter for the label, returns nil if not found
+func (result *IRODSRuleResult) GetParameter(label string) *IRODSRuleParameter {
+	for _, param := range result.Parameters {
+		if param.Label == label {
+			return param
+		}
+	}
+
+	// try with normalized label
+	normalized := (&IRODSRuleParameter{Label: label}).GetLabel()
+	for _, param := range result.Parameters {
+		if param.Label == normalized {
+			return param
+		}
+	}
+
+	return nil
+}
+
+// GetExecOut returns stdout/stderr of the rule execution, returns nil if not available
+func (result *IRODSRuleResult) GetExecOut() *IRODSRuleExecOut {
+	param := result.GetParameter(RuleExecOutLabel)
+	if param == nil {
+		return nil
+	}
+
+	if execOut, ok := param.Value.(*IRODSRuleExecOut); ok {
+		return execOut
+	}
+
+	return nil
+}
+
+// GetStdout returns stdout of the rule execution
+func (result *IRODSRuleResult) GetStdout() string {
+	execOut := result.GetExecOut()
+	if execOut == nil {
+		return ""
+	}
+
+	return execOut.Stdout
+}
+
+// GetStderr returns stderr of the rule execution
+func (result *IRODSRuleResult) GetStderr() string {
+	execOut := result.GetExecOut()
+	if execOut == nil {
+		return ""
+	}
+
+	return execOut.Stderr
+}
+
+// ToString stringifies the object
+func (result *IRODSRuleResult) ToString() string {
+	return fmt.Sprintf("<IRODSRuleResult %d parameters>", len(result.Parameters))
+}
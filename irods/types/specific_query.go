@@ -0,0 +1,18 @@
+package types
+
+import (
+	"fmt"
+)
+
+// IRODSSpecificQuery contains irods specific query (SQL alias) information
+type IRODSSpecificQuery struct {
+	// Alias is the name of the specific query
+	Alias string `json:"alias"`
+	// SQL is the SQL statement
+	SQL string `json:"sql"`
+}
+
+// ToString stringifies the object
+func (query *IRODSSpecificQuery) ToString() string {
+	return fmt.Sprintf("<IRODSSpecificQuery %s %s>", query.Alias, query.SQL)
+}
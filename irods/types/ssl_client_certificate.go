@@ -0,0 +1,292 @@
+package types
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/pbkdf2"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"hash"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	oidPBES2  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	oidHMACWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+
+	oidAES128CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+	oidDESEDE3CBC = asn1.ObjectIdentifier{1, 2, 840, 113549, 3, 7}
+)
+
+type pkcs8EncryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// clientCertificateCacheEntry holds a loaded client certificate and mod times of the files it is loaded from
+type clientCertificateCacheEntry struct {
+	certificate      *tls.Certificate
+	keyPassword      string
+	chainFileModTime time.Time
+	keyFileModTime   time.Time
+}
+
+var (
+	clientCertificateCache      = map[string]*clientCertificateCacheEntry{}
+	clientCertificateCacheMutex sync.Mutex
+)
+
+// LoadClientCertificate loads the client certificate chain and the private key for mutual TLS
+// the certificate is cached and reloaded when the files are modified, so rotated certificates are used for new connections
+func (config *IRODSSSLConfig) LoadClientCertificate() (*tls.Certificate, error) {
+	if len(config.CertificateChainFile) == 0 || len(config.CertificateKeyFile) == 0 {
+		return nil, errors.Errorf("client certificate chain file or key file is not set")
+	}
+
+	chainStat, err := os.Stat(config.CertificateChainFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			newErr := errors.Join(err, NewFileNotFoundError(config.CertificateChainFile))
+			return nil, errors.Wrapf(newErr, "Certificate Chain File %q error", config.CertificateChainFile)
+		}
+		return nil, errors.Wrapf(err, "Certificate Chain File %q error", config.CertificateChainFile)
+	}
+
+	keyStat, err := os.Stat(config.CertificateKeyFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			newErr := errors.Join(err, NewFileNotFoundError(config.CertificateKeyFile))
+			return nil, errors.Wrapf(newErr, "Certificate Key File %q error", config.CertificateKeyFile)
+		}
+		return nil, errors.Wrapf(err, "Certificate Key File %q error", config.CertificateKeyFile)
+	}
+
+	cacheKey := config.CertificateChainFile + "\x00" + config.CertificateKeyFile
+
+	clientCertificateCacheMutex.Lock()
+	defer clientCertificateCacheMutex.Unlock()
+
+	if cacheEntry, ok := clientCertificateCache[cacheKey]; ok {
+		if cacheEntry.chainFileModTime.Equal(chainStat.ModTime()) && cacheEntry.keyFileModTime.Equal(keyStat.ModTime()) && cacheEntry.keyPassword == config.CertificateKeyPassword {
+			return cacheEntry.certificate, nil
+		}
+	}
+
+	log.Debugf("loading client certificate from %q and %q", config.CertificateChainFile, config.CertificateKeyFile)
+
+	certificate, err := loadClientCertificate(config.CertificateChainFile, config.CertificateKeyFile, config.CertificateKeyPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCertificateCache[cacheKey] = &clientCertificateCacheEntry{
+		certificate:      certificate,
+		keyPassword:      config.CertificateKeyPassword,
+		chainFileModTime: chainStat.ModTime(),
+		keyFileModTime:   keyStat.ModTime(),
+	}
+
+	return certificate, nil
+}
+
+func loadClientCertificate(chainFile string, keyFile string, keyPassword string) (*tls.Certificate, error) {
+	chainPEM, err := os.ReadFile(chainFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read certificate chain file %q", chainFile)
+	}
+
+	keyPEM, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read certificate key file %q", keyFile)
+	}
+
+	keyPEM, err = decryptPrivateKeyPEM(keyPEM, keyPassword)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decrypt certificate key file %q", keyFile)
+	}
+
+	certificate, err := tls.X509KeyPair(chainPEM, keyPEM)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load client certificate from %q and %q", chainFile, keyFile)
+	}
+
+	return &certificate, nil
+}
+
+// decryptPrivateKeyPEM decrypts an encrypted private key in PEM, supports PKCS#8 (PBES2) and legacy OpenSSL encryption
+// returns the input as is if the key is not encrypted
+func decryptPrivateKeyPEM(keyPEM []byte, password string) ([]byte, error) {
+	rest := keyPEM
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			// no encrypted key found
+			return keyPEM, nil
+		}
+
+		if block.Type == "ENCRYPTED PRIVATE KEY" {
+			if len(password) == 0 {
+				return nil, errors.Errorf("private key is encrypted, but password is not given")
+			}
+
+			der, err := decryptPKCS8PrivateKey(block.Bytes, password)
+			if err != nil {
+				return nil, err
+			}
+
+			return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+		}
+
+		if strings.HasSuffix(block.Type, "PRIVATE KEY") && x509.IsEncryptedPEMBlock(block) { //nolint:staticcheck
+			if len(password) == 0 {
+				return nil, errors.Errorf("private key is encrypted, but password is not given")
+			}
+
+			der, err := x509.DecryptPEMBlock(block, []byte(password)) //nolint:staticcheck
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to decrypt private key")
+			}
+
+			return pem.EncodeToMemory(&pem.Block{Type: block.Type, Bytes: der}), nil
+		}
+	}
+}
+
+func decryptPKCS8PrivateKey(der []byte, password string) ([]byte, error) {
+	keyInfo := pkcs8EncryptedPrivateKeyInfo{}
+	_, err := asn1.Unmarshal(der, &keyInfo)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse encrypted private key")
+	}
+
+	if !keyInfo.Algorithm.Algorithm.Equal(oidPBES2) {
+		return nil, errors.Errorf("unsupported private key encryption algorithm %q", keyInfo.Algorithm.Algorithm.String())
+	}
+
+	params := pbes2Params{}
+	_, err = asn1.Unmarshal(keyInfo.Algorithm.Parameters.FullBytes, &params)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse PBES2 parameters")
+	}
+
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, errors.Errorf("unsupported key derivation function %q", params.KeyDerivationFunc.Algorithm.String())
+	}
+
+	kdfParams := pbkdf2Params{}
+	_, err = asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdfParams)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse PBKDF2 parameters")
+	}
+
+	var prf func() hash.Hash
+	switch {
+	case len(kdfParams.PRF.Algorithm) == 0, kdfParams.PRF.Algorithm.Equal(oidHMACWithSHA1):
+		prf = sha1.New
+	case kdfParams.PRF.Algorithm.Equal(oidHMACWithSHA256):
+		prf = sha256.New
+	case kdfParams.PRF.Algorithm.Equal(oidHMACWithSHA384):
+		prf = sha512.New384
+	case kdfParams.PRF.Algorithm.Equal(oidHMACWithSHA512):
+		prf = sha512.New
+	default:
+		return nil, errors.Errorf("unsupported PBKDF2 PRF %q", kdfParams.PRF.Algorithm.String())
+	}
+
+	var keyLen int
+	var newBlockCipher func(key []byte) (cipher.Block, error)
+	switch {
+	case params.EncryptionScheme.Algorithm.Equal(oidAES128CBC):
+		keyLen = 16
+		newBlockCipher = aes.NewCipher
+	case params.EncryptionScheme.Algorithm.Equal(oidAES192CBC):
+		keyLen = 24
+		newBlockCipher = aes.NewCipher
+	case params.EncryptionScheme.Algorithm.Equal(oidAES256CBC):
+		keyLen = 32
+		newBlockCipher = aes.NewCipher
+	case params.EncryptionScheme.Algorithm.Equal(oidDESEDE3CBC):
+		keyLen = 24
+		newBlockCipher = des.NewTripleDESCipher
+	default:
+		return nil, errors.Errorf("unsupported encryption scheme %q", params.EncryptionScheme.Algorithm.String())
+	}
+
+	var iv []byte
+	_, err = asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse encryption IV")
+	}
+
+	key, err := pbkdf2.Key(prf, password, kdfParams.Salt, kdfParams.IterationCount, keyLen)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to derive encryption key")
+	}
+
+	block, err := newBlockCipher(key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create cipher")
+	}
+
+	if len(iv) != block.BlockSize() || len(keyInfo.EncryptedData) == 0 || len(keyInfo.EncryptedData)%block.BlockSize() != 0 {
+		return nil, errors.Errorf("invalid encrypted private key")
+	}
+
+	decrypted := make([]byte, len(keyInfo.EncryptedData))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(decrypted, keyInfo.EncryptedData)
+
+	// remove PKCS#7 padding
+	padLen := int(decrypted[len(decrypted)-1])
+	if padLen == 0 || padLen > block.BlockSize() || padLen > len(decrypted) {
+		return nil, errors.Errorf("failed to decrypt private key, incorrect password")
+	}
+
+	for _, b := range decrypted[len(decrypted)-padLen:] {
+		if int(b) != padLen {
+			return nil, errors.Errorf("failed to decrypt private key, incorrect password")
+		}
+	}
+
+	decrypted = decrypted[:len(decrypted)-padLen]
+
+	// check if it is a valid key
+	_, err = x509.ParsePKCS8PrivateKey(decrypted)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse decrypted private key, incorrect password")
+	}
+
+	return decrypted, nil
+}
@@ -58,6 +58,14 @@ type IRODSSSLConfig struct {
 	VerifyServer            SSLVerifyServer
 	DHParamsFile            string
 	ServerName              string // optional server name for verification
+	CertificateChainFile    string // optional client certificate chain for mutual TLS
+	CertificateKeyFile      string // optional client private key for mutual TLS
+	CertificateKeyPassword  string // optional password for encrypted client private key
+}
+
+// HasClientCertificate checks if client certificate is configured
+func (config *IRODSSSLConfig) HasClientCertificate() bool {
+	return len(config.CertificateChainFile) > 0 || len(config.CertificateKeyFile) > 0
 }
 
 // LoadCACert loads CA Cert
@@ -124,7 +132,7 @@ func (config *IRODSSSLConfig) GetTLSConfig(serverName string, ignoreCertFileErro
 		serverName = config.ServerName
 	}
 
-	return &tls.Config{
+	tlsConfig := &tls.Config{
 		RootCAs:            caCertPool,
 		ServerName:         serverName,
 		InsecureSkipVerify: !config.VerifyServer.IsVerificationRequired(),
@@ -177,7 +185,23 @@ func (config *IRODSSSLConfig) GetTLSConfig(serverName string, ignoreCertFileErro
 			tls.TLS_ECDHE_RSA_WITH_RC4_128_SHA,
 			tls.TLS_RSA_WITH_RC4_128_SHA,
 		},
-	}, nil
+	}
+
+	if config.HasClientCertificate() {
+		// load the client certificate to report errors early
+		_, err = config.LoadClientCertificate()
+		if err != nil {
+			return nil, err
+		}
+
+		// reload for every handshake to pick up rotated certificates
+		sslConfig := *config
+		tlsConfig.GetClientCertificate = func(_ *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return sslConfig.LoadClientCertificate()
+		}
+	}
+
+	return tlsConfig, nil
 }
 
 func (config *IRODSSSLConfig) Validate() error {
@@ -224,5 +248,17 @@ func (config *IRODSSSLConfig) Validate() error {
 		return errors.Wrapf(newErr, "failed to validate SSL verify server")
 	}
 
+	if config.HasClientCertificate() {
+		if len(config.CertificateChainFile) == 0 {
+			newErr := NewConnectionConfigError(nil)
+			return errors.Wrapf(newErr, "empty client certificate chain file")
+		}
+
+		if len(config.CertificateKeyFile) == 0 {
+			newErr := NewConnectionConfigError(nil)
+			return errors.Wrapf(newErr, "empty client certificate key file")
+		}
+	}
+
 	return nil
 }
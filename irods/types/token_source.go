@@ -0,0 +1,114 @@
+package types
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// TokenExpiryMarginDefault is a default margin to treat a token as expired before it actually expires
+	TokenExpiryMarginDefault time.Duration = 30 * time.Second
+)
+
+// AuthToken is a bearer token, e.g., OAuth2 access token, used with openid auth scheme
+type AuthToken struct {
+	AccessToken string
+	Expiry      time.Time // zero if the token does not expire
+}
+
+// IsExpired checks if the token is expired or expires within the margin
+func (token *AuthToken) IsExpired(margin time.Duration) bool {
+	if token.Expiry.IsZero() {
+		return false
+	}
+
+	return time.Now().Add(margin).After(token.Expiry)
+}
+
+// IsValid checks if the token has an access token and is not expired
+func (token *AuthToken) IsValid() bool {
+	return token != nil && len(token.AccessToken) > 0 && !token.IsExpired(0)
+}
+
+// TokenSource returns a valid token, it refreshes the token if needed
+// golang.org/x/oauth2 TokenSource can be adapted with TokenSourceFunc
+type TokenSource interface {
+	Token() (*AuthToken, error)
+}
+
+// TokenSourceFunc is a func that implements TokenSource
+type TokenSourceFunc func() (*AuthToken, error)
+
+// Token returns a token
+func (f TokenSourceFunc) Token() (*AuthToken, error) {
+	return f()
+}
+
+// staticTokenSource returns the same token always
+type staticTokenSource struct {
+	token *AuthToken
+}
+
+// NewStaticTokenSource creates a TokenSource that returns the same token always
+func NewStaticTokenSource(token *AuthToken) TokenSource {
+	return &staticTokenSource{
+		token: token,
+	}
+}
+
+// Token returns the token
+func (source *staticTokenSource) Token() (*AuthToken, error) {
+	return source.token, nil
+}
+
+// ReuseTokenSource caches a token returned from the source until it expires
+type ReuseTokenSource struct {
+	source TokenSource
+	margin time.Duration
+	token  *AuthToken
+	mutex  sync.Mutex
+}
+
+// NewReuseTokenSource creates a ReuseTokenSource, a token expiring within the margin is refreshed
+func NewReuseTokenSource(source TokenSource, margin time.Duration) *ReuseTokenSource {
+	return &ReuseTokenSource{
+		source: source,
+		margin: margin,
+	}
+}
+
+// Token returns the cached token, or a new token from the source if the cached one expired
+func (source *ReuseTokenSource) Token() (*AuthToken, error) {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+
+	if source.token != nil && len(source.token.AccessToken) > 0 && !source.token.IsExpired(source.margin) {
+		return source.token, nil
+	}
+
+	token, err := source.source.Token()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get a token from token source")
+	}
+
+	if token == nil || len(token.AccessToken) == 0 {
+		return nil, errors.Errorf("token source returned an empty token")
+	}
+
+	if token.IsExpired(0) {
+		return nil, errors.Errorf("token source returned an expired token, expired at %s", token.Expiry.Format(time.RFC3339))
+	}
+
+	source.token = token
+	return token, nil
+}
+
+// Invalidate drops the cached token, a new token is obtained from the source at next call
+func (source *ReuseTokenSource) Invalidate() {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+
+	source.token = nil
+}
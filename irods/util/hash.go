@@ -71,6 +71,24 @@ func HashBuffer(buffer *bytes.Buffer, hashAlg string, processCallback common.Tra
 	}
 }
 
+// GetHashAlgorithm returns a new hash.Hash for the hash algorithm, used to calculate hash of streamed data
+func GetHashAlgorithm(hashAlg string) (hash.Hash, error) {
+	switch strings.ToLower(hashAlg) {
+	case strings.ToLower(string(types.ChecksumAlgorithmMD5)):
+		return md5.New(), nil
+	case strings.ToLower(string(types.ChecksumAlgorithmADLER32)):
+		return adler32.New(), nil
+	case strings.ToLower(string(types.ChecksumAlgorithmSHA1)):
+		return sha1.New(), nil
+	case strings.ToLower(string(types.ChecksumAlgorithmSHA256)):
+		return sha256.New(), nil
+	case strings.ToLower(string(types.ChecksumAlgorithmSHA512)):
+		return sha512.New(), nil
+	default:
+		return nil, errors.Errorf("unknown hash algorithm %q", hashAlg)
+	}
+}
+
 // HashStringsWithAlgorithm calculates hash of strings
 func HashStringsWithAlgorithm(strs []string, hashAlg hash.Hash) ([]byte, error) {
 	for _, str := range strs {
@@ -0,0 +1,341 @@
+package testcases
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cyverse/go-irodsclient/irods/auth"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	gsiTestServerDN string = "/O=Grid/OU=Test/CN=host/localhost"
+	gsiTestUserDN   string = "/O=Grid/OU=Test/CN=Test User"
+)
+
+func getAuthGSITest() Test {
+	return Test{
+		Name: "Auth_GSI",
+		Func: authGSITest,
+	}
+}
+
+func authGSITest(t *testing.T, test *Test) {
+	t.Run("GetX509DN", testGetX509DN)
+	t.Run("EstablishGSIContext", testEstablishGSIContext)
+	t.Run("EstablishGSIContextWrongServerDN", testEstablishGSIContextWrongServerDN)
+	t.Run("EstablishGSIContextUntrustedCA", testEstablishGSIContextUntrustedCA)
+}
+
+type gsiTestCertificate struct {
+	cert    *x509.Certificate
+	certDER []byte
+	key     *ecdsa.PrivateKey
+}
+
+func createGSITestCertificate(t *testing.T, subject pkix.Name, isCA bool, issuer *gsiTestCertificate) *gsiTestCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	FailError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               subject,
+		NotBefore:             time.Now().Add(-1 * time.Hour),
+		NotAfter:              time.Now().Add(1 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+
+	if isCA {
+		template.KeyUsage |= x509.KeyUsageCertSign
+	}
+
+	parent := template
+	signer := key
+	if issuer != nil {
+		parent = issuer.cert
+		signer = issuer.key
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+	FailError(t, err)
+
+	cert, err := x509.ParseCertificate(certDER)
+	FailError(t, err)
+
+	return &gsiTestCertificate{
+		cert:    cert,
+		certDER: certDER,
+		key:     key,
+	}
+}
+
+func writeGSITestCACertificate(t *testing.T, dir string, ca *gsiTestCertificate) string {
+	caDir := filepath.Join(dir, "certificates")
+	err := os.MkdirAll(caDir, 0700)
+	FailError(t, err)
+
+	err = os.WriteFile(filepath.Join(caDir, "testca.0"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.certDER}), 0600)
+	FailError(t, err)
+
+	// files that do not have certificates are ignored
+	err = os.WriteFile(filepath.Join(caDir, "testca.signing_policy"), []byte("access_id_CA X509 '/O=Grid/OU=Test/CN=Test CA'\n"), 0600)
+	FailError(t, err)
+
+	return caDir
+}
+
+func writeGSITestProxyFile(t *testing.T, dir string, ca *gsiTestCertificate) string {
+	user := createGSITestCertificate(t, pkix.Name{Organization: []string{"Grid"}, OrganizationalUnit: []string{"Test"}, CommonName: "Test User"}, false, ca)
+
+	// proxy certificate is signed by the user certificate
+	proxySubject := pkix.Name{}
+	proxySubject.ExtraNames = append(proxySubject.ExtraNames, user.cert.Subject.Names...)
+	proxySubject.ExtraNames = append(proxySubject.ExtraNames, pkix.AttributeTypeAndValue{Type: []int{2, 5, 4, 3}, Value: "1234567"})
+	proxy := createGSITestCertificate(t, proxySubject, false, &gsiTestCertificate{cert: user.cert, key: user.key})
+
+	keyDER, err := x509.MarshalECPrivateKey(proxy.key)
+	FailError(t, err)
+
+	proxyBytes := bytes.Buffer{}
+	proxyBytes.Write(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: proxy.certDER}))
+	proxyBytes.Write(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	proxyBytes.Write(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: user.certDER}))
+
+	proxyFile := filepath.Join(dir, "x509up_test")
+	err = os.WriteFile(proxyFile, proxyBytes.Bytes(), 0600)
+	FailError(t, err)
+
+	return proxyFile
+}
+
+// gsiTestTokenTransport exchanges GSS-API tokens prefixed with length over a net.Conn
+type gsiTestTokenTransport struct {
+	conn net.Conn
+}
+
+func (transport *gsiTestTokenTransport) SendToken(token []byte) error {
+	lengthBuffer := make([]byte, 4)
+	binary.BigEndian.PutUint32(lengthBuffer, uint32(len(token)))
+
+	_, err := transport.conn.Write(append(lengthBuffer, token...))
+	return err
+}
+
+func (transport *gsiTestTokenTransport) RecvToken() ([]byte, error) {
+	lengthBuffer := make([]byte, 4)
+	_, err := io.ReadFull(transport.conn, lengthBuffer)
+	if err != nil {
+		return nil, err
+	}
+
+	token := make([]byte, binary.BigEndian.Uint32(lengthBuffer))
+	_, err = io.ReadFull(transport.conn, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// gsiTestServerConn is a TLS stand-in of GSI server, reads and writes TLS records as GSS-API tokens
+type gsiTestServerConn struct {
+	net.Conn
+	transport  *gsiTestTokenTransport
+	readBuffer bytes.Buffer
+}
+
+func (conn *gsiTestServerConn) Read(b []byte) (int, error) {
+	for conn.readBuffer.Len() == 0 {
+		token, err := conn.transport.RecvToken()
+		if err != nil {
+			return 0, err
+		}
+
+		conn.readBuffer.Write(token)
+	}
+
+	return conn.readBuffer.Read(b)
+}
+
+func (conn *gsiTestServerConn) Write(b []byte) (int, error) {
+	err := conn.transport.SendToken(b)
+	if err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+type gsiTestServerResult struct {
+	clientDN       string
+	delegationFlag string
+	err            error
+}
+
+func runGSITestServer(serverConn net.Conn, server *gsiTestCertificate) chan gsiTestServerResult {
+	resultChan := make(chan gsiTestServerResult, 1)
+
+	go func() {
+		defer func() {
+			_ = serverConn.Close()
+		}()
+
+		tlsConfig := &tls.Config{
+			Certificates: []tls.Certificate{
+				{
+					Certificate: [][]byte{server.certDER},
+					PrivateKey:  server.key,
+				},
+			},
+			// proxy certificates are not verified by the stand-in
+			ClientAuth: tls.RequireAnyClientCert,
+		}
+
+		tlsConn := tls.Server(&gsiTestServerConn{
+			Conn:      serverConn,
+			transport: &gsiTestTokenTransport{conn: serverConn},
+		}, tlsConfig)
+
+		err := tlsConn.Handshake()
+		if err != nil {
+			resultChan <- gsiTestServerResult{err: err}
+			return
+		}
+
+		flag := make([]byte, 1)
+		_, err = io.ReadFull(tlsConn, flag)
+		if err != nil {
+			resultChan <- gsiTestServerResult{err: err}
+			return
+		}
+
+		clientDN := ""
+		peerCerts := tlsConn.ConnectionState().PeerCertificates
+		if len(peerCerts) > 1 {
+			// identity of proxy certificate is the user certificate
+			clientDN, err = auth.GetX509DN(peerCerts[1])
+		}
+
+		resultChan <- gsiTestServerResult{
+			clientDN:       clientDN,
+			delegationFlag: string(flag),
+			err:            err,
+		}
+	}()
+
+	return resultChan
+}
+
+func testGetX509DN(t *testing.T) {
+	ca := createGSITestCertificate(t, pkix.Name{Organization: []string{"Grid"}, OrganizationalUnit: []string{"Test"}, CommonName: "Test CA"}, true, nil)
+	server := createGSITestCertificate(t, pkix.Name{Organization: []string{"Grid"}, OrganizationalUnit: []string{"Test"}, CommonName: "host/localhost"}, false, ca)
+
+	dn, err := auth.GetX509DN(server.cert)
+	FailError(t, err)
+	assert.Equal(t, gsiTestServerDN, dn)
+}
+
+func testEstablishGSIContext(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := createGSITestCertificate(t, pkix.Name{Organization: []string{"Grid"}, OrganizationalUnit: []string{"Test"}, CommonName: "Test CA"}, true, nil)
+	server := createGSITestCertificate(t, pkix.Name{Organization: []string{"Grid"}, OrganizationalUnit: []string{"Test"}, CommonName: "host/localhost"}, false, ca)
+
+	gsiConfig := &types.IRODSGSIConfig{
+		ServerDN:          gsiTestServerDN,
+		ProxyFile:         writeGSITestProxyFile(t, dir, ca),
+		CACertificatePath: writeGSITestCACertificate(t, dir, ca),
+	}
+
+	gsiClient, err := auth.NewGSIClient(gsiConfig)
+	FailError(t, err)
+	assert.Equal(t, gsiTestUserDN+"/CN=1234567", gsiClient.GetClientDN())
+
+	clientConn, serverConn := net.Pipe()
+	defer func() {
+		_ = clientConn.Close()
+	}()
+
+	resultChan := runGSITestServer(serverConn, server)
+
+	err = gsiClient.EstablishContext(&gsiTestTokenTransport{conn: clientConn}, gsiConfig.ServerDN)
+	FailError(t, err)
+	assert.Equal(t, gsiTestServerDN, gsiClient.GetServerDN())
+
+	result := <-resultChan
+	FailError(t, result.err)
+	assert.Equal(t, gsiTestUserDN, result.clientDN)
+	assert.Equal(t, "0", result.delegationFlag)
+}
+
+func testEstablishGSIContextWrongServerDN(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := createGSITestCertificate(t, pkix.Name{Organization: []string{"Grid"}, OrganizationalUnit: []string{"Test"}, CommonName: "Test CA"}, true, nil)
+	server := createGSITestCertificate(t, pkix.Name{Organization: []string{"Grid"}, OrganizationalUnit: []string{"Test"}, CommonName: "host/localhost"}, false, ca)
+
+	gsiConfig := &types.IRODSGSIConfig{
+		ProxyFile:         writeGSITestProxyFile(t, dir, ca),
+		CACertificatePath: writeGSITestCACertificate(t, dir, ca),
+	}
+
+	gsiClient, err := auth.NewGSIClient(gsiConfig)
+	FailError(t, err)
+
+	clientConn, serverConn := net.Pipe()
+
+	resultChan := runGSITestServer(serverConn, server)
+
+	err = gsiClient.EstablishContext(&gsiTestTokenTransport{conn: clientConn}, "/O=Grid/OU=Test/CN=host/other")
+	assert.Error(t, err)
+
+	_ = clientConn.Close()
+
+	result := <-resultChan
+	assert.Error(t, result.err)
+}
+
+func testEstablishGSIContextUntrustedCA(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := createGSITestCertificate(t, pkix.Name{Organization: []string{"Grid"}, OrganizationalUnit: []string{"Test"}, CommonName: "Test CA"}, true, nil)
+	otherCA := createGSITestCertificate(t, pkix.Name{Organization: []string{"Grid"}, OrganizationalUnit: []string{"Test"}, CommonName: "Other CA"}, true, nil)
+	server := createGSITestCertificate(t, pkix.Name{Organization: []string{"Grid"}, OrganizationalUnit: []string{"Test"}, CommonName: "host/localhost"}, false, otherCA)
+
+	gsiConfig := &types.IRODSGSIConfig{
+		ProxyFile:         writeGSITestProxyFile(t, dir, ca),
+		CACertificatePath: writeGSITestCACertificate(t, dir, ca),
+	}
+
+	gsiClient, err := auth.NewGSIClient(gsiConfig)
+	FailError(t, err)
+
+	clientConn, serverConn := net.Pipe()
+
+	resultChan := runGSITestServer(serverConn, server)
+
+	err = gsiClient.EstablishContext(&gsiTestTokenTransport{conn: clientConn}, gsiTestServerDN)
+	assert.Error(t, err)
+
+	_ = clientConn.Close()
+
+	result := <-resultChan
+	assert.Error(t, result.err)
+}
@@ -0,0 +1,351 @@
+package testcases
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"encoding/binary"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cyverse/go-irodsclient/irods/auth"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	krb_asn1tools "github.com/jcmturner/gokrb5/v8/asn1tools"
+	krb_crypto "github.com/jcmturner/gokrb5/v8/crypto"
+	krb_gssapi "github.com/jcmturner/gokrb5/v8/gssapi"
+	krb_asnapptag "github.com/jcmturner/gokrb5/v8/iana/asnAppTag"
+	krb_etypeid "github.com/jcmturner/gokrb5/v8/iana/etypeID"
+	krb_keyusage "github.com/jcmturner/gokrb5/v8/iana/keyusage"
+	krb_msgtype "github.com/jcmturner/gokrb5/v8/iana/msgtype"
+	krb_nametype "github.com/jcmturner/gokrb5/v8/iana/nametype"
+	krb_keytab "github.com/jcmturner/gokrb5/v8/keytab"
+	krb_messages "github.com/jcmturner/gokrb5/v8/messages"
+	krb_service "github.com/jcmturner/gokrb5/v8/service"
+	krb_spnego "github.com/jcmturner/gokrb5/v8/spnego"
+	krb_types "github.com/jcmturner/gokrb5/v8/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	krbTestRealm            string = "EXAMPLE.COM"
+	krbTestClientName       string = "rods"
+	krbTestServiceName      string = "irods/irods.example.com"
+	krbTestServicePrincipal string = krbTestServiceName + "@" + krbTestRealm
+)
+
+func getAuthKRBTest() Test {
+	return Test{
+		Name: "Auth_KRB",
+		Func: authKRBTest,
+	}
+}
+
+func authKRBTest(t *testing.T, test *Test) {
+	t.Run("GSSTokenFraming", testGSSTokenFraming)
+	t.Run("EstablishKRBContext", testEstablishKRBContext)
+	t.Run("EstablishKRBContextWrongAPRep", testEstablishKRBContextWrongAPRep)
+}
+
+// krbTestCredential is a credential stored in a credential cache
+type krbTestCredential struct {
+	server    krb_types.PrincipalName
+	ticket    krb_messages.Ticket
+	key       krb_types.EncryptionKey
+	authTime  time.Time
+	startTime time.Time
+	endTime   time.Time
+}
+
+func writeKRBTestPrincipal(buffer *bytes.Buffer, principal krb_types.PrincipalName) {
+	_ = binary.Write(buffer, binary.BigEndian, principal.NameType)
+	_ = binary.Write(buffer, binary.BigEndian, int32(len(principal.NameString)))
+	writeKRBTestData(buffer, []byte(krbTestRealm))
+	for _, component := range principal.NameString {
+		writeKRBTestData(buffer, []byte(component))
+	}
+}
+
+func writeKRBTestData(buffer *bytes.Buffer, data []byte) {
+	_ = binary.Write(buffer, binary.BigEndian, int32(len(data)))
+	buffer.Write(data)
+}
+
+// writeKRBTestCCache writes a credential cache file (version 4) with the given credentials of the client
+func writeKRBTestCCache(t *testing.T, dir string, client krb_types.PrincipalName, credentials []krbTestCredential) string {
+	buffer := bytes.Buffer{}
+
+	// version 4 without header fields
+	buffer.Write([]byte{0x05, 0x04, 0x00, 0x00})
+	writeKRBTestPrincipal(&buffer, client)
+
+	for _, credential := range credentials {
+		ticketBytes, err := credential.ticket.Marshal()
+		FailError(t, err)
+
+		writeKRBTestPrincipal(&buffer, client)
+		writeKRBTestPrincipal(&buffer, credential.server)
+		_ = binary.Write(&buffer, binary.BigEndian, uint16(credential.key.KeyType))
+		writeKRBTestData(&buffer, credential.key.KeyValue)
+		_ = binary.Write(&buffer, binary.BigEndian, int32(credential.authTime.Unix()))
+		_ = binary.Write(&buffer, binary.BigEndian, int32(credential.startTime.Unix()))
+		_ = binary.Write(&buffer, binary.BigEndian, int32(credential.endTime.Unix()))
+		_ = binary.Write(&buffer, binary.BigEndian, int32(credential.endTime.Unix()))
+		// is_skey, ticket flags, addresses and auth data
+		buffer.WriteByte(0)
+		buffer.Write([]byte{0x00, 0x00, 0x00, 0x00})
+		_ = binary.Write(&buffer, binary.BigEndian, int32(0))
+		_ = binary.Write(&buffer, binary.BigEndian, int32(0))
+		writeKRBTestData(&buffer, ticketBytes)
+		writeKRBTestData(&buffer, []byte{})
+	}
+
+	ccacheFile := filepath.Join(dir, "krb5cc_test")
+	err := os.WriteFile(ccacheFile, buffer.Bytes(), 0600)
+	FailError(t, err)
+
+	return ccacheFile
+}
+
+// createKRBTestEnvironment creates a service keytab and a credential cache having a TGT and a service ticket, so no KDC is required
+func createKRBTestEnvironment(t *testing.T) (*krb_keytab.Keytab, *types.IRODSKerberosConfig) {
+	dir := t.TempDir()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	serviceKeytab := krb_keytab.New()
+	err := serviceKeytab.AddEntry(krbTestServiceName, krbTestRealm, "service_password", now, 1, krb_etypeid.AES256_CTS_HMAC_SHA1_96)
+	FailError(t, err)
+
+	tgsKeytab := krb_keytab.New()
+	err = tgsKeytab.AddEntry("krbtgt/"+krbTestRealm, krbTestRealm, "tgs_password", now, 1, krb_etypeid.AES256_CTS_HMAC_SHA1_96)
+	FailError(t, err)
+
+	clientName := krb_types.NewPrincipalName(krb_nametype.KRB_NT_PRINCIPAL, krbTestClientName)
+	tgsName := krb_types.NewPrincipalName(krb_nametype.KRB_NT_SRV_INST, "krbtgt/"+krbTestRealm)
+	serviceName := krb_types.NewPrincipalName(krb_nametype.KRB_NT_SRV_INST, krbTestServiceName)
+
+	startTime := now.Add(-1 * time.Minute)
+	endTime := now.Add(1 * time.Hour)
+
+	credentials := []krbTestCredential{}
+	for _, server := range []struct {
+		name   krb_types.PrincipalName
+		keytab *krb_keytab.Keytab
+	}{
+		{name: tgsName, keytab: tgsKeytab},
+		{name: serviceName, keytab: serviceKeytab},
+	} {
+		ticket, key, err := krb_messages.NewTicket(clientName, krbTestRealm, server.name, krbTestRealm, krb_types.NewKrbFlags(), server.keytab, krb_etypeid.AES256_CTS_HMAC_SHA1_96, 1, now, startTime, endTime, endTime)
+		FailError(t, err)
+
+		credentials = append(credentials, krbTestCredential{
+			server:    server.name,
+			ticket:    ticket,
+			key:       key,
+			authTime:  now,
+			startTime: startTime,
+			endTime:   endTime,
+		})
+	}
+
+	configFile := filepath.Join(dir, "krb5.conf")
+	err = os.WriteFile(configFile, []byte("[libdefaults]\n  default_realm = "+krbTestRealm+"\n"), 0600)
+	FailError(t, err)
+
+	return serviceKeytab, &types.IRODSKerberosConfig{
+		CredentialCacheFile: writeKRBTestCCache(t, dir, clientName, credentials),
+		ConfigFile:          configFile,
+	}
+}
+
+// krbTestEncAPRepPart is EncAPRepPart without optional fields
+type krbTestEncAPRepPart struct {
+	CTime time.Time `asn1:"generalized,explicit,tag:0"`
+	Cusec int       `asn1:"explicit,tag:1"`
+}
+
+// marshalKRBTestAPRep creates a GSS-API token of AP-REP, gokrb5 does not support marshaling AP-REP tokens
+func marshalKRBTestAPRep(ctime time.Time, cusec int, sessionKey krb_types.EncryptionKey) ([]byte, error) {
+	encPartBytes, err := asn1.Marshal(krbTestEncAPRepPart{
+		CTime: ctime.UTC(),
+		Cusec: cusec,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	encPart, err := krb_crypto.GetEncryptedData(krb_asn1tools.AddASNAppTag(encPartBytes, krb_asnapptag.EncAPRepPart), sessionKey, krb_keyusage.AP_REP_ENCPART, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	apRepBytes, err := asn1.Marshal(krb_messages.APRep{
+		PVNO:    5,
+		MsgType: krb_msgtype.KRB_AP_REP,
+		EncPart: encPart,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tokenBytes, err := asn1.Marshal(asn1.ObjectIdentifier(krb_gssapi.OIDKRB5.OID()))
+	if err != nil {
+		return nil, err
+	}
+
+	tokenBytes = append(tokenBytes, 0x02, 0x00)
+	tokenBytes = append(tokenBytes, krb_asn1tools.AddASNAppTag(apRepBytes, krb_asnapptag.APREP)...)
+	return krb_asn1tools.AddASNAppTag(tokenBytes, 0), nil
+}
+
+// verifyKRBTestAPReq verifies AP-REQ token with the service keytab, returns the authenticated client and AP-REQ
+func verifyKRBTestAPReq(serviceKeytab *krb_keytab.Keytab, tokenBytes []byte) (string, *krb_messages.APReq, error) {
+	token := krb_spnego.KRB5Token{}
+	err := token.Unmarshal(tokenBytes)
+	if err != nil {
+		return "", nil, err
+	}
+
+	_, credentials, err := krb_service.VerifyAPREQ(&token.APReq, krb_service.NewSettings(serviceKeytab))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return credentials.UserName() + "@" + credentials.Domain(), &token.APReq, nil
+}
+
+type krbTestServerResult struct {
+	clientPrincipal string
+	err             error
+}
+
+// runKRBTestServer is a stand-in of kerberos service, verifies AP-REQ and returns AP-REP
+func runKRBTestServer(serverConn net.Conn, serviceKeytab *krb_keytab.Keytab, wrongAPRep bool) chan krbTestServerResult {
+	resultChan := make(chan krbTestServerResult, 1)
+
+	go func() {
+		defer func() {
+			_ = serverConn.Close()
+		}()
+
+		tokenBytes, err := auth.ReadGSSToken(serverConn)
+		if err != nil {
+			resultChan <- krbTestServerResult{err: err}
+			return
+		}
+
+		clientPrincipal, apReq, err := verifyKRBTestAPReq(serviceKeytab, tokenBytes)
+		if err != nil {
+			resultChan <- krbTestServerResult{err: err}
+			return
+		}
+
+		ctime := apReq.Authenticator.CTime
+		if wrongAPRep {
+			ctime = ctime.Add(1 * time.Second)
+		}
+
+		apRepBytes, err := marshalKRBTestAPRep(ctime, apReq.Authenticator.Cusec, apReq.Ticket.DecryptedEncPart.Key)
+		if err != nil {
+			resultChan <- krbTestServerResult{err: err}
+			return
+		}
+
+		_, err = serverConn.Write(auth.MarshalGSSToken(apRepBytes))
+
+		resultChan <- krbTestServerResult{
+			clientPrincipal: clientPrincipal,
+			err:             err,
+		}
+	}()
+
+	return resultChan
+}
+
+func testGSSTokenFraming(t *testing.T) {
+	framed := auth.MarshalGSSToken([]byte("token"))
+	assert.Equal(t, []byte{0x00, 0x00, 0x00, 0x05, 't', 'o', 'k', 'e', 'n'}, framed)
+
+	token, err := auth.ReadGSSToken(bytes.NewReader(framed))
+	FailError(t, err)
+	assert.Equal(t, []byte("token"), token)
+
+	token, err = auth.ReadGSSToken(bytes.NewReader(auth.MarshalGSSToken([]byte{})))
+	FailError(t, err)
+	assert.Empty(t, token)
+
+	// truncated token
+	_, err = auth.ReadGSSToken(bytes.NewReader(framed[:7]))
+	assert.Error(t, err)
+
+	// too long token
+	tooLong := make([]byte, 4)
+	binary.BigEndian.PutUint32(tooLong, uint32(auth.GSSTokenMaxLength+1))
+	_, err = auth.ReadGSSToken(bytes.NewReader(tooLong))
+	assert.Error(t, err)
+}
+
+func testEstablishKRBContext(t *testing.T) {
+	serviceKeytab, krbConfig := createKRBTestEnvironment(t)
+
+	krbClient, err := auth.NewKerberosClient(krbConfig, "")
+	FailError(t, err)
+	defer krbClient.Release()
+
+	assert.Equal(t, krbTestClientName+"@"+krbTestRealm, krbClient.GetClientPrincipal())
+
+	clientConn, serverConn := net.Pipe()
+	defer func() {
+		_ = clientConn.Close()
+	}()
+
+	resultChan := runKRBTestServer(serverConn, serviceKeytab, false)
+
+	clientToken, err := krbClient.InitSecContext(krbTestServicePrincipal, true)
+	FailError(t, err)
+
+	_, err = clientConn.Write(auth.MarshalGSSToken(clientToken))
+	FailError(t, err)
+
+	serverToken, err := auth.ReadGSSToken(clientConn)
+	FailError(t, err)
+
+	err = krbClient.VerifyServerToken(serverToken)
+	FailError(t, err)
+
+	result := <-resultChan
+	FailError(t, result.err)
+	assert.Equal(t, krbTestClientName+"@"+krbTestRealm, result.clientPrincipal)
+}
+
+func testEstablishKRBContextWrongAPRep(t *testing.T) {
+	serviceKeytab, krbConfig := createKRBTestEnvironment(t)
+
+	krbClient, err := auth.NewKerberosClient(krbConfig, "")
+	FailError(t, err)
+	defer krbClient.Release()
+
+	clientConn, serverConn := net.Pipe()
+	defer func() {
+		_ = clientConn.Close()
+	}()
+
+	resultChan := runKRBTestServer(serverConn, serviceKeytab, true)
+
+	clientToken, err := krbClient.InitSecContext(krbTestServicePrincipal, true)
+	FailError(t, err)
+
+	_, err = clientConn.Write(auth.MarshalGSSToken(clientToken))
+	FailError(t, err)
+
+	serverToken, err := auth.ReadGSSToken(clientConn)
+	FailError(t, err)
+
+	// AP-REP does not match the authenticator
+	err = krbClient.VerifyServerToken(serverToken)
+	assert.Error(t, err)
+
+	result := <-resultChan
+	FailError(t, result.err)
+}
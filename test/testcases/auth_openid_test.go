@@ -0,0 +1,432 @@
+package testcases
+
+import (
+	"crypto/tls"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	"github.com/cyverse/go-irodsclient/irods/message"
+	"github.com/cyverse/go-irodsclient/irods/session"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func getAuthOpenIDTest() Test {
+	return Test{
+		Name: "Auth_OpenID",
+		Func: authOpenIDTest,
+	}
+}
+
+func authOpenIDTest(t *testing.T, test *Test) {
+	t.Run("OpenIDAuthPluginRequireSSL", testOpenIDAuthPluginRequireSSL)
+	t.Run("OpenIDAuth", testOpenIDAuth)
+	t.Run("OpenIDAuthRejected", testOpenIDAuthRejected)
+	t.Run("OpenIDAuthRetryWithFreshToken", testOpenIDAuthRetryWithFreshToken)
+}
+
+// authTestHandler handles an auth request of a connection, returns the response context and the result
+type authTestHandler func(authContext map[string]interface{}) (map[string]interface{}, int32)
+
+// authTestServer is a stand-in of iRODS server, negotiates SSL and passes auth requests to the handler of the connection
+type authTestServer struct {
+	listener       net.Listener
+	certificate    tls.Certificate
+	newAuthHandler func() authTestHandler
+
+	mutex       sync.Mutex
+	connections int
+}
+
+// openIDTestServer is a stand-in of iRODS server accepting openid auth requests
+type openIDTestServer struct {
+	*authTestServer
+	rejectTokens map[string]bool
+
+	tokenMutex   sync.Mutex
+	accessTokens []string
+}
+
+func newAuthTestServer(t *testing.T, newAuthHandler func() authTestHandler) *authTestServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	FailError(t, err)
+
+	cert := createGSITestCertificate(t, pkix.Name{CommonName: "localhost"}, false, nil)
+
+	server := &authTestServer{
+		listener: listener,
+		certificate: tls.Certificate{
+			Certificate: [][]byte{cert.certDER},
+			PrivateKey:  cert.key,
+		},
+		newAuthHandler: newAuthHandler,
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			server.mutex.Lock()
+			server.connections++
+			server.mutex.Unlock()
+
+			go server.serve(conn)
+		}
+	}()
+
+	return server
+}
+
+func newOpenIDTestServer(t *testing.T, rejectTokens ...string) *openIDTestServer {
+	server := &openIDTestServer{
+		rejectTokens: map[string]bool{},
+	}
+
+	for _, token := range rejectTokens {
+		server.rejectTokens[token] = true
+	}
+
+	server.authTestServer = newAuthTestServer(t, func() authTestHandler {
+		return server.handleAuth
+	})
+
+	return server
+}
+
+func (server *openIDTestServer) handleAuth(authContext map[string]interface{}) (map[string]interface{}, int32) {
+	accessToken, _ := authContext[connection.OPENID_AUTH_ACCESS_TOKEN_KEY].(string)
+
+	server.tokenMutex.Lock()
+	server.accessTokens = append(server.accessTokens, accessToken)
+	server.tokenMutex.Unlock()
+
+	result := int32(0)
+	if server.rejectTokens[accessToken] {
+		result = int32(common.CAT_INVALID_AUTHENTICATION)
+	}
+
+	return map[string]interface{}{
+		"user_name": "rods",
+	}, result
+}
+
+func (server *openIDTestServer) getAccount(t *testing.T) *types.IRODSAccount {
+	addr := server.listener.Addr().(*net.TCPAddr)
+	return createOpenIDTestAccount(t, "127.0.0.1", addr.Port)
+}
+
+func createOpenIDTestAccount(t *testing.T, host string, port int) *types.IRODSAccount {
+	account, err := types.CreateIRODSAccount(host, port, "rods", "tempZone", types.AuthSchemeOpenID, "", "")
+	FailError(t, err)
+
+	account.SetSSLConfiguration(&types.IRODSSSLConfig{
+		EncryptionKeySize:       32,
+		EncryptionAlgorithm:     "AES-256-CBC",
+		EncryptionSaltSize:      8,
+		EncryptionNumHashRounds: 16,
+		VerifyServer:            types.SSLVerifyServerNone,
+	})
+
+	return account
+}
+
+func (server *authTestServer) getConnections() int {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return server.connections
+}
+
+func (server *openIDTestServer) getAccessTokens() []string {
+	server.tokenMutex.Lock()
+	defer server.tokenMutex.Unlock()
+
+	return append([]string{}, server.accessTokens...)
+}
+
+func (server *authTestServer) Close() {
+	_ = server.listener.Close()
+}
+
+func readOpenIDTestMessageHeader(conn net.Conn) (*message.IRODSMessageHeader, error) {
+	headerLenBuffer := make([]byte, 4)
+	_, err := io.ReadFull(conn, headerLenBuffer)
+	if err != nil {
+		return nil, err
+	}
+
+	headerBuffer := make([]byte, binary.BigEndian.Uint32(headerLenBuffer))
+	_, err = io.ReadFull(conn, headerBuffer)
+	if err != nil {
+		return nil, err
+	}
+
+	header := &message.IRODSMessageHeader{}
+	err = header.FromBytes(headerBuffer)
+	if err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+func readOpenIDTestMessage(conn net.Conn) (*message.IRODSMessage, error) {
+	header, err := readOpenIDTestMessageHeader(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	bodyBuffer := make([]byte, header.MessageLen+header.ErrorLen+header.BsLen)
+	_, err = io.ReadFull(conn, bodyBuffer)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &message.IRODSMessageBody{}
+	err = body.FromBytes(header, bodyBuffer[:header.MessageLen+header.ErrorLen], bodyBuffer[header.MessageLen+header.ErrorLen:])
+	if err != nil {
+		return nil, err
+	}
+
+	body.Type = header.Type
+	body.IntInfo = header.IntInfo
+
+	return &message.IRODSMessage{
+		Header: header,
+		Body:   body,
+	}, nil
+}
+
+func writeOpenIDTestMessage(conn net.Conn, msg *message.IRODSMessage) error {
+	headerBytes, err := msg.Header.GetBytes()
+	if err != nil {
+		return err
+	}
+
+	bodyBytes, err := msg.Body.GetBytes()
+	if err != nil {
+		return err
+	}
+
+	buffer := make([]byte, 4)
+	binary.BigEndian.PutUint32(buffer, uint32(len(headerBytes)))
+	buffer = append(buffer, headerBytes...)
+	buffer = append(buffer, bodyBytes...)
+
+	_, err = conn.Write(buffer)
+	return err
+}
+
+// serve handles a connection, startup with SSL, auth requests, then waits for disconnect
+func (server *authTestServer) serve(conn net.Conn) {
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	// startup pack
+	_, err := readOpenIDTestMessage(conn)
+	if err != nil {
+		return
+	}
+
+	negotiation := message.IRODSMessageCSNegotiation{
+		Status: 1,
+		Result: string(types.CSNegotiationPolicyRequestSSL),
+	}
+	negotiationMessage, err := negotiation.GetMessage()
+	if err != nil {
+		return
+	}
+
+	err = writeOpenIDTestMessage(conn, negotiationMessage)
+	if err != nil {
+		return
+	}
+
+	// negotiation result
+	_, err = readOpenIDTestMessage(conn)
+	if err != nil {
+		return
+	}
+
+	version := message.IRODSMessageVersion{
+		Status:         0,
+		ReleaseVersion: "rods4.3.2",
+		APIVersion:     "d",
+	}
+	versionMessage, err := version.GetMessage()
+	if err != nil {
+		return
+	}
+
+	err = writeOpenIDTestMessage(conn, versionMessage)
+	if err != nil {
+		return
+	}
+
+	tlsConn := tls.Server(conn, &tls.Config{
+		Certificates: []tls.Certificate{server.certificate},
+	})
+
+	err = tlsConn.Handshake()
+	if err != nil {
+		return
+	}
+
+	// ssl settings are sent in a header, followed by shared secret
+	_, err = readOpenIDTestMessageHeader(tlsConn)
+	if err != nil {
+		return
+	}
+
+	_, err = readOpenIDTestMessage(tlsConn)
+	if err != nil {
+		return
+	}
+
+	handleAuth := server.newAuthHandler()
+
+	for {
+		requestMessage, err := readOpenIDTestMessage(tlsConn)
+		if err != nil || requestMessage.Header.Type == message.RODS_MESSAGE_DISCONNECT_TYPE {
+			return
+		}
+
+		if requestMessage.Header.Type != message.RODS_MESSAGE_API_REQ_TYPE || requestMessage.Header.IntInfo != int32(common.NEW_AUTH_PLUGIN_REQ_AN) {
+			return
+		}
+
+		authRequest := message.IRODSMessageNewAuthPluginRequest{}
+		err = authRequest.FromBytes(requestMessage.Body.Message)
+		if err != nil {
+			return
+		}
+
+		responseContext, result := handleAuth(authRequest.AuthContext)
+
+		responseMessage, err := message.NewIRODSMessageNewAuthPluginRequest(responseContext).GetMessage()
+		if err != nil {
+			return
+		}
+
+		responseMessage.Body.Type = message.RODS_MESSAGE_API_REPLY_TYPE
+		responseMessage.Body.IntInfo = result
+		responseMessage.Header.Type = message.RODS_MESSAGE_API_REPLY_TYPE
+		responseMessage.Header.IntInfo = result
+
+		err = writeOpenIDTestMessage(tlsConn, responseMessage)
+		if err != nil {
+			return
+		}
+	}
+}
+
+func testOpenIDAuthPluginRequireSSL(t *testing.T) {
+	account := createOpenIDTestAccount(t, "localhost", 1247)
+	account.AccessToken = "static_token"
+
+	conn, err := connection.NewIRODSConnection(account, nil)
+	FailError(t, err)
+
+	plugin := connection.NewOpenIDAuthPlugin()
+
+	responseContext, err := plugin.Execute(conn, connection.AUTH_CLIENT_START, connection.NewIRODSAuthContext())
+	FailError(t, err)
+
+	nextOperation, _ := responseContext.GetString(connection.AUTH_NEXT_OPERATION)
+	assert.Equal(t, connection.AUTH_CLIENT_AUTH_REQUEST, nextOperation)
+
+	userName, _ := responseContext.GetString("user_name")
+	assert.Equal(t, "rods", userName)
+
+	// bearer token must not be sent over a connection not secured
+	responseContext.Set(connection.OPENID_AUTH_ACCESS_TOKEN_KEY, account.AccessToken)
+
+	_, err = plugin.Execute(conn, connection.AUTH_CLIENT_AUTH_REQUEST, responseContext)
+	assert.Error(t, err)
+	assert.True(t, types.IsAuthError(err))
+}
+
+func testOpenIDAuth(t *testing.T) {
+	server := newOpenIDTestServer(t)
+	defer server.Close()
+
+	account := server.getAccount(t)
+	account.AccessToken = "static_token"
+
+	conn, err := connection.NewIRODSConnection(account, nil)
+	FailError(t, err)
+
+	err = conn.Connect()
+	FailError(t, err)
+	defer func() {
+		_ = conn.Disconnect()
+	}()
+
+	assert.True(t, conn.IsSSL())
+	assert.True(t, conn.IsLoggedIn())
+	assert.Equal(t, []string{"static_token"}, server.getAccessTokens())
+}
+
+func testOpenIDAuthRejected(t *testing.T) {
+	server := newOpenIDTestServer(t, "static_token")
+	defer server.Close()
+
+	account := server.getAccount(t)
+	account.AccessToken = "static_token"
+
+	// static access token is not retried
+	_, err := session.NewConnectionPool(account, &session.ConnectionPoolConfig{
+		InitialCap: 1,
+		MaxIdle:    1,
+		MaxCap:     1,
+	})
+	assert.Error(t, err)
+	assert.True(t, types.IsAuthError(err))
+
+	assert.Equal(t, []string{"static_token"}, server.getAccessTokens())
+	assert.Equal(t, 1, server.getConnections())
+}
+
+func testOpenIDAuthRetryWithFreshToken(t *testing.T) {
+	// the first token is rejected before it expires, e.g., revoked
+	server := newOpenIDTestServer(t, "token1")
+	defer server.Close()
+
+	source, count := newCountingTokenSource(1 * time.Hour)
+
+	account := server.getAccount(t)
+	account.SetTokenSource(source)
+
+	pool, err := session.NewConnectionPool(account, &session.ConnectionPoolConfig{
+		InitialCap: 1,
+		MaxIdle:    1,
+		MaxCap:     1,
+	})
+	FailError(t, err)
+	defer pool.Release()
+
+	assert.Equal(t, 2, *count)
+	assert.Equal(t, []string{"token1", "token2"}, server.getAccessTokens())
+
+	// a new connection is made for the retry
+	assert.Equal(t, 2, server.getConnections())
+
+	conn, isNew, err := pool.Get(false, false, false)
+	FailError(t, err)
+	assert.False(t, isNew)
+	assert.True(t, conn.IsLoggedIn())
+
+	err = pool.Return(conn)
+	FailError(t, err)
+}
@@ -0,0 +1,310 @@
+package testcases
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	pamInteractiveTestChallenge string = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	pamInteractiveTestUser      string = "user"
+	pamInteractiveTestPassword  string = "password"
+)
+
+func getAuthPAMInteractiveTest() Test {
+	return Test{
+		Name: "Auth_PAMInteractive",
+		Func: authPAMInteractiveTest,
+	}
+}
+
+func authPAMInteractiveTest(t *testing.T, test *Test) {
+	t.Run("PAMInteractiveAuthSharesToken", testPAMInteractiveAuthSharesToken)
+	t.Run("PAMInteractiveAuthPromptsForRejectedToken", testPAMInteractiveAuthPromptsForRejectedToken)
+}
+
+// pamInteractiveTestServer is a stand-in of iRODS server accepting pam_interactive auth requests and native auth with PAM tokens issued
+type pamInteractiveTestServer struct {
+	*authTestServer
+
+	stateMutex   sync.Mutex
+	pamToken     string
+	pamFlows     int
+	nativeTokens []string
+}
+
+func newPAMInteractiveTestServer(t *testing.T) *pamInteractiveTestServer {
+	server := &pamInteractiveTestServer{}
+
+	server.authTestServer = newAuthTestServer(t, func() authTestHandler {
+		step := 0
+
+		return func(authContext map[string]interface{}) (map[string]interface{}, int32) {
+			return server.handleAuth(authContext, &step)
+		}
+	})
+
+	return server
+}
+
+// handleAuth handles an auth request, step is the progress of pam_interactive auth of the connection
+func (server *pamInteractiveTestServer) handleAuth(authContext map[string]interface{}, step *int) (map[string]interface{}, int32) {
+	responseContext := map[string]interface{}{}
+	for k, v := range authContext {
+		responseContext[k] = v
+	}
+
+	scheme, _ := authContext["scheme"].(string)
+	nextOperation, _ := authContext[connection.AUTH_NEXT_OPERATION].(string)
+
+	server.stateMutex.Lock()
+	defer server.stateMutex.Unlock()
+
+	switch scheme {
+	case "native":
+		switch nextOperation {
+		case connection.AUTH_AGENT_AUTH_REQUEST:
+			responseContext["request_result"] = pamInteractiveTestChallenge
+			return responseContext, 0
+		case connection.AUTH_AGENT_AUTH_RESPONSE:
+			digest, _ := authContext["digest"].(string)
+
+			// find the token used
+			for _, token := range []string{server.pamToken, "stale_token"} {
+				if digest == getPAMInteractiveTestDigest(token) {
+					server.nativeTokens = append(server.nativeTokens, token)
+					break
+				}
+			}
+
+			if len(server.pamToken) == 0 || digest != getPAMInteractiveTestDigest(server.pamToken) {
+				return responseContext, int32(common.CAT_INVALID_AUTHENTICATION)
+			}
+			return responseContext, 0
+		}
+	case "pam_interactive":
+		switch nextOperation {
+		case connection.AUTH_AGENT_AUTH_REQUEST:
+			return responseContext, 0
+		case connection.AUTH_AGENT_AUTH_RESPONSE:
+			resp, _ := authContext["resp"].(string)
+
+			switch *step {
+			case 0:
+				server.pamFlows++
+				responseContext[connection.AUTH_NEXT_OPERATION] = connection.PAM_INTERACTIVE_AUTH_PERFORM_WAITING
+				responseContext["msg"] = map[string]interface{}{
+					"prompt": "Username: ",
+				}
+			case 1:
+				if resp != pamInteractiveTestUser {
+					responseContext[connection.AUTH_NEXT_OPERATION] = connection.PAM_INTERACTIVE_AUTH_PERFORM_NOT_AUTHENTICATED
+					return responseContext, 0
+				}
+
+				responseContext[connection.AUTH_NEXT_OPERATION] = connection.PAM_INTERACTIVE_AUTH_PERFORM_WAITING_PW
+				responseContext["msg"] = map[string]interface{}{
+					"prompt": "Password: ",
+				}
+			default:
+				if resp != pamInteractiveTestPassword {
+					responseContext[connection.AUTH_NEXT_OPERATION] = connection.PAM_INTERACTIVE_AUTH_PERFORM_NOT_AUTHENTICATED
+					return responseContext, 0
+				}
+
+				// issue a new token
+				server.pamToken = fmt.Sprintf("pam_token%d", server.pamFlows)
+
+				delete(responseContext, "msg")
+				responseContext[connection.AUTH_NEXT_OPERATION] = connection.PAM_INTERACTIVE_AUTH_PERFORM_AUTHENTICATED
+				responseContext["request_result"] = server.pamToken
+			}
+
+			*step++
+			return responseContext, 0
+		}
+	}
+
+	return responseContext, int32(common.SYS_INVALID_INPUT_PARAM)
+}
+
+// revokePAMToken makes the PAM token issued invalid
+func (server *pamInteractiveTestServer) revokePAMToken() {
+	server.stateMutex.Lock()
+	defer server.stateMutex.Unlock()
+
+	server.pamToken = ""
+}
+
+func (server *pamInteractiveTestServer) getPAMFlows() int {
+	server.stateMutex.Lock()
+	defer server.stateMutex.Unlock()
+
+	return server.pamFlows
+}
+
+func (server *pamInteractiveTestServer) getNativeTokens() []string {
+	server.stateMutex.Lock()
+	defer server.stateMutex.Unlock()
+
+	return append([]string{}, server.nativeTokens...)
+}
+
+func (server *pamInteractiveTestServer) getAccount(t *testing.T) *types.IRODSAccount {
+	addr := server.listener.Addr().(*net.TCPAddr)
+
+	account, err := types.CreateIRODSAccount("127.0.0.1", addr.Port, "rods", "tempZone", types.AuthSchemePAMInteractive, "", "")
+	FailError(t, err)
+
+	account.SetSSLConfiguration(&types.IRODSSSLConfig{
+		EncryptionKeySize:       32,
+		EncryptionAlgorithm:     "AES-256-CBC",
+		EncryptionSaltSize:      8,
+		EncryptionNumHashRounds: 16,
+		VerifyServer:            types.SSLVerifyServerNone,
+	})
+
+	return account
+}
+
+// getPAMInteractiveTestDigest returns the digest of native auth for the challenge of the test server
+func getPAMInteractiveTestDigest(password string) string {
+	paddedPassword := make([]byte, common.MaxPasswordLength)
+	copy(paddedPassword, []byte(password))
+
+	m := md5.New()
+	m.Write([]byte(pamInteractiveTestChallenge))
+	m.Write(paddedPassword)
+
+	digest := m.Sum(nil)
+	for idx := range digest {
+		if digest[idx] == 0 {
+			digest[idx] = 1
+		}
+	}
+
+	return base64.StdEncoding.EncodeToString(digest[:16])
+}
+
+// pamInteractiveTestPrompts answers prompts of the test server and records them
+type pamInteractiveTestPrompts struct {
+	mutex   sync.Mutex
+	prompts []string
+}
+
+func (prompts *pamInteractiveTestPrompts) prompt(prompt string, sensitive bool) (string, error) {
+	prompts.mutex.Lock()
+	defer prompts.mutex.Unlock()
+
+	prompts.prompts = append(prompts.prompts, prompt)
+
+	if sensitive {
+		return pamInteractiveTestPassword, nil
+	}
+	return pamInteractiveTestUser, nil
+}
+
+func (prompts *pamInteractiveTestPrompts) getPrompts() []string {
+	prompts.mutex.Lock()
+	defer prompts.mutex.Unlock()
+
+	return append([]string{}, prompts.prompts...)
+}
+
+func connectPAMInteractiveTest(t *testing.T, account *types.IRODSAccount) *connection.IRODSConnection {
+	conn, err := connection.NewIRODSConnection(account, nil)
+	FailError(t, err)
+
+	err = conn.Connect()
+	FailError(t, err)
+
+	assert.True(t, conn.IsSSL())
+	assert.True(t, conn.IsLoggedIn())
+	return conn
+}
+
+func testPAMInteractiveAuthSharesToken(t *testing.T) {
+	server := newPAMInteractiveTestServer(t)
+	defer server.Close()
+
+	prompts := &pamInteractiveTestPrompts{}
+
+	account := server.getAccount(t)
+	account.SetPAMInteractiveHandler(types.NewPAMInteractiveHandler(prompts.prompt, func(message string) {}))
+
+	conn1 := connectPAMInteractiveTest(t, account)
+	defer func() {
+		_ = conn1.Disconnect()
+	}()
+
+	// users are prompted, then the token issued is stored in the handler
+	assert.Equal(t, []string{"Username: ", "Password: "}, prompts.getPrompts())
+	assert.Equal(t, 1, server.getPAMFlows())
+	assert.Equal(t, "pam_token1", account.PAMInteractiveHandler.GetPAMToken())
+	assert.Equal(t, "pam_token1", account.GetPAMToken())
+
+	// other connections of the account reuse the token without prompts
+	conn2 := connectPAMInteractiveTest(t, account)
+	defer func() {
+		_ = conn2.Disconnect()
+	}()
+
+	assert.Equal(t, 2, len(prompts.getPrompts()))
+	assert.Equal(t, 1, server.getPAMFlows())
+	assert.Equal(t, []string{"pam_token1", "pam_token1"}, server.getNativeTokens())
+	assert.Equal(t, 2, server.getConnections())
+}
+
+func testPAMInteractiveAuthPromptsForRejectedToken(t *testing.T) {
+	server := newPAMInteractiveTestServer(t)
+	defer server.Close()
+
+	prompts := &pamInteractiveTestPrompts{}
+
+	account := server.getAccount(t)
+	account.PAMToken = "stale_token"
+	account.SetPAMInteractiveHandler(types.NewPAMInteractiveHandler(prompts.prompt, func(message string) {}))
+
+	// the token given is rejected, users are prompted on the same connection
+	conn1 := connectPAMInteractiveTest(t, account)
+	defer func() {
+		_ = conn1.Disconnect()
+	}()
+
+	assert.Equal(t, []string{"Username: ", "Password: "}, prompts.getPrompts())
+	assert.Equal(t, 1, server.getPAMFlows())
+	assert.Equal(t, "pam_token1", account.GetPAMToken())
+	assert.Equal(t, []string{"stale_token", "pam_token1"}, server.getNativeTokens())
+	assert.Equal(t, 1, server.getConnections())
+
+	// the token issued is revoked, e.g., expired
+	server.revokePAMToken()
+
+	conn2 := connectPAMInteractiveTest(t, account)
+	defer func() {
+		_ = conn2.Disconnect()
+	}()
+
+	assert.Equal(t, 4, len(prompts.getPrompts()))
+	assert.Equal(t, 2, server.getPAMFlows())
+	assert.Equal(t, "pam_token2", account.PAMInteractiveHandler.GetPAMToken())
+	assert.Equal(t, "pam_token2", account.GetPAMToken())
+
+	// the new token is reused
+	conn3 := connectPAMInteractiveTest(t, account)
+	defer func() {
+		_ = conn3.Disconnect()
+	}()
+
+	assert.Equal(t, 4, len(prompts.getPrompts()))
+	assert.Equal(t, 2, server.getPAMFlows())
+}
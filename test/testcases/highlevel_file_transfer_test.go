@@ -1,11 +1,20 @@
 package testcases
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"net"
 	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
 	"testing"
 
+	"github.com/cockroachdb/errors"
 	"github.com/cyverse/go-irodsclient/fs"
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -24,6 +33,17 @@ func highlevelFileTransferTest(t *testing.T, test *Test) {
 	t.Run("UploadAndDownloadRedirectToResource", testUploadAndDownloadRedirectToResource)
 	t.Run("UploadAndDownloadRedirectToResourceOverwrite", testUploadAndDownloadRedirectToResourceOverwrite)
 	t.Run("UploadAndDownload1000sRedirectToResource", testUploadAndDownload1000sRedirectToResource)
+	t.Run("UploadDirBulk", testUploadDirBulk)
+	t.Run("UploadAndDownloadDir", testUploadAndDownloadDir)
+	t.Run("SyncDir", testSyncDir)
+	t.Run("SyncDirSkipDeletesAfterErrors", testSyncDirSkipDeletesAfterErrors)
+	t.Run("SyncDirDryRunChecksum", testSyncDirDryRunChecksum)
+	t.Run("UploadResumable", testUploadResumable)
+	t.Run("UploadFromReaderAndDownloadToWriter", testUploadFromReaderAndDownloadToWriter)
+	t.Run("UploadFromFailingReader", testUploadFromFailingReader)
+	t.Run("DownloadWithMatchHashPolicy", testDownloadWithMatchHashPolicy)
+	t.Run("UploadChecksumMismatch", testUploadChecksumMismatch)
+	t.Run("UploadWithMatchHashPolicy", testUploadWithMatchHashPolicy)
 }
 
 func testUploadAndDownload(t *testing.T) {
@@ -548,3 +568,898 @@ func testUploadAndDownload1000sRedirectToResource(t *testing.T) {
 		FailError(t, err)
 	}
 }
+
+func testUploadDirBulk(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	// local dir with many small files, a sub dir and a large file
+	localDir := t.TempDir()
+	localSubDir := filepath.Join(localDir, "sub")
+	err = os.MkdirAll(localSubDir, 0755)
+	FailError(t, err)
+
+	smallFileNum := 120
+	for i := 0; i < smallFileNum; i++ {
+		err = os.WriteFile(filepath.Join(localDir, fmt.Sprintf("small_%d.txt", i)), []byte(fmt.Sprintf("HELLO WORLD %d", i)), 0644)
+		FailError(t, err)
+	}
+
+	err = os.WriteFile(filepath.Join(localSubDir, "small_sub.txt"), []byte("HELLO SUB"), 0644)
+	FailError(t, err)
+
+	largeFile, err := CreateLocalTestFile(t, "large_", 5*1024*1024)
+	FailError(t, err)
+
+	err = os.Rename(largeFile, filepath.Join(localSubDir, "large.bin"))
+	FailError(t, err)
+
+	irodsDir := homeDir + "/test_bulk_dir"
+
+	results, err := filesystem.UploadDirBulk(localDir, irodsDir, "", false, true, nil)
+	FailError(t, err)
+
+	defer func() {
+		err = filesystem.RemoveDir(irodsDir, true, true)
+		FailError(t, err)
+	}()
+
+	assert.Len(t, results, smallFileNum+2)
+	for _, result := range results {
+		assert.Equal(t, result.LocalSize, result.IRODSSize)
+		assert.NotEmpty(t, result.IRODSCheckSum)
+		assert.Equal(t, result.LocalCheckSum, result.IRODSCheckSum)
+	}
+
+	entries, err := filesystem.List(irodsDir)
+	FailError(t, err)
+	assert.Len(t, entries, smallFileNum+1)
+
+	entries, err = filesystem.List(irodsDir + "/sub")
+	FailError(t, err)
+	assert.Len(t, entries, 2)
+
+	// contents of data objects must match local files
+	localFiles := map[string]string{
+		irodsDir + "/sub/small_sub.txt": filepath.Join(localSubDir, "small_sub.txt"),
+		irodsDir + "/sub/large.bin":     filepath.Join(localSubDir, "large.bin"),
+	}
+	for i := 0; i < smallFileNum; i++ {
+		filename := fmt.Sprintf("small_%d.txt", i)
+		localFiles[irodsDir+"/"+filename] = filepath.Join(localDir, filename)
+	}
+
+	for irodsPath, localPath := range localFiles {
+		localData, err := os.ReadFile(localPath)
+		FailError(t, err)
+
+		buffer := &bytes.Buffer{}
+		_, err = filesystem.DownloadFileToBuffer(irodsPath, "", buffer, false, nil)
+		FailError(t, err)
+
+		assert.Equal(t, localData, buffer.Bytes(), irodsPath)
+	}
+
+	// upload again without force
+	_, err = filesystem.UploadDirBulk(localDir, irodsDir, "", false, false, nil)
+	assert.Error(t, err)
+
+	// upload again with force
+	results, err = filesystem.UploadDirBulk(localDir, irodsDir, "", true, false, nil)
+	FailError(t, err)
+	assert.Len(t, results, smallFileNum+2)
+}
+
+func testDownloadWithMatchHashPolicy(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+	serverInfo := server.GetInfo()
+
+	// checksum is not generated synchronously in v4.2.8
+	if serverInfo.Version == "4.2.8" {
+		return
+	}
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	filename := "test_hash_policy.bin"
+	localPath, err := CreateLocalTestFile(t, filename, 1024*1024)
+	FailError(t, err)
+
+	irodsPath := homeDir + "/" + filename
+
+	_, err = filesystem.UploadFile(localPath, irodsPath, "", false, true, nil)
+	FailError(t, err)
+
+	entry, err := filesystem.Stat(irodsPath)
+	FailError(t, err)
+	assert.NotEmpty(t, entry.CheckSum)
+
+	// use a default hash scheme different from the checksum algorithm of the data object
+	otherHashScheme := "MD5"
+	if entry.CheckSumAlgorithm == types.ChecksumAlgorithmMD5 {
+		otherHashScheme = "SHA256"
+	}
+
+	account, err := server.GetAccount()
+	FailError(t, err)
+	account.DefaultHashScheme = otherHashScheme
+
+	// compatible, hash with the algorithm of the data object
+	account.MatchHashPolicy = types.MatchHashPolicyCompatible
+	compatibleFilesystem, err := fs.NewFileSystem(account, server.GetFileSystemConfig())
+	FailError(t, err)
+	defer compatibleFilesystem.Release()
+
+	result, err := compatibleFilesystem.DownloadFile(irodsPath, "", t.TempDir()+"/compatible.bin", true, nil)
+	FailError(t, err)
+	assert.Equal(t, entry.CheckSumAlgorithm, result.LocalCheckSumAlgorithm)
+	assert.Equal(t, entry.CheckSum, result.LocalCheckSum)
+
+	// strict, algorithm mismatch
+	account.MatchHashPolicy = types.MatchHashPolicyStrict
+	strictFilesystem, err := fs.NewFileSystem(account, server.GetFileSystemConfig())
+	FailError(t, err)
+	defer strictFilesystem.Release()
+
+	_, err = strictFilesystem.DownloadFile(irodsPath, "", t.TempDir()+"/strict.bin", true, nil)
+	assert.Error(t, err)
+	assert.True(t, types.IsChecksumMismatchError(err))
+
+	err = filesystem.RemoveFile(irodsPath, true)
+	FailError(t, err)
+}
+
+func testUploadChecksumMismatch(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	filename := "test_upload_mismatch.bin"
+	localPath, err := CreateLocalTestFile(t, filename, 1024*1024)
+	FailError(t, err)
+
+	irodsPath := homeDir + "/" + filename
+
+	// change the local file after its hash is calculated, before it is uploaded
+	modified := false
+	callback := func(name string, current int64, total int64) {
+		if name != "checksum" || current != total || modified {
+			return
+		}
+
+		modified = true
+
+		data, err := os.ReadFile(localPath)
+		FailError(t, err)
+
+		for i := range data {
+			data[i] = ^data[i]
+		}
+
+		err = os.WriteFile(localPath, data, 0644)
+		FailError(t, err)
+	}
+
+	_, err = filesystem.UploadFile(localPath, irodsPath, "", false, true, callback)
+	assert.True(t, modified)
+	assert.Error(t, err)
+	assert.True(t, types.IsChecksumMismatchError(err))
+
+	if filesystem.ExistsFile(irodsPath) {
+		err = filesystem.RemoveFile(irodsPath, true)
+		FailError(t, err)
+	}
+}
+
+func testUploadWithMatchHashPolicy(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+	serverInfo := server.GetInfo()
+
+	// checksum is not generated synchronously in v4.2.8
+	if serverInfo.Version == "4.2.8" {
+		return
+	}
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	filename := "test_upload_hash_policy.bin"
+	localPath, err := CreateLocalTestFile(t, filename, 1024*1024)
+	FailError(t, err)
+
+	irodsPath := homeDir + "/" + filename
+
+	_, err = filesystem.UploadFile(localPath, irodsPath, "", false, true, nil)
+	FailError(t, err)
+
+	entry, err := filesystem.Stat(irodsPath)
+	FailError(t, err)
+	assert.NotEmpty(t, entry.CheckSum)
+
+	// use a default hash scheme different from the checksum algorithm of the data object
+	otherHashScheme := "MD5"
+	if entry.CheckSumAlgorithm == types.ChecksumAlgorithmMD5 {
+		otherHashScheme = "SHA256"
+	}
+
+	account, err := server.GetAccount()
+	FailError(t, err)
+	account.DefaultHashScheme = otherHashScheme
+	account.MatchHashPolicy = types.MatchHashPolicyStrict
+
+	strictFilesystem, err := fs.NewFileSystem(account, server.GetFileSystemConfig())
+	FailError(t, err)
+	defer strictFilesystem.Release()
+
+	// strict, overwrite is hashed with the default hash scheme, not with the algorithm of the data object
+	result, err := strictFilesystem.UploadFile(localPath, irodsPath, "", false, true, nil)
+	if err != nil {
+		// the server keeps its own algorithm
+		assert.True(t, types.IsChecksumMismatchError(err))
+	} else {
+		assert.Equal(t, types.GetChecksumAlgorithm(otherHashScheme), result.LocalCheckSumAlgorithm)
+		assert.Equal(t, result.LocalCheckSumAlgorithm, result.IRODSCheckSumAlgorithm)
+		assert.Equal(t, result.LocalCheckSum, result.IRODSCheckSum)
+	}
+
+	err = filesystem.RemoveFile(irodsPath, true)
+	FailError(t, err)
+}
+
+func testUploadAndDownloadDir(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	// local dir with small files, a sub dir, a large file and a symlink
+	localDir := t.TempDir()
+	localSubDir := filepath.Join(localDir, "sub")
+	err = os.MkdirAll(localSubDir, 0755)
+	FailError(t, err)
+
+	err = os.WriteFile(filepath.Join(localDir, "a.txt"), []byte("HELLO A"), 0644)
+	FailError(t, err)
+
+	err = os.WriteFile(filepath.Join(localDir, "b.log"), []byte("HELLO LOG"), 0644)
+	FailError(t, err)
+
+	err = os.WriteFile(filepath.Join(localSubDir, "c.txt"), []byte("HELLO C"), 0644)
+	FailError(t, err)
+
+	largeFile, err := CreateLocalTestFile(t, "large_", 5*1024*1024)
+	FailError(t, err)
+
+	err = os.Rename(largeFile, filepath.Join(localSubDir, "large.bin"))
+	FailError(t, err)
+
+	err = os.Symlink(filepath.Join(localDir, "a.txt"), filepath.Join(localDir, "link.txt"))
+	FailError(t, err)
+
+	irodsDir := homeDir + "/test_transfer_dir"
+
+	config := fs.NewDefaultDirTransferConfig()
+	config.ParallelThreshold = 4 * 1024 * 1024
+	config.Exclude = []string{"*.log"}
+
+	result, err := filesystem.UploadDir(localDir, irodsDir, config)
+	FailError(t, err)
+
+	defer func() {
+		err = filesystem.RemoveDir(irodsDir, true, true)
+		FailError(t, err)
+	}()
+
+	assert.Len(t, result.Files, 3)
+	assert.Len(t, result.Skipped, 2) // b.log and link.txt
+	for _, fileResult := range result.Files {
+		assert.NoError(t, fileResult.Error)
+		assert.Equal(t, fileResult.Size, fileResult.Result.IRODSSize)
+
+		if fileResult.Size >= config.ParallelThreshold {
+			assert.Equal(t, fs.FileTransferMethodParallel, fileResult.Method)
+		} else {
+			assert.Equal(t, fs.FileTransferMethodSingle, fileResult.Method)
+		}
+	}
+
+	assert.False(t, filesystem.ExistsFile(irodsDir+"/b.log"))
+	assert.False(t, filesystem.ExistsFile(irodsDir+"/link.txt"))
+	assert.True(t, filesystem.ExistsFile(irodsDir+"/sub/large.bin"))
+
+	// upload again without force
+	_, err = filesystem.UploadDir(localDir, irodsDir, config)
+	assert.Error(t, err)
+
+	// follow symlinks
+	config.Force = true
+	config.SymlinkPolicy = fs.SymlinkPolicyFollow
+
+	result, err = filesystem.UploadDir(localDir, irodsDir, config)
+	FailError(t, err)
+	assert.Len(t, result.Files, 4)
+	assert.True(t, filesystem.ExistsFile(irodsDir+"/link.txt"))
+
+	// download with include filter
+	downloadDir := t.TempDir()
+
+	config = fs.NewDefaultDirTransferConfig()
+	config.Include = []string{"*.txt"}
+	config.VerifyChecksum = false
+
+	result, err = filesystem.DownloadDir(irodsDir, downloadDir, config)
+	FailError(t, err)
+	assert.Len(t, result.Files, 3)
+
+	data, err := os.ReadFile(filepath.Join(downloadDir, "sub", "c.txt"))
+	FailError(t, err)
+	assert.Equal(t, "HELLO C", string(data))
+
+	data, err = os.ReadFile(filepath.Join(downloadDir, "link.txt"))
+	FailError(t, err)
+	assert.Equal(t, "HELLO A", string(data))
+
+	_, err = os.Stat(filepath.Join(downloadDir, "sub", "large.bin"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func testSyncDir(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	localDir := t.TempDir()
+	err = os.MkdirAll(filepath.Join(localDir, "sub"), 0755)
+	FailError(t, err)
+
+	err = os.WriteFile(filepath.Join(localDir, "a.txt"), []byte("HELLO A"), 0644)
+	FailError(t, err)
+
+	err = os.WriteFile(filepath.Join(localDir, "sub", "b.txt"), []byte("HELLO B"), 0644)
+	FailError(t, err)
+
+	irodsDir := homeDir + "/test_sync_dir"
+	irodsCopyDir := homeDir + "/test_sync_dir_copy"
+
+	defer func() {
+		err = filesystem.RemoveDir(irodsDir, true, true)
+		FailError(t, err)
+
+		err = filesystem.RemoveDir(irodsCopyDir, true, true)
+		FailError(t, err)
+	}()
+
+	// dry-run
+	config := fs.NewDefaultSyncConfig()
+	config.DryRun = true
+
+	result, err := filesystem.SyncLocalToIRODS(localDir, irodsDir, config)
+	FailError(t, err)
+	assert.Len(t, result.GetActions(fs.SyncActionMakeDir), 2)
+	assert.Len(t, result.GetActions(fs.SyncActionCreate), 2)
+	assert.False(t, filesystem.Exists(irodsDir))
+
+	// local to irods
+	config.DryRun = false
+
+	result, err = filesystem.SyncLocalToIRODS(localDir, irodsDir, config)
+	FailError(t, err)
+	assert.Len(t, result.GetActions(fs.SyncActionCreate), 2)
+	assert.True(t, filesystem.ExistsFile(irodsDir+"/sub/b.txt"))
+
+	// nothing to do
+	result, err = filesystem.SyncLocalToIRODS(localDir, irodsDir, config)
+	FailError(t, err)
+	assert.Empty(t, result.Actions)
+	assert.Len(t, result.Unchanged, 2)
+
+	// same size but different content, and an extraneous file
+	err = os.WriteFile(filepath.Join(localDir, "a.txt"), []byte("HELLO Z"), 0644)
+	FailError(t, err)
+
+	fileHandle, err := filesystem.CreateFile(irodsDir+"/extra.txt", "", "w")
+	FailError(t, err)
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	config.DeleteExtraneous = true
+
+	result, err = filesystem.SyncLocalToIRODS(localDir, irodsDir, config)
+	FailError(t, err)
+	assert.Len(t, result.GetActions(fs.SyncActionUpdate), 1)
+	assert.Len(t, result.GetActions(fs.SyncActionDelete), 1)
+	assert.False(t, filesystem.Exists(irodsDir+"/extra.txt"))
+
+	// irods to local
+	downloadDir := t.TempDir()
+
+	result, err = filesystem.SyncIRODSToLocal(irodsDir, downloadDir, config)
+	FailError(t, err)
+	assert.Len(t, result.GetActions(fs.SyncActionCreate), 2)
+
+	data, err := os.ReadFile(filepath.Join(downloadDir, "a.txt"))
+	FailError(t, err)
+	assert.Equal(t, "HELLO Z", string(data))
+
+	// irods to irods
+	config.Comparator = fs.SyncCompareSize
+
+	result, err = filesystem.SyncIRODSToIRODS(irodsDir, irodsCopyDir, config)
+	FailError(t, err)
+	assert.Len(t, result.GetActions(fs.SyncActionCreate), 2)
+	assert.True(t, filesystem.ExistsFile(irodsCopyDir+"/sub/b.txt"))
+}
+
+func testSyncDirSkipDeletesAfterErrors(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	localDir := t.TempDir()
+
+	err = os.WriteFile(filepath.Join(localDir, "a.txt"), []byte("HELLO A"), 0644)
+	FailError(t, err)
+
+	// symlink fails to sync with SymlinkPolicyError
+	err = os.Symlink(filepath.Join(localDir, "a.txt"), filepath.Join(localDir, "link.txt"))
+	FailError(t, err)
+
+	irodsDir := homeDir + "/test_sync_skip_deletes_dir"
+	err = filesystem.MakeDir(irodsDir, true)
+	FailError(t, err)
+
+	defer func() {
+		err = filesystem.RemoveDir(irodsDir, true, true)
+		FailError(t, err)
+	}()
+
+	fileHandle, err := filesystem.CreateFile(irodsDir+"/extra.txt", "", "w")
+	FailError(t, err)
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	config := fs.NewDefaultSyncConfig()
+	config.DeleteExtraneous = true
+	config.Transfer.SymlinkPolicy = fs.SymlinkPolicyError
+
+	// deletions are skipped after failures
+	result, err := filesystem.SyncLocalToIRODS(localDir, irodsDir, config)
+	assert.Error(t, err)
+	assert.True(t, filesystem.ExistsFile(irodsDir+"/a.txt"))
+	assert.True(t, filesystem.ExistsFile(irodsDir+"/extra.txt"))
+
+	deletes := result.GetActions(fs.SyncActionDelete)
+	if assert.Len(t, deletes, 1) {
+		assert.Error(t, deletes[0].Error)
+	}
+
+	// opt in to delete after failures
+	config.DeleteAfterErrors = true
+
+	result, err = filesystem.SyncLocalToIRODS(localDir, irodsDir, config)
+	assert.Error(t, err)
+	assert.False(t, filesystem.Exists(irodsDir+"/extra.txt"))
+
+	deletes = result.GetActions(fs.SyncActionDelete)
+	if assert.Len(t, deletes, 1) {
+		assert.NoError(t, deletes[0].Error)
+	}
+}
+
+func testSyncDirDryRunChecksum(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	localDir := t.TempDir()
+
+	err = os.WriteFile(filepath.Join(localDir, "a.txt"), []byte("HELLO A"), 0644)
+	FailError(t, err)
+
+	irodsDir := homeDir + "/test_sync_dry_run_dir"
+	err = filesystem.MakeDir(irodsDir, true)
+	FailError(t, err)
+
+	defer func() {
+		err = filesystem.RemoveDir(irodsDir, true, true)
+		FailError(t, err)
+	}()
+
+	// same size, without checksum
+	fileHandle, err := filesystem.CreateFile(irodsDir+"/a.txt", "", "w")
+	FailError(t, err)
+
+	_, err = fileHandle.Write([]byte("HELLO Z"))
+	FailError(t, err)
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	entry, err := filesystem.Stat(irodsDir + "/a.txt")
+	FailError(t, err)
+	assert.Empty(t, entry.CheckSum)
+
+	// dry-run does not compute checksums
+	config := fs.NewDefaultSyncConfig()
+	config.DryRun = true
+
+	result, err := filesystem.SyncLocalToIRODS(localDir, irodsDir, config)
+	FailError(t, err)
+	assert.Len(t, result.GetActions(fs.SyncActionCompare), 1)
+	assert.Empty(t, result.GetActions(fs.SyncActionUpdate))
+
+	filesystem.ClearCache()
+
+	entry, err = filesystem.Stat(irodsDir + "/a.txt")
+	FailError(t, err)
+	assert.Empty(t, entry.CheckSum)
+
+	// checksums are computed and compared in the real run
+	config.DryRun = false
+
+	result, err = filesystem.SyncLocalToIRODS(localDir, irodsDir, config)
+	FailError(t, err)
+	assert.Len(t, result.GetActions(fs.SyncActionUpdate), 1)
+}
+
+// tcpTestProxy relays connections to a server, used to cut connections in the middle of a transfer
+type tcpTestProxy struct {
+	listener net.Listener
+	target   string
+
+	mutex sync.Mutex
+	conns []net.Conn
+	cut   bool
+}
+
+func newTCPTestProxy(t *testing.T, host string, port int) *tcpTestProxy {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	FailError(t, err)
+
+	proxy := &tcpTestProxy{
+		listener: listener,
+		target:   net.JoinHostPort(host, strconv.Itoa(port)),
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go proxy.relay(conn)
+		}
+	}()
+
+	return proxy
+}
+
+func (proxy *tcpTestProxy) getPort() int {
+	return proxy.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (proxy *tcpTestProxy) relay(conn net.Conn) {
+	serverConn, err := net.Dial("tcp", proxy.target)
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+
+	proxy.mutex.Lock()
+	if proxy.cut {
+		proxy.mutex.Unlock()
+		_ = conn.Close()
+		_ = serverConn.Close()
+		return
+	}
+	proxy.conns = append(proxy.conns, conn, serverConn)
+	proxy.mutex.Unlock()
+
+	go func() {
+		_, _ = io.Copy(serverConn, conn)
+		_ = serverConn.Close()
+	}()
+
+	_, _ = io.Copy(conn, serverConn)
+	_ = conn.Close()
+}
+
+// Cut closes all connections relayed and stops accepting new connections
+func (proxy *tcpTestProxy) Cut() {
+	proxy.mutex.Lock()
+	defer proxy.mutex.Unlock()
+
+	if proxy.cut {
+		return
+	}
+
+	proxy.cut = true
+	_ = proxy.listener.Close()
+
+	for _, conn := range proxy.conns {
+		_ = conn.Close()
+	}
+}
+
+func testUploadResumable(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	fileSize := int64(32 * 1024 * 1024)
+	localPath, err := CreateLocalTestFile(t, "test_resumable_", fileSize)
+	FailError(t, err)
+
+	localData, err := os.ReadFile(localPath)
+	FailError(t, err)
+
+	stat, err := os.Stat(localPath)
+	FailError(t, err)
+
+	irodsPath := homeDir + "/test_resumable.bin"
+	statusFilePath := irods_fs.GetDataObjectUploadStatusFilePath(localPath)
+
+	defer func() {
+		err = filesystem.RemoveFile(irodsPath, true)
+		FailError(t, err)
+	}()
+
+	for _, taskNum := range []int{1, 4} {
+		// upload through a proxy, connections are cut when a half is uploaded
+		account, err := server.GetAccount()
+		FailError(t, err)
+
+		proxy := newTCPTestProxy(t, account.Host, account.Port)
+		account.Host = "127.0.0.1"
+		account.Port = proxy.getPort()
+
+		proxyFilesystem, err := fs.NewFileSystem(account, server.GetFileSystemConfig())
+		FailError(t, err)
+
+		cutCallback := func(taskName string, processed int64, total int64) {
+			if taskName == "upload" && processed >= fileSize/2 {
+				proxy.Cut()
+			}
+		}
+
+		_, err = proxyFilesystem.UploadFileParallelResumable(localPath, irodsPath, "", taskNum, false, true, cutCallback)
+		assert.Error(t, err)
+
+		proxy.Cut()
+		proxyFilesystem.Release()
+
+		// status file is kept to resume
+		_, err = os.Stat(statusFilePath)
+		FailError(t, err)
+
+		// servers not supporting replica tokens upload in a single task
+		numTasks := taskNum
+		if !filesystem.SupportParallelUpload() {
+			numTasks = 1
+		}
+
+		transferStatusLocal, err := irods_fs.GetOrNewDataObjectTransferStatusLocalForUpload(localPath, irodsPath, fileSize, stat.ModTime().UnixNano(), numTasks)
+		FailError(t, err)
+
+		completed := int64(0)
+		for _, transferStatusEntry := range transferStatusLocal.GetStatus().StatusMap {
+			completed += transferStatusEntry.CompletedLength
+		}
+
+		assert.GreaterOrEqual(t, completed, fileSize/2)
+		assert.Less(t, completed, fileSize)
+
+		// resume
+		firstProgress := int64(-1)
+		callbackMutex := sync.Mutex{}
+		callback := func(taskName string, processed int64, total int64) {
+			callbackMutex.Lock()
+			defer callbackMutex.Unlock()
+
+			if taskName == "upload" && firstProgress < 0 {
+				firstProgress = processed
+			}
+		}
+
+		result, err := filesystem.UploadFileParallelResumable(localPath, irodsPath, "", taskNum, false, true, callback)
+		FailError(t, err)
+
+		if numTasks == 1 {
+			// continued from the last offset recorded
+			assert.Equal(t, completed, firstProgress)
+		}
+
+		assert.Equal(t, fileSize, result.IRODSSize)
+		assert.Equal(t, result.LocalCheckSum, result.IRODSCheckSum)
+
+		_, err = os.Stat(statusFilePath)
+		assert.True(t, os.IsNotExist(err))
+
+		buffer := &bytes.Buffer{}
+		_, err = filesystem.DownloadFileToBuffer(irodsPath, "", buffer, false, nil)
+		FailError(t, err)
+		assert.Equal(t, localData, buffer.Bytes())
+	}
+}
+
+func testUploadFromReaderAndDownloadToWriter(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	fileSize := int64(10 * 1024 * 1024)
+	localPath, err := CreateLocalTestFile(t, "test_stream_", fileSize)
+	FailError(t, err)
+
+	localData, err := os.ReadFile(localPath)
+	FailError(t, err)
+
+	irodsPath := homeDir + "/test_stream.bin"
+
+	// small buffer to stream in many chunks
+	opts := fs.NewDefaultStreamTransferOptions()
+	opts.BufferSize = 1024 * 1024
+
+	uploaded := int64(0)
+	opts.TransferCallback = func(taskName string, processed int64, total int64) {
+		uploaded = processed
+	}
+
+	result, err := filesystem.UploadFromReader(bytes.NewReader(localData), irodsPath, opts)
+	FailError(t, err)
+
+	defer func() {
+		err = filesystem.RemoveFile(irodsPath, true)
+		FailError(t, err)
+	}()
+
+	assert.Equal(t, fileSize, uploaded)
+	assert.Equal(t, fileSize, result.LocalSize)
+	assert.Equal(t, fileSize, result.IRODSSize)
+	assert.NotEmpty(t, result.IRODSCheckSum)
+	assert.Equal(t, result.LocalCheckSum, result.IRODSCheckSum)
+
+	// overwrite with smaller data
+	smallData := localData[:fileSize/3]
+	result, err = filesystem.UploadFromReader(bytes.NewReader(smallData), irodsPath, opts)
+	FailError(t, err)
+	assert.Equal(t, int64(len(smallData)), result.IRODSSize)
+	assert.Equal(t, result.LocalCheckSum, result.IRODSCheckSum)
+
+	// download
+	opts.TransferCallback = nil
+
+	buffer := &bytes.Buffer{}
+	result, err = filesystem.DownloadToWriter(irodsPath, buffer, opts)
+	FailError(t, err)
+	assert.Equal(t, int64(len(smallData)), result.LocalSize)
+	assert.Equal(t, result.IRODSCheckSum, result.LocalCheckSum)
+	assert.Equal(t, smallData, buffer.Bytes())
+}
+
+// failingReader returns an error after reading limit bytes
+type failingReader struct {
+	reader io.Reader
+	limit  int64
+	read   int64
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.read >= r.limit {
+		return 0, errors.New("reader failed")
+	}
+
+	if int64(len(p)) > r.limit-r.read {
+		p = p[:r.limit-r.read]
+	}
+
+	n, err := r.reader.Read(p)
+	r.read += int64(n)
+	return n, err
+}
+
+func testUploadFromFailingReader(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	fileSize := int64(5 * 1024 * 1024)
+	localPath, err := CreateLocalTestFile(t, "test_stream_fail_", fileSize)
+	FailError(t, err)
+
+	localData, err := os.ReadFile(localPath)
+	FailError(t, err)
+
+	irodsPath := homeDir + "/test_stream_fail.bin"
+
+	opts := fs.NewDefaultStreamTransferOptions()
+	opts.BufferSize = 1024 * 1024
+
+	_, err = filesystem.UploadFromReader(bytes.NewReader(localData), irodsPath, opts)
+	FailError(t, err)
+
+	// fails after writing a few chunks
+	reader := &failingReader{
+		reader: bytes.NewReader(localData),
+		limit:  fileSize / 2,
+	}
+
+	result, err := filesystem.UploadFromReader(reader, irodsPath, opts)
+	assert.Error(t, err)
+	assert.Equal(t, fileSize/2, result.LocalSize)
+
+	// partial data object must not be left
+	assert.False(t, filesystem.ExistsFile(irodsPath))
+
+	_, err = filesystem.StatFile(irodsPath)
+	assert.Error(t, err)
+	assert.True(t, types.IsFileNotFoundError(err))
+}
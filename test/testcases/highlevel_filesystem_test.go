@@ -1,15 +1,20 @@
 package testcases
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	io_fs "io/fs"
 	"os"
 	"path"
+	"sync"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/cyverse/go-irodsclient/fs"
 	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/message"
 	"github.com/cyverse/go-irodsclient/irods/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -33,6 +38,14 @@ func highlevelFilesystemTest(t *testing.T, test *Test) {
 	t.Run("WriteRename", testWriteRename)
 	t.Run("WriteRenameDir", testWriteRenameDir)
 	t.Run("RemoveClose", testRemoveClose)
+	t.Run("UnregisterRegister", testUnregisterRegister)
+	t.Run("PhysicalMove", testPhysicalMove)
+	t.Run("ChecksumDir", testChecksumDir)
+	t.Run("ChecksumDirMismatch", testChecksumDirMismatch)
+	t.Run("BufferedReadWrite", testBufferedReadWrite)
+	t.Run("ConcurrentReadAt", testConcurrentReadAt)
+	t.Run("IOFS", testIOFS)
+	t.Run("WritableFS", testWritableFS)
 }
 
 func testMakeDir(t *testing.T) {
@@ -666,3 +679,659 @@ func testRemoveClose(t *testing.T) {
 
 	assert.False(t, filesystem.Exists(irodsPath))
 }
+
+func testUnregisterRegister(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	filename := "testregister.bin"
+	irodsPath := homeDir + "/" + filename
+
+	text := "HELLO WORLD"
+
+	fileHandle, err := filesystem.CreateFile(irodsPath, "", "w")
+	FailError(t, err)
+
+	_, err = fileHandle.Write([]byte(text))
+	FailError(t, err)
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	stat, err := filesystem.Stat(irodsPath)
+	FailError(t, err)
+
+	if !assert.NotEmpty(t, stat.IRODSReplicas) {
+		t.FailNow()
+	}
+
+	replica := stat.IRODSReplicas[0]
+
+	// unregister, the physical file remains
+	err = filesystem.UnregisterFile(irodsPath)
+	FailError(t, err)
+
+	assert.False(t, filesystem.Exists(irodsPath))
+
+	// register it back
+	err = filesystem.RegisterFile(replica.Path, irodsPath, replica.ResourceName, false, false, true, false)
+	FailError(t, err)
+
+	assert.True(t, filesystem.Exists(irodsPath))
+
+	stat, err = filesystem.Stat(irodsPath)
+	FailError(t, err)
+
+	assert.Equal(t, int64(len(text)), stat.Size)
+	assert.NotEmpty(t, stat.CheckSum)
+
+	err = filesystem.RemoveFile(irodsPath, true)
+	FailError(t, err)
+
+	assert.False(t, filesystem.Exists(irodsPath))
+}
+
+// createTestResource creates a unixfilesystem resource next to the given resource, like iadmin mkresc
+func createTestResource(t *testing.T, filesystem *fs.FileSystem, baseResource string, name string) {
+	conn, err := filesystem.GetMetadataConnection(false)
+	FailError(t, err)
+	defer filesystem.ReturnMetadataConnection(conn) //nolint
+
+	resc, err := irods_fs.GetResource(conn, baseResource)
+	FailError(t, err)
+
+	vaultPath := path.Join(path.Dir(resc.Path), name+"Vault")
+
+	conn.Lock()
+	defer conn.Unlock()
+
+	req := message.NewIRODSMessageAdminRequest("add", "resource", name, "unixfilesystem", fmt.Sprintf("%s:%s", resc.Location, vaultPath), "", resc.Zone)
+	err = conn.RequestAndCheck(req, &message.IRODSMessageAdminResponse{}, nil, conn.GetOperationTimeout())
+	FailError(t, err)
+}
+
+// removeTestResource removes the resource created by createTestResource, like iadmin rmresc
+func removeTestResource(t *testing.T, filesystem *fs.FileSystem, name string) {
+	conn, err := filesystem.GetMetadataConnection(false)
+	FailError(t, err)
+	defer filesystem.ReturnMetadataConnection(conn) //nolint
+
+	conn.Lock()
+	defer conn.Unlock()
+
+	req := message.NewIRODSMessageAdminRequest("rm", "resource", name)
+	err = conn.RequestAndCheck(req, &message.IRODSMessageAdminResponse{}, nil, conn.GetOperationTimeout())
+	FailError(t, err)
+}
+
+func testPhysicalMove(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	srcResource := server.GetInfo().Resource
+	destResource := "phymvTestResc"
+
+	createTestResource(t, filesystem, srcResource, destResource)
+	defer removeTestResource(t, filesystem, destResource)
+
+	text := "HELLO WORLD"
+
+	dirPath := homeDir + "/phymv_test_dir"
+	err = filesystem.MakeDir(dirPath, true)
+	FailError(t, err)
+
+	filePaths := []string{
+		homeDir + "/phymv_test.txt",
+		dirPath + "/phymv_test1.txt",
+		dirPath + "/phymv_test2.txt",
+	}
+
+	for _, filePath := range filePaths {
+		fileHandle, err := filesystem.CreateFile(filePath, srcResource, "w")
+		FailError(t, err)
+
+		_, err = fileHandle.Write([]byte(text))
+		FailError(t, err)
+
+		err = fileHandle.Close()
+		FailError(t, err)
+
+		// cache the entry before move
+		entry, err := filesystem.Stat(filePath)
+		FailError(t, err)
+		assert.True(t, entry.HasReplicaOnResource(srcResource))
+		assert.False(t, entry.HasReplicaOnResource(destResource))
+	}
+
+	assertMoved := func(filePath string) {
+		entry, err := filesystem.Stat(filePath)
+		FailError(t, err)
+
+		assert.Len(t, entry.IRODSReplicas, 1)
+		assert.False(t, entry.HasReplicaOnResource(srcResource))
+		assert.True(t, entry.HasReplicaOnResource(destResource))
+		assert.Equal(t, int64(len(text)), entry.Size)
+	}
+
+	// single file
+	err = filesystem.PhysicalMoveFile(filePaths[0], srcResource, destResource, false)
+	FailError(t, err)
+
+	assertMoved(filePaths[0])
+
+	// dir, with admin flag
+	err = filesystem.PhysicalMoveDir(dirPath, srcResource, destResource, true)
+	FailError(t, err)
+
+	assertMoved(filePaths[1])
+	assertMoved(filePaths[2])
+
+	// content is kept
+	for _, filePath := range filePaths {
+		fileHandle, err := filesystem.OpenFile(filePath, "", "r")
+		FailError(t, err)
+
+		buffer := make([]byte, len(text))
+		_, err = io.ReadFull(fileHandle, buffer)
+		FailError(t, err)
+
+		err = fileHandle.Close()
+		FailError(t, err)
+
+		assert.Equal(t, text, string(buffer))
+	}
+
+	// remove data on the resource before removing the resource
+	err = filesystem.RemoveFile(filePaths[0], true)
+	FailError(t, err)
+
+	err = filesystem.RemoveDir(dirPath, true, true)
+	FailError(t, err)
+}
+
+func testChecksumDir(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	fileSize := int64(1024 * 1024) // 1MB
+	localPath, err := CreateLocalTestFile(t, "test_file_", fileSize)
+	FailError(t, err)
+	defer func() {
+		err = os.Remove(localPath)
+		FailError(t, err)
+	}()
+
+	checksumDir := homeDir + "/test_checksum_dir"
+	subDir := checksumDir + "/sub"
+
+	err = filesystem.MakeDir(subDir, true)
+	FailError(t, err)
+
+	defer func() {
+		err = filesystem.RemoveDir(checksumDir, true, true)
+		FailError(t, err)
+	}()
+
+	irodsPaths := []string{
+		checksumDir + "/" + path.Base(localPath),
+		subDir + "/" + path.Base(localPath),
+	}
+
+	for _, irodsPath := range irodsPaths {
+		_, err = filesystem.UploadFile(localPath, irodsPath, "", false, false, nil)
+		FailError(t, err)
+	}
+
+	// compute
+	result, err := filesystem.ChecksumDir(checksumDir, "", true, false, false, false)
+	FailError(t, err)
+
+	assert.Len(t, result.Files, len(irodsPaths))
+	assert.Empty(t, result.GetMismatches())
+	assert.Empty(t, result.GetFailures())
+
+	for _, fileResult := range result.Files {
+		assert.Contains(t, irodsPaths, fileResult.IRODSPath)
+		if assert.NotNil(t, fileResult.Checksum) {
+			assert.NotEmpty(t, fileResult.Checksum.Checksum)
+		}
+	}
+
+	stat, err := filesystem.Stat(irodsPaths[0])
+	FailError(t, err)
+	assert.NotEmpty(t, stat.CheckSum)
+
+	// verify
+	result, err = filesystem.ChecksumDir(checksumDir, "", false, true, true, false)
+	FailError(t, err)
+
+	assert.Len(t, result.Files, len(irodsPaths))
+	assert.Empty(t, result.GetMismatches())
+	assert.Empty(t, result.GetFailures())
+}
+
+func testChecksumDirMismatch(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	checksumDir := homeDir + "/test_checksum_mismatch_dir"
+
+	err = filesystem.MakeDir(checksumDir, true)
+	FailError(t, err)
+
+	defer func() {
+		err = filesystem.RemoveDir(checksumDir, true, true)
+		FailError(t, err)
+	}()
+
+	goodPath := checksumDir + "/good.txt"
+	corruptPath := checksumDir + "/corrupt.txt"
+
+	for _, irodsPath := range []string{goodPath, corruptPath} {
+		fileHandle, err := filesystem.CreateFile(irodsPath, "", "w")
+		FailError(t, err)
+
+		_, err = fileHandle.Write([]byte("HELLO WORLD"))
+		FailError(t, err)
+
+		err = fileHandle.Close()
+		FailError(t, err)
+	}
+
+	// register checksums
+	result, err := filesystem.ChecksumDir(checksumDir, "", true, false, false, false)
+	FailError(t, err)
+	assert.Empty(t, result.GetMismatches())
+	assert.Empty(t, result.GetFailures())
+
+	stat, err := filesystem.Stat(corruptPath)
+	FailError(t, err)
+
+	if !assert.NotEmpty(t, stat.IRODSReplicas) {
+		t.FailNow()
+	}
+
+	replica := stat.IRODSReplicas[0]
+
+	// corrupt the replica, the physical file is registered at another path and overwritten with the same size
+	aliasPath := homeDir + "/test_checksum_mismatch_alias.txt"
+
+	err = filesystem.RegisterFile(replica.Path, aliasPath, replica.ResourceName, false, false, false, false)
+	FailError(t, err)
+
+	fileHandle, err := filesystem.OpenFile(aliasPath, "", string(types.FileOpenModeReadWrite))
+	FailError(t, err)
+
+	_, err = fileHandle.Write([]byte("HELLO IRODS"))
+	FailError(t, err)
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	// keep the physical file to be removed with the data object
+	err = filesystem.UnregisterFile(aliasPath)
+	FailError(t, err)
+
+	// verify
+	result, err = filesystem.ChecksumDir(checksumDir, "", false, false, true, false)
+	FailError(t, err)
+
+	assert.Len(t, result.Files, 2)
+
+	mismatches := result.GetMismatches()
+	if assert.Len(t, mismatches, 1) {
+		assert.Equal(t, corruptPath, mismatches[0].IRODSPath)
+		assert.True(t, mismatches[0].Mismatch)
+		assert.Error(t, mismatches[0].Error)
+	}
+}
+
+func testBufferedReadWrite(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	irodsPath := homeDir + "/testbuffered.bin"
+
+	data := make([]byte, 1024*1024)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+
+	bufferConfig := &fs.FileHandleBufferConfig{
+		ReadAheadSize:   64 * 1024,
+		WriteBehindSize: 64 * 1024,
+		AsyncPrefetch:   true,
+	}
+
+	fileHandle, err := filesystem.CreateFile(irodsPath, "", "w")
+	FailError(t, err)
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	// write in small chunks
+	fileHandle, err = filesystem.OpenFileBuffered(irodsPath, "", "w", bufferConfig)
+	FailError(t, err)
+	assert.True(t, fileHandle.IsBuffered())
+
+	chunkSize := 4 * 1024
+	for offset := 0; offset < len(data); offset += chunkSize {
+		writeLen, err := fileHandle.Write(data[offset : offset+chunkSize])
+		FailError(t, err)
+		assert.Equal(t, chunkSize, writeLen)
+	}
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	stat, err := filesystem.Stat(irodsPath)
+	FailError(t, err)
+	assert.Equal(t, int64(len(data)), stat.Size)
+
+	// sequential read in small chunks
+	fileHandle, err = filesystem.OpenFileBuffered(irodsPath, "", "r", bufferConfig)
+	FailError(t, err)
+
+	readData := bytes.Buffer{}
+	buffer := make([]byte, chunkSize)
+	for {
+		readLen, err := fileHandle.Read(buffer)
+		readData.Write(buffer[:readLen])
+		if err == io.EOF {
+			break
+		}
+		FailError(t, err)
+	}
+
+	assert.Equal(t, data, readData.Bytes())
+
+	// random read
+	for _, offset := range []int64{512 * 1024, 1000, 900 * 1024, 0} {
+		readLen, err := fileHandle.ReadAt(buffer, offset)
+		FailError(t, err)
+		assert.Equal(t, chunkSize, readLen)
+		assert.Equal(t, data[offset:offset+int64(chunkSize)], buffer[:readLen])
+	}
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	err = filesystem.RemoveFile(irodsPath, true)
+	FailError(t, err)
+}
+
+func testConcurrentReadAt(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	irodsPath := homeDir + "/testconcurrent.bin"
+
+	data := make([]byte, 4*1024*1024)
+	for i := range data {
+		data[i] = byte(i % 253)
+	}
+
+	fileHandle, err := filesystem.CreateFile(irodsPath, "", "w")
+	FailError(t, err)
+
+	_, err = fileHandle.Write(data)
+	FailError(t, err)
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	fileHandle, err = filesystem.OpenFileConcurrentReader(irodsPath, "", 3)
+	FailError(t, err)
+	assert.True(t, fileHandle.IsConcurrentReader())
+
+	chunkSize := 64 * 1024
+	wg := sync.WaitGroup{}
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+
+		go func(offset int64) {
+			defer wg.Done()
+
+			buffer := make([]byte, chunkSize)
+			readLen, err := fileHandle.ReadAt(buffer, offset)
+			assert.NoError(t, err)
+			assert.Equal(t, chunkSize, readLen)
+			assert.Equal(t, data[offset:offset+int64(chunkSize)], buffer[:readLen])
+		}(int64(i) * 256 * 1024)
+	}
+
+	wg.Wait()
+
+	// read beyond EOF
+	buffer := make([]byte, chunkSize)
+	readLen, err := fileHandle.ReadAt(buffer, int64(len(data)-1024))
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, 1024, readLen)
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	err = filesystem.RemoveFile(irodsPath, true)
+	FailError(t, err)
+}
+
+func testIOFS(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	rootPath := homeDir + "/testiofs"
+
+	err = filesystem.MakeDir(rootPath+"/subdir", true)
+	FailError(t, err)
+
+	files := map[string]string{
+		"file1.txt":        "hello world",
+		"file2.bin":        "binary data",
+		"subdir/file3.txt": "nested file",
+	}
+
+	for name, content := range files {
+		fileHandle, err := filesystem.CreateFile(rootPath+"/"+name, "", "w")
+		FailError(t, err)
+
+		_, err = fileHandle.Write([]byte(content))
+		FailError(t, err)
+
+		err = fileHandle.Close()
+		FailError(t, err)
+	}
+
+	iofs := fs.NewIOFS(filesystem, rootPath)
+
+	err = fstest.TestFS(iofs, "file1.txt", "file2.bin", "subdir/file3.txt")
+	assert.NoError(t, err)
+
+	// Sys() exposes the entry
+	fileInfo, err := iofs.Stat("subdir/file3.txt")
+	FailError(t, err)
+
+	entry, ok := fileInfo.Sys().(*fs.Entry)
+	assert.True(t, ok)
+	assert.Equal(t, rootPath+"/subdir/file3.txt", entry.Path)
+	assert.NotEmpty(t, entry.Owner)
+	assert.NotEmpty(t, entry.IRODSReplicas)
+
+	// not exist
+	_, err = iofs.Stat("no_such_file")
+	assert.ErrorIs(t, err, io_fs.ErrNotExist)
+
+	// glob
+	matches, err := iofs.Glob("*.txt")
+	FailError(t, err)
+	assert.Equal(t, []string{"file1.txt"}, matches)
+
+	err = filesystem.RemoveDir(rootPath, true, true)
+	FailError(t, err)
+}
+
+func testWritableFS(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	rootPath := homeDir + "/testwritablefs"
+
+	err = filesystem.MakeDir(rootPath, true)
+	FailError(t, err)
+
+	wfs := fs.NewWritableFS(filesystem, rootPath)
+
+	// mkdir
+	err = wfs.MkdirAll("/a/b", 0o755)
+	FailError(t, err)
+
+	err = wfs.Mkdir("/a", 0o755)
+	assert.ErrorIs(t, err, os.ErrExist)
+
+	err = wfs.Mkdir("/x/y", 0o755)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	// create and write
+	file, err := wfs.Create("/a/b/file.txt")
+	FailError(t, err)
+
+	_, err = file.WriteString("hello world")
+	FailError(t, err)
+
+	err = file.Close()
+	FailError(t, err)
+
+	// open without O_CREATE
+	_, err = wfs.OpenFile("/a/no_such_file", os.O_RDWR, 0o644)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	// exclusive create
+	_, err = wfs.OpenFile("/a/b/file.txt", os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o644)
+	assert.ErrorIs(t, err, os.ErrExist)
+
+	// append
+	file, err = wfs.OpenFile("/a/b/file.txt", os.O_WRONLY|os.O_APPEND, 0o644)
+	FailError(t, err)
+
+	_, err = file.WriteString("!")
+	FailError(t, err)
+
+	err = file.Close()
+	FailError(t, err)
+
+	// read
+	file, err = wfs.Open("/a/b/file.txt")
+	FailError(t, err)
+
+	data, err := io.ReadAll(file)
+	FailError(t, err)
+	assert.Equal(t, "hello world!", string(data))
+
+	err = file.Close()
+	FailError(t, err)
+
+	// readdir
+	dir, err := wfs.Open("/a/b")
+	FailError(t, err)
+
+	names, err := dir.Readdirnames(0)
+	FailError(t, err)
+	assert.Equal(t, []string{"file.txt"}, names)
+
+	err = dir.Close()
+	FailError(t, err)
+
+	// chtimes
+	mtime := time.Unix(1600000000, 0)
+	err = wfs.Chtimes("/a/b/file.txt", mtime, mtime)
+	FailError(t, err)
+
+	fileInfo, err := wfs.Stat("/a/b/file.txt")
+	FailError(t, err)
+	assert.Equal(t, mtime.Unix(), fileInfo.ModTime().Unix())
+
+	// rename replaces the existing file
+	file, err = wfs.Create("/a/other.txt")
+	FailError(t, err)
+
+	err = file.Close()
+	FailError(t, err)
+
+	err = wfs.Rename("/a/b/file.txt", "/a/other.txt")
+	FailError(t, err)
+
+	fileInfo, err = wfs.Stat("/a/other.txt")
+	FailError(t, err)
+	assert.Equal(t, int64(12), fileInfo.Size())
+
+	_, err = wfs.Stat("/a/b/file.txt")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	// remove
+	err = wfs.Remove("/a")
+	assert.Error(t, err)
+
+	err = wfs.RemoveAll("/a")
+	FailError(t, err)
+
+	err = wfs.RemoveAll("/a")
+	assert.NoError(t, err)
+
+	err = filesystem.RemoveDir(rootPath, true, true)
+	FailError(t, err)
+}
@@ -1,9 +1,13 @@
 package testcases
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/cyverse/go-irodsclient/irods/connection"
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -19,6 +23,9 @@ func lowlevelConnectionTest(t *testing.T, test *Test) {
 	t.Run("Connection", testConnection)
 	t.Run("InvalidUsername", testInvalidUsername)
 	t.Run("ManyConnections", testManyConnections)
+	t.Run("ConnectionContextCancel", testConnectionContextCancel)
+	t.Run("CustomAuthScheme", testCustomAuthScheme)
+	t.Run("UnregisterAuthSchemeCaseInsensitive", testUnregisterAuthSchemeCaseInsensitive)
 }
 
 func testConnection(t *testing.T) {
@@ -80,3 +87,125 @@ func testManyConnections(t *testing.T) {
 		t.Logf("Connection %d: %s %s", i, conn.GetVersion().ReleaseVersion, conn.GetVersion().APIVersion)
 	}
 }
+
+func testConnectionContextCancel(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	account, err := server.GetAccount()
+	FailError(t, err)
+
+	// connect with a cancelled context
+	cancelledCtx, cancelFunc := context.WithCancel(context.Background())
+	cancelFunc()
+
+	conn, err := connection.NewIRODSConnection(account, server.GetConnectionConfig())
+	FailError(t, err)
+
+	err = conn.ConnectContext(cancelledCtx)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.False(t, conn.IsConnected())
+
+	// cancel after connect
+	conn, err = connection.NewIRODSConnection(account, server.GetConnectionConfig())
+	FailError(t, err)
+
+	err = conn.Connect()
+	FailError(t, err)
+	defer func() {
+		_ = conn.Disconnect()
+	}()
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	conn.SetContext(ctx)
+
+	homeDir := account.GetHomeDirPath()
+	_, err = irods_fs.GetCollection(conn, homeDir)
+	FailError(t, err)
+
+	cancelFunc()
+
+	_, err = irods_fs.GetCollection(conn, homeDir)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.True(t, conn.IsSocketFailed())
+}
+
+func testCustomAuthScheme(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	account, err := server.GetAccount()
+	FailError(t, err)
+
+	customScheme := types.AuthScheme("test_custom")
+
+	assert.Equal(t, types.AuthSchemeUnknown, types.GetAuthScheme(string(customScheme)))
+
+	// custom scheme that authenticates with native auth
+	pluginCalled := 0
+	legacyCalled := 0
+	pluginFactory := func(conn *connection.IRODSConnection) (connection.IRODSAuthPlugin, *connection.IRODSAuthContext, error) {
+		pluginCalled++
+
+		authContext := connection.NewIRODSAuthContext()
+		authContext.Set("password", account.Password)
+		authContext.Set(connection.AUTH_TTL_KEY, "0")
+		return connection.NewNativeAuthPlugin(), authContext, nil
+	}
+
+	legacy := func(conn *connection.IRODSConnection) error {
+		legacyCalled++
+		return connection.AuthenticateNative(conn, account.Password)
+	}
+
+	err = connection.RegisterAuthScheme(customScheme, nil, nil)
+	assert.Error(t, err)
+
+	err = connection.RegisterAuthScheme(customScheme, pluginFactory, legacy)
+	FailError(t, err)
+	defer connection.UnregisterAuthScheme(customScheme)
+
+	assert.Equal(t, customScheme, types.GetAuthScheme(" TEST_CUSTOM "))
+
+	_, ok := connection.GetAuthSchemeHandler(customScheme)
+	assert.True(t, ok)
+
+	if account.CSNegotiationPolicy != types.CSNegotiationPolicyRequestSSL {
+		// non-native auth schemes require SSL
+		return
+	}
+
+	account.AuthenticationScheme = customScheme
+
+	conn, err := connection.NewIRODSConnection(account, server.GetConnectionConfig())
+	FailError(t, err)
+
+	err = conn.Connect()
+	FailError(t, err)
+	defer func() {
+		_ = conn.Disconnect()
+	}()
+
+	assert.Equal(t, 1, pluginCalled+legacyCalled)
+}
+
+func testUnregisterAuthSchemeCaseInsensitive(t *testing.T) {
+	legacy := func(conn *connection.IRODSConnection) error {
+		return nil
+	}
+
+	err := connection.RegisterAuthScheme(types.AuthScheme("Test_Case"), nil, legacy)
+	FailError(t, err)
+
+	_, ok := connection.GetAuthSchemeHandler(types.AuthScheme("test_case"))
+	assert.True(t, ok)
+
+	// unregistered with a name in different case
+	connection.UnregisterAuthScheme(types.AuthScheme(" TEST_CASE "))
+
+	_, ok = connection.GetAuthSchemeHandler(types.AuthScheme("test_case"))
+	assert.False(t, ok)
+	assert.Equal(t, types.AuthSchemeUnknown, types.GetAuthScheme("test_case"))
+}
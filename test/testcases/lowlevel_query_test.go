@@ -0,0 +1,259 @@
+package testcases
+
+import (
+	"fmt"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	"github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func getLowlevelQueryTest() Test {
+	return Test{
+		Name: "Lowlevel_Query",
+		Func: lowlevelQueryTest,
+	}
+}
+
+func lowlevelQueryTest(t *testing.T, test *Test) {
+	t.Run("GenQuery2Builder", testGenQuery2Builder)
+	t.Run("ExecuteGenQuery2", testExecuteGenQuery2)
+	t.Run("GenQueryBuilder", testGenQueryBuilder)
+	t.Run("ExecuteGenQuery", testExecuteGenQuery)
+	t.Run("SpecificQuery", testSpecificQuery)
+}
+
+func testGenQuery2Builder(t *testing.T) {
+	query := fs.NewGenQuery2("COLL_NAME", "DATA_NAME").
+		Where("COLL_NAME", fs.GenQuery2OperatorLike, "/zone/home/%").
+		Where("DATA_NAME", fs.GenQuery2OperatorEqual, "it's").
+		OrderBy("DATA_NAME", true).
+		Offset(5).
+		Limit(10)
+
+	assert.Equal(t, `select COLL_NAME, DATA_NAME where COLL_NAME like '/zone/home/%' and DATA_NAME = 'it\'s' order by DATA_NAME desc offset 5 limit 10`, query.Build())
+
+	query = fs.NewGenQuery2("count(DATA_ID)", "RESC_NAME").NoDistinct().WhereIn("RESC_NAME", "a", "b").GroupBy("RESC_NAME")
+	assert.Equal(t, `select no distinct count(DATA_ID), RESC_NAME where RESC_NAME in ('a', 'b') group by RESC_NAME`, query.Build())
+}
+
+func testGenQueryBuilder(t *testing.T) {
+	query := fs.NewGenQuery().
+		Select(common.ICAT_COLUMN_COLL_NAME, common.ICAT_COLUMN_DATA_NAME).
+		Where(common.ICAT_COLUMN_COLL_NAME, fs.GenQueryOperatorEqual, "/zone/home/test").
+		WhereIn(common.ICAT_COLUMN_DATA_NAME, "a", "b").
+		WhereBetween(common.ICAT_COLUMN_DATA_SIZE, "1", "100")
+	FailError(t, query.Err())
+
+	request := query.GetRequest(0)
+	assert.Equal(t, 3, request.Conditions.Length)
+
+	// single quotes can't be escaped in GenQuery, they must not change the condition
+	query = fs.NewGenQuery().
+		Select(common.ICAT_COLUMN_DATA_NAME).
+		Where(common.ICAT_COLUMN_DATA_NAME, fs.GenQueryOperatorEqual, "o'brien")
+	assert.Error(t, query.Err())
+	assert.Equal(t, 0, query.GetRequest(0).Conditions.Length)
+
+	query = fs.NewGenQuery().
+		Select(common.ICAT_COLUMN_DATA_NAME).
+		WhereIn(common.ICAT_COLUMN_DATA_NAME, "a", "b' || = 'c")
+	assert.Error(t, query.Err())
+	assert.Equal(t, 0, query.GetRequest(0).Conditions.Length)
+
+	query = fs.NewGenQuery().
+		Select(common.ICAT_COLUMN_DATA_NAME).
+		WhereBetween(common.ICAT_COLUMN_DATA_NAME, "a", "z'")
+	assert.Error(t, query.Err())
+	assert.Equal(t, 0, query.GetRequest(0).Conditions.Length)
+
+	// query having invalid condition fails before sending a request
+	err := fs.ExecuteGenQuery(nil, query, func(row fs.GenQueryRow) error {
+		return nil
+	})
+	assert.Error(t, err)
+	assert.ErrorContains(t, err, "single quotes")
+}
+
+func testExecuteGenQuery2(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	account, err := server.GetAccount()
+	FailError(t, err)
+
+	conn, err := connection.NewIRODSConnection(account, server.GetConnectionConfig())
+	FailError(t, err)
+
+	err = conn.Connect()
+	FailError(t, err)
+	defer func() {
+		_ = conn.Disconnect()
+	}()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	query := fs.NewGenQuery2("COLL_NAME").Where("COLL_NAME", fs.GenQuery2OperatorEqual, homeDir)
+
+	if !conn.SupportGenQuery2() {
+		_, err = fs.ExecuteGenQuery2(conn, query.Build())
+		assert.Error(t, err)
+		assert.True(t, types.IsAPINotSupportedError(err))
+		return
+	}
+
+	rows, err := fs.ExecuteGenQuery2(conn, query.Build())
+	FailError(t, err)
+
+	if assert.Len(t, rows, 1) {
+		assert.Equal(t, []string{homeDir}, rows[0])
+	}
+
+	sql, err := fs.GetGenQuery2SQL(conn, query.Build())
+	FailError(t, err)
+	assert.NotEmpty(t, sql)
+}
+
+func testExecuteGenQuery(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	account, err := server.GetAccount()
+	FailError(t, err)
+
+	conn, err := connection.NewIRODSConnection(account, server.GetConnectionConfig())
+	FailError(t, err)
+
+	err = conn.Connect()
+	FailError(t, err)
+	defer func() {
+		_ = conn.Disconnect()
+	}()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	numObjects := 5
+	for i := 0; i < numObjects; i++ {
+		objPath := path.Join(homeDir, fmt.Sprintf("genquery_test_%d", i))
+
+		handle, err := fs.CreateDataObject(conn, objPath, "", "w", true, map[common.KeyWord]string{})
+		FailError(t, err)
+
+		err = fs.CloseDataObject(conn, handle)
+		FailError(t, err)
+	}
+
+	query := fs.NewGenQuery().
+		Select(common.ICAT_COLUMN_D_DATA_ID, common.ICAT_COLUMN_DATA_NAME, common.ICAT_COLUMN_D_CREATE_TIME).
+		Where(common.ICAT_COLUMN_COLL_NAME, fs.GenQueryOperatorEqual, homeDir).
+		Where(common.ICAT_COLUMN_DATA_NAME, fs.GenQueryOperatorLike, "genquery_test_%").
+		OrderBy(common.ICAT_COLUMN_DATA_NAME, true).
+		MaxRows(2)
+
+	type dataObject struct {
+		ID         int64     `irods:"D_DATA_ID"`
+		Name       string    `irods:"DATA_NAME"`
+		CreateTime time.Time `irods:"D_CREATE_TIME"`
+	}
+
+	objects := []dataObject{}
+	err = fs.ExecuteGenQuery(conn, query, func(row fs.GenQueryRow) error {
+		obj := dataObject{}
+		err := row.Scan(&obj)
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, obj)
+		return nil
+	})
+	FailError(t, err)
+
+	if assert.Len(t, objects, numObjects) {
+		assert.Equal(t, fmt.Sprintf("genquery_test_%d", numObjects-1), objects[0].Name)
+		assert.Greater(t, objects[0].ID, int64(0))
+		assert.False(t, objects[0].CreateTime.IsZero())
+	}
+
+	// stop early
+	count := 0
+	err = fs.ExecuteGenQuery(conn, query, func(row fs.GenQueryRow) error {
+		count++
+		if count == 3 {
+			return fs.ErrStopGenQuery
+		}
+		return nil
+	})
+	FailError(t, err)
+	assert.Equal(t, 3, count)
+
+	// aggregate
+	countQuery := fs.NewGenQuery().
+		SelectAggregate(common.ICAT_COLUMN_D_DATA_ID, common.GENQUERY_SELECT_COUNT).
+		Where(common.ICAT_COLUMN_COLL_NAME, fs.GenQueryOperatorEqual, homeDir)
+
+	rows, err := fs.ListGenQuery(conn, countQuery)
+	FailError(t, err)
+
+	if assert.Len(t, rows, 1) {
+		assert.Equal(t, fmt.Sprintf("%d", numObjects), rows[0][common.ICAT_COLUMN_D_DATA_ID])
+	}
+}
+
+func testSpecificQuery(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	account, err := server.GetAccount()
+	FailError(t, err)
+
+	conn, err := connection.NewIRODSConnection(account, server.GetConnectionConfig())
+	FailError(t, err)
+
+	err = conn.Connect()
+	FailError(t, err)
+	defer func() {
+		_ = conn.Disconnect()
+	}()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	alias := fmt.Sprintf("test_sq_%s", path.Base(homeDir))
+	sql := "select coll_name from R_COLL_MAIN where coll_name = ?"
+
+	err = fs.AddSpecificQuery(conn, alias, sql)
+	FailError(t, err)
+	defer func() {
+		_ = fs.RemoveSpecificQuery(conn, alias)
+	}()
+
+	queries, err := fs.ListSpecificQueriesByAlias(conn, alias)
+	FailError(t, err)
+	if assert.Len(t, queries, 1) {
+		assert.Equal(t, alias, queries[0].Alias)
+		assert.Equal(t, sql, queries[0].SQL)
+	}
+
+	rows, err := fs.ExecuteSpecificQuery(conn, alias, []string{homeDir}, "")
+	FailError(t, err)
+	if assert.Len(t, rows, 1) {
+		assert.Equal(t, []string{homeDir}, rows[0])
+	}
+
+	err = fs.RemoveSpecificQuery(conn, alias)
+	FailError(t, err)
+
+	queries, err = fs.ListSpecificQueries(conn)
+	FailError(t, err)
+	for _, query := range queries {
+		assert.NotEqual(t, alias, query.Alias)
+	}
+}
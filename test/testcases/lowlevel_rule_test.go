@@ -0,0 +1,127 @@
+package testcases
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	"github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func getLowlevelRuleTest() Test {
+	return Test{
+		Name: "Lowlevel_Rule",
+		Func: lowlevelRuleTest,
+	}
+}
+
+func lowlevelRuleTest(t *testing.T, test *Test) {
+	t.Run("ExecuteRule", testExecuteRule)
+	t.Run("DelayRule", testDelayRule)
+}
+
+func testExecuteRule(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	account, err := server.GetAccount()
+	FailError(t, err)
+
+	conn, err := connection.NewIRODSConnection(account, server.GetConnectionConfig())
+	FailError(t, err)
+
+	err = conn.Connect()
+	FailError(t, err)
+	defer func() {
+		_ = conn.Disconnect()
+	}()
+
+	rule := `testRule {
+	writeLine("stdout", "hello *name");
+	writeLine("stderr", "count *count");
+	*out = *name ++ "_out";
+}`
+
+	nameParam, err := types.NewIRODSRuleParameter("*name", "irods")
+	FailError(t, err)
+
+	countParam, err := types.NewIRODSRuleParameter("*count", 3)
+	FailError(t, err)
+
+	result, err := fs.ExecuteRule(conn, rule, []*types.IRODSRuleParameter{nameParam, countParam}, []string{"*out", "ruleExecOut"}, types.RuleEngineInstanceNative)
+	FailError(t, err)
+
+	outParam := result.GetParameter("*out")
+	if assert.NotNil(t, outParam) {
+		assert.Equal(t, types.RuleParameterTypeString, outParam.Type)
+		assert.Equal(t, "irods_out", outParam.GetString())
+	}
+
+	assert.Equal(t, "hello irods", strings.TrimSpace(result.GetStdout()))
+	assert.Equal(t, "count 3", strings.TrimSpace(result.GetStderr()))
+}
+
+func testDelayRule(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	account, err := server.GetAccount()
+	FailError(t, err)
+
+	conn, err := connection.NewIRODSConnection(account, server.GetConnectionConfig())
+	FailError(t, err)
+
+	err = conn.Connect()
+	FailError(t, err)
+	defer func() {
+		_ = conn.Disconnect()
+	}()
+
+	rule := `testDelayRule {
+	delay("<PLUSET>1h</PLUSET>") {
+		writeLine("serverLog", "delayed rule for test");
+	}
+}`
+
+	_, err = fs.ExecuteRule(conn, rule, nil, nil, types.RuleEngineInstanceNative)
+	FailError(t, err)
+
+	rules, err := fs.ListDelayRulesForUser(conn, account.ClientUser)
+	FailError(t, err)
+	assert.GreaterOrEqual(t, len(rules), 1)
+
+	var delayRule *types.IRODSDelayRule
+	for _, r := range rules {
+		if strings.Contains(r.Name, "delayed rule for test") {
+			delayRule = r
+			break
+		}
+	}
+	if !assert.NotNil(t, delayRule) {
+		t.FailNow()
+	}
+
+	// modify
+	newTime := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+	err = fs.ModifyDelayRuleExecutionTime(conn, delayRule.ID, newTime)
+	FailError(t, err)
+
+	err = fs.ModifyDelayRuleFrequency(conn, delayRule.ID, "1h REPEAT FOR EVER")
+	FailError(t, err)
+
+	modifiedRule, err := fs.GetDelayRule(conn, delayRule.ID)
+	FailError(t, err)
+	assert.Equal(t, newTime.Unix(), modifiedRule.ExecutionTime.Unix())
+	assert.Equal(t, "1h REPEAT FOR EVER", modifiedRule.Frequency)
+
+	// delete
+	err = fs.DeleteDelayRule(conn, delayRule.ID)
+	FailError(t, err)
+
+	_, err = fs.GetDelayRule(conn, delayRule.ID)
+	assert.Error(t, err)
+	assert.True(t, types.IsDelayRuleNotFoundError(err))
+}
@@ -144,12 +144,20 @@ func TestLocalMain(t *testing.T) {
 	// Add all test cases here
 	tests = append(tests, getUtilEncodingTest())
 	tests = append(tests, getTypeDurationTest())
+	tests = append(tests, getTypeSSLConfigTest())
+	tests = append(tests, getTypeTokenSourceTest())
+	tests = append(tests, getAuthGSITest())
+	tests = append(tests, getAuthKRBTest())
+	tests = append(tests, getAuthOpenIDTest())
+	tests = append(tests, getAuthPAMInteractiveTest())
 	tests = append(tests, getUtilErrorTest())
 	tests = append(tests, getUtilEnvironmentTest())
 	tests = append(tests, getUtilPasswordObfuscationTest())
 	tests = append(tests, getLowlevelConnectionTest())
 	tests = append(tests, getLowlevelSessionTest())
 	tests = append(tests, getLowlevelProcessTest())
+	tests = append(tests, getLowlevelRuleTest())
+	tests = append(tests, getLowlevelQueryTest())
 	tests = append(tests, getLowlevelUserTest())
 	tests = append(tests, getLowlevelLockTest())
 	tests = append(tests, getLowlevelFileTransferTest())
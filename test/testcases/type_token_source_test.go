@@ -0,0 +1,148 @@
+package testcases
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func getTypeTokenSourceTest() Test {
+	return Test{
+		Name: "Type_TokenSource",
+		Func: typeTokenSourceTest,
+	}
+}
+
+func typeTokenSourceTest(t *testing.T, test *Test) {
+	t.Run("ReuseTokenSource", testReuseTokenSource)
+	t.Run("ReuseTokenSourceInvalidate", testReuseTokenSourceInvalidate)
+	t.Run("AccountAuthToken", testAccountAuthToken)
+}
+
+// newCountingTokenSource returns a token source that issues a new token for each call
+func newCountingTokenSource(lifetime time.Duration) (types.TokenSource, *int) {
+	count := 0
+	source := types.TokenSourceFunc(func() (*types.AuthToken, error) {
+		count++
+		return &types.AuthToken{
+			AccessToken: fmt.Sprintf("token%d", count),
+			Expiry:      time.Now().Add(lifetime),
+		}, nil
+	})
+
+	return source, &count
+}
+
+func testReuseTokenSource(t *testing.T) {
+	// long-lived token is reused
+	source, count := newCountingTokenSource(1 * time.Hour)
+	reuseSource := types.NewReuseTokenSource(source, types.TokenExpiryMarginDefault)
+
+	token1, err := reuseSource.Token()
+	FailError(t, err)
+
+	token2, err := reuseSource.Token()
+	FailError(t, err)
+
+	assert.Equal(t, "token1", token1.AccessToken)
+	assert.Equal(t, token1.AccessToken, token2.AccessToken)
+	assert.Equal(t, 1, *count)
+
+	// token expiring within the margin is refreshed
+	source, count = newCountingTokenSource(10 * time.Second)
+	reuseSource = types.NewReuseTokenSource(source, types.TokenExpiryMarginDefault)
+
+	token1, err = reuseSource.Token()
+	FailError(t, err)
+
+	token2, err = reuseSource.Token()
+	FailError(t, err)
+
+	assert.NotEqual(t, token1.AccessToken, token2.AccessToken)
+	assert.Equal(t, 2, *count)
+
+	// expired token from the source is an error
+	expiredSource := types.NewStaticTokenSource(&types.AuthToken{
+		AccessToken: "expired",
+		Expiry:      time.Now().Add(-1 * time.Minute),
+	})
+	reuseSource = types.NewReuseTokenSource(expiredSource, 0)
+
+	_, err = reuseSource.Token()
+	assert.Error(t, err)
+}
+
+func testReuseTokenSourceInvalidate(t *testing.T) {
+	source, count := newCountingTokenSource(1 * time.Hour)
+	reuseSource := types.NewReuseTokenSource(source, types.TokenExpiryMarginDefault)
+
+	token1, err := reuseSource.Token()
+	FailError(t, err)
+
+	reuseSource.Invalidate()
+
+	token2, err := reuseSource.Token()
+	FailError(t, err)
+
+	assert.NotEqual(t, token1.AccessToken, token2.AccessToken)
+	assert.Equal(t, 2, *count)
+}
+
+func testAccountAuthToken(t *testing.T) {
+	account, err := types.CreateIRODSAccount("localhost", 1247, "test", "tempZone", types.AuthSchemeOpenID, "", "")
+	FailError(t, err)
+
+	assert.Equal(t, types.AuthSchemeOpenID, types.GetAuthScheme("oidc"))
+	assert.True(t, account.AuthenticationScheme.IsSSLRequired())
+	assert.False(t, account.AuthenticationScheme.UsePassword())
+
+	account.SetSSLConfiguration(&types.IRODSSSLConfig{
+		EncryptionKeySize:       32,
+		EncryptionAlgorithm:     "AES-256-CBC",
+		EncryptionSaltSize:      8,
+		EncryptionNumHashRounds: 16,
+	})
+
+	// token is required
+	err = account.Validate()
+	assert.Error(t, err)
+
+	_, err = account.GetAuthToken()
+	assert.Error(t, err)
+	assert.True(t, types.IsAuthError(err))
+
+	// static access token
+	account.AccessToken = "static_token"
+	err = account.Validate()
+	FailError(t, err)
+
+	token, err := account.GetAuthToken()
+	FailError(t, err)
+	assert.Equal(t, "static_token", token.AccessToken)
+
+	redacted := account.GetRedacted()
+	assert.NotEqual(t, account.AccessToken, redacted.AccessToken)
+
+	// token source overrides the static access token
+	source, count := newCountingTokenSource(1 * time.Hour)
+	account.SetTokenSource(source)
+
+	token, err = account.GetAuthToken()
+	FailError(t, err)
+	assert.Equal(t, "token1", token.AccessToken)
+
+	token, err = account.GetAuthToken()
+	FailError(t, err)
+	assert.Equal(t, "token1", token.AccessToken)
+
+	// fresh token after invalidation
+	account.InvalidateAuthToken()
+
+	token, err = account.GetAuthToken()
+	FailError(t, err)
+	assert.Equal(t, "token2", token.AccessToken)
+	assert.Equal(t, 2, *count)
+}
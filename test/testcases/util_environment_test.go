@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/cyverse/go-irodsclient/config"
+	"github.com/cyverse/go-irodsclient/irods/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -20,6 +21,8 @@ func utilEnvironmentTest(t *testing.T, test *Test) {
 	t.Run("SaveAndLoadEnvironment", testSaveAndLoadEnvironment)
 	t.Run("SaveAndLoadSession", testSaveAndLoadSession)
 	t.Run("LoadFilePaths", testLoadFilePaths)
+	t.Run("SaveAndLoadKerberosEnvironment", testSaveAndLoadKerberosEnvironment)
+	t.Run("SavePAMInteractiveSession", testSavePAMInteractiveSession)
 }
 
 func testSaveAndLoadEnvironment(t *testing.T) {
@@ -154,3 +157,124 @@ func testLoadFilePaths(t *testing.T) {
 	assert.Equal(t, envMgr.Environment.AuthenticationFile, envMgr2.Environment.AuthenticationFile)
 	assert.Equal(t, account.Password, envMgr2.Environment.Password)
 }
+
+func testSaveAndLoadKerberosEnvironment(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	account, err := server.GetAccount()
+	FailError(t, err)
+
+	account.AuthenticationScheme = types.AuthSchemeKRB
+	account.Password = ""
+	account.SetKerberosConfiguration(&types.IRODSKerberosConfig{
+		ServicePrincipal:    "irods/irods.example.com@EXAMPLE.COM",
+		Principal:           "rods@EXAMPLE.COM",
+		KeytabFile:          "/tmp/rods.keytab",
+		CredentialCacheFile: "/tmp/krb5cc_rods",
+		ConfigFile:          "/tmp/krb5.conf",
+	})
+
+	assert.False(t, account.AuthenticationScheme.IsSSLRequired())
+	assert.False(t, account.AuthenticationScheme.UsePassword())
+	assert.Equal(t, types.AuthSchemeKRB, types.GetAuthScheme("kerberos"))
+
+	// save
+	envMgr, err := config.NewICommandsEnvironmentManager()
+	FailError(t, err)
+
+	envMgr.FromIRODSAccount(account)
+
+	tempPath := t.TempDir()
+
+	err = envMgr.SetEnvironmentDirPath(tempPath)
+	FailError(t, err)
+
+	err = envMgr.SaveEnvironment()
+	FailError(t, err)
+
+	// krb does not use password file
+	assert.NoFileExists(t, envMgr.PasswordFilePath)
+
+	// load
+	envMgr2, err := config.NewICommandsEnvironmentManager()
+	FailError(t, err)
+
+	err = envMgr2.SetEnvironmentDirPath(tempPath)
+	FailError(t, err)
+
+	err = envMgr2.Load()
+	FailError(t, err)
+
+	account2, err := envMgr2.ToIRODSAccount()
+	FailError(t, err)
+
+	assert.Equal(t, types.AuthSchemeKRB, account2.AuthenticationScheme)
+	assert.Empty(t, account2.Password)
+	assert.Equal(t, *account.KerberosConfiguration, *account2.KerberosConfiguration)
+}
+
+func testSavePAMInteractiveSession(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	account, err := server.GetAccount()
+	FailError(t, err)
+
+	assert.Equal(t, types.AuthSchemePAMInteractive, types.GetAuthScheme("pam_interactive"))
+	assert.True(t, types.AuthSchemePAMInteractive.IsPAM())
+
+	account.AuthenticationScheme = types.AuthSchemePAMInteractive
+	account.Password = ""
+	account.PAMToken = ""
+
+	prompted := false
+	handler := types.NewPAMInteractiveHandler(func(prompt string, sensitive bool) (string, error) {
+		prompted = true
+		return "", nil
+	}, nil)
+	account.SetPAMInteractiveHandler(handler)
+
+	// token issued by pam_interactive auth is kept in the handler
+	handler.SetPAMToken("pam_token_issued")
+	assert.Equal(t, "pam_token_issued", account.GetPAMToken())
+
+	// handler is shared by copies of the account
+	accountCopy := *account
+	assert.Equal(t, "pam_token_issued", accountCopy.GetPAMToken())
+
+	// save
+	envMgr, err := config.NewICommandsEnvironmentManager()
+	FailError(t, err)
+
+	envMgr.FromIRODSAccount(account)
+
+	tempPath := t.TempDir()
+
+	err = envMgr.SetEnvironmentDirPath(tempPath)
+	FailError(t, err)
+
+	err = envMgr.SaveSession()
+	FailError(t, err)
+
+	assert.FileExists(t, envMgr.PasswordFilePath)
+
+	// load
+	envMgr2, err := config.NewICommandsEnvironmentManager()
+	FailError(t, err)
+
+	err = envMgr2.SetEnvironmentDirPath(tempPath)
+	FailError(t, err)
+
+	envMgr2.Environment.AuthenticationScheme = string(types.AuthSchemePAMInteractive)
+
+	err = envMgr2.Load()
+	FailError(t, err)
+
+	account2, err := envMgr2.ToIRODSAccount()
+	FailError(t, err)
+
+	assert.Equal(t, "pam_token_issued", account2.PAMToken)
+	assert.Empty(t, account2.Password)
+	assert.False(t, prompted)
+}